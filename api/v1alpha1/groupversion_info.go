@@ -0,0 +1,37 @@
+/*
+Copyright 2024 The evicted-pod-reaper Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package v1alpha1 contains the API types of the reaper's runtime
+// configuration
+// +kubebuilder:object:generate=true
+// +groupName=pod-reaper.kyos.com
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+var (
+	// GroupVersion is the group and version of the reaper's API types
+	GroupVersion = schema.GroupVersion{Group: "pod-reaper.kyos.com", Version: "v1alpha1"}
+
+	// SchemeBuilder registers the API types with a scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: GroupVersion}
+
+	// AddToScheme adds the API types to a scheme
+	AddToScheme = SchemeBuilder.AddToScheme
+)
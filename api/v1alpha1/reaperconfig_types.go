@@ -0,0 +1,73 @@
+/*
+Copyright 2024 The evicted-pod-reaper Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReaperConfigSpec holds the settings applied to a running reaper. Unset
+// fields keep the value configured through the environment.
+type ReaperConfigSpec struct {
+	// Enabled pauses reaping when false
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TTLSeconds is how long evicted pods are kept before deletion, unless
+	// a namespace, reason or owner TTL applies
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSeconds *int32 `json:"ttlSeconds,omitempty"`
+
+	// Namespaces limits reaping to these namespaces. They narrow the
+	// namespaces the reaper watches, which can't grow without a restart.
+	// +optional
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Reasons lists the pod status reasons eligible for reaping, instead of
+	// Evicted. Pods with a reason-match annotation keep their own list.
+	// +optional
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:shortName=rc
+// +kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+// +kubebuilder:printcolumn:name="TTL",type=integer,JSONPath=`.spec.ttlSeconds`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// ReaperConfig configures a running reaper. The reaper only reads the one
+// named by REAPER_CONFIG_RESOURCE.
+type ReaperConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ReaperConfigSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ReaperConfigList contains a list of ReaperConfig
+type ReaperConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ReaperConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ReaperConfig{}, &ReaperConfigList{})
+}
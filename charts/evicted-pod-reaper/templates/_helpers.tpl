@@ -86,6 +86,14 @@ Create environment variables for the controller
 {{- end }}
 - name: REAPER_TTL_TO_DELETE
   value: {{ .Values.reaper.ttlToDelete | quote }}
+- name: POD_NAMESPACE
+  valueFrom:
+    fieldRef:
+      fieldPath: metadata.namespace
+- name: POD_NAME
+  valueFrom:
+    fieldRef:
+      fieldPath: metadata.name
 {{- with .Values.reaper.env }}
 {{ toYaml . }}
 {{- end }}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// cacheSyncer is the part of a manager's cache waited on at startup
+type cacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// waitForCacheSync waits up to timeout for the cache to sync, or
+// indefinitely if the timeout is zero. It returns an error only if the
+// timeout expires, not when ctx is cancelled.
+func waitForCacheSync(ctx context.Context, cache cacheSyncer, timeout time.Duration) error {
+	syncCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		syncCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if cache.WaitForCacheSync(syncCtx) {
+		return nil
+	}
+	if ctx.Err() == nil && errors.Is(syncCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("cache did not sync within %s", timeout)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeCache syncs after a delay, or never if the delay is zero
+type fakeCache struct {
+	delay time.Duration
+}
+
+func (c fakeCache) WaitForCacheSync(ctx context.Context) bool {
+	if c.delay == 0 {
+		<-ctx.Done()
+		return false
+	}
+	select {
+	case <-time.After(c.delay):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func TestWaitForCacheSync(t *testing.T) {
+	tests := []struct {
+		name    string
+		cache   fakeCache
+		timeout time.Duration
+		cancel  bool
+		wantErr bool
+	}{
+		{
+			name:    "syncs in time",
+			cache:   fakeCache{delay: time.Millisecond},
+			timeout: time.Second,
+		},
+		{
+			name:    "times out",
+			cache:   fakeCache{},
+			timeout: 10 * time.Millisecond,
+			wantErr: true,
+		},
+		{
+			name:   "shutting down is not a timeout",
+			cache:  fakeCache{},
+			cancel: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			err := waitForCacheSync(ctx, tt.cache, tt.timeout)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("waitForCacheSync() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// cluster is a cluster the reaper runs a manager against
+type cluster struct {
+	// Name labels the cluster's metrics, empty outside multi-cluster mode
+	Name   string
+	Config *rest.Config
+}
+
+// loadClusters loads the clusters listed in REAPER_KUBECONFIGS, as
+// kubeconfig paths each optionally followed by #context.
+// Each cluster is named after its context.
+func loadClusters(kubeconfigs []string) ([]cluster, error) {
+	var clusters []cluster
+	names := make(map[string]bool)
+	for _, entry := range kubeconfigs {
+		path, context, _ := strings.Cut(entry, "#")
+		loader := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+			&clientcmd.ClientConfigLoadingRules{ExplicitPath: path},
+			&clientcmd.ConfigOverrides{CurrentContext: context},
+		)
+		config, err := loader.ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("unable to load kubeconfig %q: %w", entry, err)
+		}
+
+		name := context
+		if name == "" {
+			if raw, err := loader.RawConfig(); err == nil {
+				name = raw.CurrentContext
+			}
+		}
+		if name == "" {
+			name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		}
+		if names[name] {
+			return nil, fmt.Errorf("kubeconfig %q names cluster %q more than once", entry, name)
+		}
+		names[name] = true
+		clusters = append(clusters, cluster{Name: name, Config: config})
+	}
+	return clusters, nil
+}
+
+// managerOptions derives a cluster's manager options from the shared ones.
+// Metrics and health probes are served by the primary manager only, from
+// the shared registry.
+func (c cluster) managerOptions(base ctrl.Options, primary bool) ctrl.Options {
+	opts := base
+	if !primary {
+		opts.Metrics.BindAddress = "0"
+		opts.HealthProbeBindAddress = "0"
+	}
+	return opts
+}
+
+// registerer labels the cluster's metrics with its name in multi-cluster mode
+func (c cluster) registerer(registry prometheus.Registerer) prometheus.Registerer {
+	if c.Name == "" {
+		return registry
+	}
+	return prometheus.WrapRegistererWith(prometheus.Labels{"cluster": c.Name}, registry)
+}
+
+// controllerName keeps controller names unique across the managers
+func (c cluster) controllerName() string {
+	if c.Name == "" {
+		return ""
+	}
+	return "pod-" + c.Name
+}
+
+// lastErrorPath serves each cluster's last reconcile error separately
+func (c cluster) lastErrorPath() string {
+	if c.Name == "" {
+		return controller.LastErrorPath
+	}
+	return controller.LastErrorPath + "/" + c.Name
+}
+
+// reapPath serves each cluster's reap API separately
+func (c cluster) reapPath() string {
+	if c.Name == "" {
+		return controller.ReapPath
+	}
+	return controller.ReapPath + "/" + c.Name
+}
+
+// startManagers runs the managers until the context is cancelled or one of
+// them stops, then waits for the rest to stop
+func startManagers(ctx context.Context, mgrs []ctrl.Manager) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(mgrs))
+	for _, mgr := range mgrs {
+		go func(mgr ctrl.Manager) {
+			errs <- mgr.Start(ctx)
+		}(mgr)
+	}
+
+	var firstErr error
+	for range mgrs {
+		if err := <-errs; err != nil && firstErr == nil {
+			firstErr = err
+		}
+		cancel()
+	}
+	return firstErr
+}
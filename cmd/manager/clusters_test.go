@@ -0,0 +1,145 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrl "sigs.k8s.io/controller-runtime"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// writeKubeconfig writes a kubeconfig with one context per server, the first
+// being current, and returns its path
+func writeKubeconfig(t *testing.T, file string, servers map[string]string, current string) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("apiVersion: v1\nkind: Config\ncurrent-context: " + current + "\nclusters:\n")
+	for name, server := range servers {
+		b.WriteString("- name: " + name + "\n  cluster:\n    server: " + server + "\n")
+	}
+	b.WriteString("contexts:\n")
+	for name := range servers {
+		b.WriteString("- name: " + name + "\n  context:\n    cluster: " + name + "\n    user: reaper\n")
+	}
+	b.WriteString("users:\n- name: reaper\n  user:\n    token: secret\n")
+
+	path := filepath.Join(t.TempDir(), file)
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		t.Fatalf("Failed to write kubeconfig: %v", err)
+	}
+	return path
+}
+
+func TestLoadClusters(t *testing.T) {
+	single := writeKubeconfig(t, "single.yaml", map[string]string{"edge-1": "https://edge-1.example:6443"}, "edge-1")
+	multi := writeKubeconfig(t, "multi.yaml", map[string]string{
+		"edge-2": "https://edge-2.example:6443",
+		"edge-3": "https://edge-3.example:6443",
+	}, "edge-2")
+
+	clusters, err := loadClusters([]string{single, multi + "#edge-3"})
+	if err != nil {
+		t.Fatalf("loadClusters() error = %v", err)
+	}
+
+	want := []struct{ name, host string }{
+		{"edge-1", "https://edge-1.example:6443"},
+		{"edge-3", "https://edge-3.example:6443"},
+	}
+	if len(clusters) != len(want) {
+		t.Fatalf("Expected %d clusters, got %d", len(want), len(clusters))
+	}
+	for i, w := range want {
+		if clusters[i].Name != w.name {
+			t.Errorf("cluster %d name = %q, want %q", i, clusters[i].Name, w.name)
+		}
+		if clusters[i].Config.Host != w.host {
+			t.Errorf("cluster %d host = %q, want %q", i, clusters[i].Config.Host, w.host)
+		}
+	}
+
+	if clusters, err := loadClusters(nil); err != nil || clusters != nil {
+		t.Errorf("loadClusters(nil) = %v, %v, want no clusters", clusters, err)
+	}
+	if _, err := loadClusters([]string{single, single}); err == nil {
+		t.Error("Expected an error for a cluster listed twice")
+	}
+	if _, err := loadClusters([]string{filepath.Join(t.TempDir(), "missing.yaml")}); err == nil {
+		t.Error("Expected an error for a missing kubeconfig")
+	}
+	if _, err := loadClusters([]string{multi + "#unknown"}); err == nil {
+		t.Error("Expected an error for an unknown context")
+	}
+}
+
+func TestClusterManagerOptions(t *testing.T) {
+	base := ctrl.Options{
+		Metrics:                metricsserver.Options{BindAddress: ":8080"},
+		HealthProbeBindAddress: ":8081",
+		LeaderElection:         true,
+	}
+	c := cluster{Name: "edge-1"}
+
+	primary := c.managerOptions(base, true)
+	if primary.Metrics.BindAddress != ":8080" || primary.HealthProbeBindAddress != ":8081" {
+		t.Errorf("Expected the primary manager to serve metrics and probes, got %q and %q",
+			primary.Metrics.BindAddress, primary.HealthProbeBindAddress)
+	}
+
+	secondary := c.managerOptions(base, false)
+	if secondary.Metrics.BindAddress != "0" || secondary.HealthProbeBindAddress != "0" {
+		t.Errorf("Expected other managers not to serve metrics or probes, got %q and %q",
+			secondary.Metrics.BindAddress, secondary.HealthProbeBindAddress)
+	}
+	if !secondary.LeaderElection {
+		t.Error("Expected leader election to be kept for every manager")
+	}
+	if base.Metrics.BindAddress != ":8080" {
+		t.Error("Expected the shared options to be left unchanged")
+	}
+
+	if got := c.controllerName(); got != "pod-edge-1" {
+		t.Errorf("controllerName() = %q, want pod-edge-1", got)
+	}
+	if got := c.lastErrorPath(); got != controller.LastErrorPath+"/edge-1" {
+		t.Errorf("lastErrorPath() = %q", got)
+	}
+	if got := (cluster{}).controllerName(); got != "" {
+		t.Errorf("Expected the default controller name outside multi-cluster mode, got %q", got)
+	}
+}
+
+func TestClusterRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	for _, name := range []string{"edge-1", "edge-2"} {
+		podMetrics := metrics.NewPodMetrics()
+		podMetrics.Register(cluster{Name: name}.registerer(registry))
+		podMetrics.IncDeleted("default", "BestEffort")
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	seen := map[string]bool{}
+	for _, mf := range mfs {
+		if mf.GetName() != "evicted_pods_deleted_total" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "cluster" {
+					seen[label.GetValue()] = true
+				}
+			}
+		}
+	}
+	if !seen["edge-1"] || !seen["edge-2"] {
+		t.Errorf("Expected deletions labelled with both clusters, got %v", seen)
+	}
+}
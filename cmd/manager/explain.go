@@ -0,0 +1,47 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// runExplain implements `manager explain --pod pod.yaml`, printing how the
+// reaper, configured from the environment, would handle the pod
+func runExplain(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("explain", flag.ContinueOnError)
+	fs.SetOutput(out)
+	podFile := fs.String("pod", "", "Path to a pod manifest (YAML or JSON).")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *podFile == "" {
+		return fmt.Errorf("--pod is required")
+	}
+
+	pod, err := loadPod(*podFile)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(out, reconcilerFromConfig(loadConfig()).Explain(pod))
+	return err
+}
+
+func loadPod(path string) (*corev1.Pod, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read pod manifest: %w", err)
+	}
+	pod := &corev1.Pod{}
+	if err := yaml.UnmarshalStrict(data, pod); err != nil {
+		return nil, fmt.Errorf("unable to parse pod manifest %s: %w", path, err)
+	}
+	if pod.Kind != "" && pod.Kind != "Pod" {
+		return nil, fmt.Errorf("manifest %s is a %s, not a Pod", path, pod.Kind)
+	}
+	return pod, nil
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeManifest writes a pod manifest to a temporary file and returns its path
+func writeManifest(t *testing.T, manifest string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pod.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0o600); err != nil {
+		t.Fatalf("Failed to write manifest: %v", err)
+	}
+	return path
+}
+
+func TestRunExplain(t *testing.T) {
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	tests := []struct {
+		name        string
+		env         map[string]string
+		manifest    string
+		wantVerdict string
+		wantLines   []string
+	}{
+		{
+			name: "evicted pod past TTL would be deleted",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+status:
+  phase: Failed
+  reason: Evicted
+  startTime: "` + old + `"
+`,
+			wantVerdict: "verdict: would delete",
+			wantLines:   []string{"pod default/web-1", "evicted:        yes", "ttl exceeded:   yes"},
+		},
+		{
+			name: "evicted pod within TTL would be requeued",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+status:
+  phase: Failed
+  reason: Evicted
+  startTime: "` + recent + `"
+`,
+			wantVerdict: "verdict: requeue in ",
+			wantLines:   []string{"ttl exceeded:   no"},
+		},
+		{
+			name: "preserved pod would be skipped",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+  annotations:
+    pod-reaper.kyos.com/preserve: "true"
+status:
+  phase: Failed
+  reason: Evicted
+  startTime: "` + old + `"
+`,
+			wantVerdict: "verdict: skip, pod is preserved",
+			wantLines:   []string{"not preserved:  no"},
+		},
+		{
+			name: "running pod is ignored",
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+status:
+  phase: Running
+`,
+			wantVerdict: "verdict: ignore, pod is not evicted",
+		},
+		{
+			name: "configuration from the environment applies",
+			env:  map[string]string{"REAPER_SAFE_MODE": "true", "REAPER_WATCH_NAMESPACES": "kube-system"},
+			manifest: `apiVersion: v1
+kind: Pod
+metadata:
+  name: web-1
+  namespace: default
+status:
+  phase: Failed
+  reason: Evicted
+  startTime: "` + old + `"
+`,
+			wantVerdict: "verdict: skip, namespace is not in the safe-mode allow-list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			var out bytes.Buffer
+			if err := runExplain([]string{"--pod", writeManifest(t, tt.manifest)}, &out); err != nil {
+				t.Fatalf("runExplain() error = %v", err)
+			}
+
+			explanation := out.String()
+			if !strings.Contains(explanation, tt.wantVerdict) {
+				t.Errorf("runExplain() output missing %q:\n%s", tt.wantVerdict, explanation)
+			}
+			for _, line := range tt.wantLines {
+				if !strings.Contains(explanation, line) {
+					t.Errorf("runExplain() output missing %q:\n%s", line, explanation)
+				}
+			}
+		})
+	}
+}
+
+func TestRunExplain_Errors(t *testing.T) {
+	var out bytes.Buffer
+	if err := runExplain(nil, &out); err == nil {
+		t.Error("runExplain() expected an error without --pod")
+	}
+	if err := runExplain([]string{"--pod", "/does/not/exist.yaml"}, &out); err == nil {
+		t.Error("runExplain() expected an error for a missing file")
+	}
+	notAPod := writeManifest(t, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\n")
+	if err := runExplain([]string{"--pod", notAPod}, &out); err == nil {
+		t.Error("runExplain() expected an error for a non-Pod manifest")
+	}
+}
@@ -1,20 +1,38 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
+	"time"
 
+	reaperv1alpha1 "github.com/kyosenergy-engineering/evicted-pod-reaper/api/v1alpha1"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/archive"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/config"
 	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
 	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/notify"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/statsd"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
@@ -27,9 +45,33 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(reaperv1alpha1.AddToScheme(scheme))
 }
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		if err := runExplain(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "replay" {
+		if err := runReplay(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "reap" {
+		ctrl.SetLogger(zap.New(zap.UseDevMode(true)))
+		if err := runReap(ctrl.SetupSignalHandler(), os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	var metricsAddr string
 	var enableLeaderElection bool
 	var leaderElectionID string
@@ -47,25 +89,126 @@ func main() {
 	flag.Parse()
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ctx := ctrl.SetupSignalHandler()
 
 	// Parse environment variables
-	watchAllNamespaces := os.Getenv("REAPER_WATCH_ALL_NAMESPACES") == "true"
-	watchNamespaces := parseNamespaces(os.Getenv("REAPER_WATCH_NAMESPACES"))
-	ttlToDelete := parseTTL(os.Getenv("REAPER_TTL_TO_DELETE"))
+	cfg := loadConfig()
+	setupExitCode = cfg.ExitCodeOnSetupError
+	startupRetry := newStartupRetry(cfg.StartupRetryTimeout)
+	watchAllNamespaces := cfg.WatchesAllNamespaces()
+	watchNamespaces := cfg.Namespaces()
+	reconciler := reconcilerFromConfig(cfg)
+	if err := cfg.CheckNamespaceFallback(); err != nil {
+		exitOnSetupError(err, "invalid namespace configuration")
+	}
+	if err := cfg.CheckSafeMode(); err != nil {
+		exitOnSetupError(err, "invalid safe mode configuration")
+	}
+	if err := cfg.CheckReasons(); err != nil {
+		exitOnSetupError(err, "invalid reason configuration")
+	}
+	shedder := newLoadShedder(cfg.ShedErrorRate, cfg.ShedWindow)
+	notifier := newNotifier(cfg.NotifyWebhookURL, cfg.NotifyBatchWindow)
+	preDeleteHook := newPreDeleteHook(cfg.PreDeleteHook, cfg.PreDeleteHookTimeout, cfg.PreDeleteHookEnv)
+	archiver, err := newArchiver(cfg)
+	if err != nil {
+		exitOnSetupError(err, "invalid archive configuration")
+	}
+	statsdClient := newStatsdClient(cfg.StatsdAddress)
 
 	setupLog.Info("Starting evicted-pod-reaper",
 		"watchAllNamespaces", watchAllNamespaces,
 		"watchNamespaces", watchNamespaces,
-		"ttlToDelete", ttlToDelete,
+		"namespacePrefix", reconciler.NamespacePrefix,
+		"namespaceSelector", cfg.NamespaceSelector,
+		"ttlToDelete", reconciler.TTLToDelete,
+		"safeMode", reconciler.SafeMode,
+		"mode", reconciler.Mode,
+		"useJobTTL", reconciler.UseJobTTL,
+		"standalonePolicy", reconciler.StandalonePolicy,
+		"useEvictionAPI", reconciler.UseEvictionAPI,
+		"reapUnschedulable", reconciler.ReapUnschedulable,
+		"reapCrashLoop", reconciler.ReapCrashLoop,
+		"priorityClassFilter", reconciler.PriorityClassFilter,
+		"preDeleteHook", cfg.PreDeleteHook,
 	)
 
+	// Without leader election every replica reaps, and they race to delete
+	// the same pods
+	if cfg.RequireLeader && !enableLeaderElection {
+		setupLog.Info("REAPER_REQUIRE_LEADER is set but leader election is disabled, " +
+			"run with --leader-elect when running more than one replica")
+	}
+
+	leaderElection, err := parseLeaderElectionTimings(cfg.LeaseDuration, cfg.RenewDeadline, cfg.RetryPeriod)
+	if err != nil {
+		exitOnSetupError(err, "invalid leader election configuration")
+	}
+
+	metricsOpts, err := metricsServerOptions(metricsAddr, cfg.MetricsTLSCert, cfg.MetricsTLSKey, cfg.MetricsTLSClientCA)
+	if err != nil {
+		exitOnSetupError(err, "invalid metrics TLS configuration")
+	}
+
+	// Serve metrics over a Unix socket instead of TCP
+	var metricsSocket *metricsSocketServer
+	if path := cfg.MetricsSocket; path != "" {
+		if metricsOpts.SecureServing {
+			exitOnSetupError(fmt.Errorf("metrics TLS is not supported on a Unix socket"), "invalid metrics configuration")
+		}
+		metricsOpts.BindAddress = "0"
+		metricsSocket = newMetricsSocketServer(path, ctrlmetrics.Registry)
+	}
+
+	maintenanceConfigMap, err := parseObjectRef(cfg.MaintenanceConfigMap)
+	if err != nil {
+		exitOnSetupError(err, "invalid maintenance ConfigMap")
+	}
+	reaperConfig, err := parseObjectRef(cfg.ConfigResource)
+	if err != nil {
+		exitOnSetupError(err, "invalid ReaperConfig reference")
+	}
+
+	// Reap listed pods on demand, for tooling such as dashboards
+	var reapAPI *reapAPIServer
+	if addr := cfg.ReapAPIAddr; addr != "" {
+		if cfg.ReapAPIToken == "" {
+			exitOnSetupError(fmt.Errorf("REAPER_REAP_API_TOKEN must be set to serve the reap API"), "invalid reap API configuration")
+		}
+		reapAPI = newReapAPIServer(addr)
+	}
+
+	namespaceSelector, err := parseSelector(cfg.NamespaceSelector)
+	if err != nil {
+		exitOnSetupError(err, "invalid namespace selector")
+	}
+	orphanedObjectSelector, err := parseSelector(cfg.OrphanedObjectSelector)
+	if err != nil {
+		exitOnSetupError(err, "invalid orphaned pod object selector")
+	}
+
 	// Configure manager options
 	mgrOpts := ctrl.Options{
 		Scheme:                 scheme,
-		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		Metrics:                metricsOpts,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       leaderElectionID,
+		// Jobs are only read when delegating cleanup, ReplicaSets when waiting
+		// for them to observe a failure, nodes when waiting for them to be
+		// Ready and namespaces for their team label, which is cached by the
+		// reconciler, don't cache them
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: []client.Object{&batchv1.Job{}, &appsv1.ReplicaSet{}, &corev1.Node{}, &corev1.Namespace{}},
+			},
+		},
+	}
+
+	leaderElection.apply(&mgrOpts)
+
+	if cfg.CacheSyncTimeout > 0 {
+		mgrOpts.Controller.CacheSyncTimeout = cfg.CacheSyncTimeout
 	}
 
 	// Configure namespace watching
@@ -78,62 +221,463 @@ func main() {
 		}
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), mgrOpts)
+	// Only watch the maintenance ConfigMap and ReaperConfig, wherever they
+	// live
+	mgrOpts.Cache.ByObject = map[client.Object]cache.ByObject{}
+	if maintenanceConfigMap != nil {
+		mgrOpts.Cache.ByObject[&corev1.ConfigMap{}] = cache.ByObject{
+			Namespaces: map[string]cache.Config{maintenanceConfigMap.Namespace: {}},
+			Field:      fields.OneTermEqualSelector("metadata.name", maintenanceConfigMap.Name),
+		}
+	}
+	if reaperConfig != nil {
+		mgrOpts.Cache.ByObject[&reaperv1alpha1.ReaperConfig{}] = cache.ByObject{
+			Namespaces: map[string]cache.Config{reaperConfig.Namespace: {}},
+			Field:      fields.OneTermEqualSelector("metadata.name", reaperConfig.Name),
+		}
+	}
+
+	clusters, err := loadClusters(cfg.Kubeconfigs)
 	if err != nil {
-		setupLog.Error(err, "unable to start manager")
-		os.Exit(1)
+		exitOnSetupError(err, "invalid kubeconfig list")
+	}
+	if len(clusters) == 0 {
+		clusters = []cluster{{Config: ctrl.GetConfigOrDie()}}
+	} else if mgrOpts.LeaderElectionNamespace == "" {
+		// Remote clusters can't infer the namespace from the service account
+		mgrOpts.LeaderElectionNamespace = cfg.PodNamespace
 	}
 
-	// Register metrics
-	podMetrics := metrics.NewPodMetrics()
-	podMetrics.Register(ctrlmetrics.Registry)
+	// One manager per cluster, the first also serves metrics and probes
+	mgrs := make([]ctrl.Manager, 0, len(clusters))
+	for i, c := range clusters {
+		// Wait out an API server that is briefly unreachable instead of
+		// starting with caches that can't sync
+		if startupRetry.timeout > 0 {
+			if err := startupRetry.do(ctx, "connecting to the API server", func() error {
+				return checkAPIServer(c.Config)
+			}); err != nil {
+				exitOnSetupError(err, "unable to reach the API server", "cluster", c.Name)
+			}
+		}
+		var mgr ctrl.Manager
+		if err := startupRetry.do(ctx, "creating manager", func() (err error) {
+			mgr, err = ctrl.NewManager(c.Config, c.managerOptions(mgrOpts, i == 0))
+			return err
+		}); err != nil {
+			exitOnSetupError(err, "unable to start manager", "cluster", c.Name)
+		}
+		mgrs = append(mgrs, mgr)
 
-	// Setup controller
-	if err = (&controller.PodReconciler{
-		Client:      mgr.GetClient(),
-		Scheme:      mgr.GetScheme(),
-		Metrics:     podMetrics,
-		TTLToDelete: ttlToDelete,
-	}).SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Pod")
-		os.Exit(1)
+		// Register metrics
+		podMetrics := metrics.NewPodMetricsWithConfig(cfg.Metrics)
+		podMetrics.Register(c.registerer(ctrlmetrics.Registry))
+		if statsdClient != nil {
+			podMetrics.SetMirror(statsdClient)
+		}
+
+		// Setup controller
+		reconciler := reconcilerFromConfig(cfg)
+		reconciler.Client = mgr.GetClient()
+		reconciler.Scheme = mgr.GetScheme()
+		reconciler.Metrics = podMetrics
+		podMetrics.SetNamespaceTTLs(reconciler.NamespaceTTLs)
+		reconciler.PreDeleteHook = preDeleteHook
+		reconciler.Notifier = notifier
+		reconciler.Archiver = archiver
+		// Throttling is per API server
+		if i == 0 {
+			reconciler.Shedder = shedder
+		} else {
+			reconciler.Shedder = newLoadShedder(cfg.ShedErrorRate, cfg.ShedWindow)
+		}
+		if cfg.MaxDeleteLatency > 0 {
+			reconciler.LatencyGuard = controller.NewLatencyGuard(cfg.MaxDeleteLatency)
+		}
+		reconciler.MaintenanceConfigMap = maintenanceConfigMap
+		reconciler.NamespaceSelector = namespaceSelector
+		reconciler.OrphanedObjectSelector = orphanedObjectSelector
+		// ConfigMaps may be cached for the maintenance window only
+		reconciler.APIReader = mgr.GetAPIReader()
+		// Only the manager reads through a cache, the reap command doesn't
+		reconciler.DeferUntilCacheSynced = cfg.DeferUntilCacheSynced
+		// A one-shot reap is a single pass, it would never delete
+		reconciler.FirstPassDryRun = cfg.FirstPassDryRun
+		if !watchAllNamespaces {
+			reconciler.CachedNamespaces = watchNamespaces
+		}
+		reconciler.ControllerName = c.controllerName()
+		reconciler.ReaperConfigured = reaperConfig != nil
+		// Identify the reaper's own pods so they are never reaped
+		self, err := controller.ResolveIdentity(ctx, mgr.GetAPIReader(), cfg.PodNamespace, cfg.PodName)
+		if err != nil {
+			setupLog.Error(err, "unable to look up own pod, identifying it by label only", "cluster", c.Name)
+		}
+		reconciler.Self = self
+		if err = reconciler.SetupWithManager(mgr); err != nil {
+			exitOnSetupError(err, "unable to create controller", "controller", "Pod", "cluster", c.Name)
+		}
+		if reaperConfig != nil {
+			configReconciler := &controller.ReaperConfigReconciler{
+				Client: mgr.GetClient(),
+				Key:    *reaperConfig,
+				Target: reconciler,
+				OnReload: func(ctx context.Context, old, new *controller.LiveConfig) {
+					config.LogDiff(log.FromContext(ctx), withLiveConfig(cfg, old), withLiveConfig(cfg, new))
+				},
+			}
+			if err = configReconciler.SetupWithManager(mgr); err != nil {
+				exitOnSetupError(err, "unable to create controller", "controller", "ReaperConfig", "cluster", c.Name)
+			}
+		}
+
+		// Warn about configured namespaces that don't exist
+		if !watchAllNamespaces || len(reconciler.WatchNamespaces) > 0 {
+			if _, err := controller.ValidateNamespaces(ctx, mgr.GetAPIReader(), watchNamespaces, podMetrics); err != nil {
+				setupLog.Error(err, "unable to validate configured namespaces", "cluster", c.Name)
+			}
+		}
+
+		if reapAPI != nil {
+			reapAPI.Handle(c.reapPath(), reconciler.ReapHandler(cfg.ReapAPIToken))
+		}
+
+		if metricsSocket != nil {
+			metricsSocket.AddExtraHandler(c.lastErrorPath(), reconciler.LastErrorHandler())
+		} else if err := mgrs[0].AddMetricsServerExtraHandler(c.lastErrorPath(), reconciler.LastErrorHandler()); err != nil {
+			exitOnSetupError(err, "unable to set up last error endpoint", "cluster", c.Name)
+		}
 	}
 
-	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up health check")
-		os.Exit(1)
+	if metricsSocket != nil {
+		if err := mgrs[0].Add(metricsSocket); err != nil {
+			exitOnSetupError(err, "unable to set up metrics socket")
+		}
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
-		os.Exit(1)
+	if reapAPI != nil {
+		if err := mgrs[0].Add(reapAPI); err != nil {
+			exitOnSetupError(err, "unable to set up reap API")
+		}
 	}
 
-	setupLog.Info("starting manager")
-	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+	if err := mgrs[0].AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		exitOnSetupError(err, "unable to set up health check")
+	}
+	if err := mgrs[0].AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		exitOnSetupError(err, "unable to set up ready check")
+	}
+
+	// Fail fast rather than hang when caches can't sync
+	if cfg.CacheSyncTimeout > 0 {
+		for i, mgr := range mgrs {
+			go func(c cluster, mgr ctrl.Manager) {
+				if err := waitForCacheSync(ctx, mgr.GetCache(), cfg.CacheSyncTimeout); err != nil {
+					exitOnSetupError(err, "unable to start, check connectivity to the API server", "cluster", c.Name)
+				}
+			}(clusters[i], mgr)
+		}
+	}
+
+	setupLog.Info("starting manager", "clusters", len(mgrs))
+	if err := startManagers(ctx, mgrs); err != nil {
 		setupLog.Error(err, "problem running manager")
 		os.Exit(1)
 	}
+
+	// Don't drop notifications still waiting for their batch window
+	if notifier != nil {
+		notifier.Flush()
+	}
+	if statsdClient != nil {
+		_ = statsdClient.Close()
+	}
 }
 
-func parseNamespaces(env string) []string {
+// loadConfig reads the Config from the environment, logging the invalid
+// settings it replaced with their defaults
+func loadConfig() config.Config {
+	cfg, err := config.LoadFromEnv()
+	if err != nil {
+		setupLog.Info("invalid configuration, using defaults", "error", err.Error())
+	}
+	return cfg
+}
+
+// withLiveConfig returns cfg with the settings of a ReaperConfig applied, or
+// cfg itself for nil, so reloads can be logged as changes to it
+func withLiveConfig(cfg config.Config, live *controller.LiveConfig) config.Config {
+	if live == nil {
+		return cfg
+	}
+	cfg.Disabled = live.Disabled
+	if live.TTLToDelete != nil {
+		cfg.TTLToDelete = *live.TTLToDelete
+	}
+	cfg.LimitNamespaces = live.Namespaces
+	if len(live.Reasons) > 0 {
+		// ReaperConfig reasons replace both lists
+		cfg.Reasons, cfg.ExceptReasons = live.Reasons, nil
+	}
+	return cfg
+}
+
+// reconcilerFromConfig builds a reconciler holding the settings of cfg.
+// Clients, metrics and runtime helpers are left unset.
+func reconcilerFromConfig(cfg config.Config) *controller.PodReconciler {
+	r := &controller.PodReconciler{
+		TTLToDelete:   cfg.TTLToDelete,
+		NamespaceTTLs: cfg.NamespaceTTLs,
+		ReasonTTLs:    cfg.ReasonTTLs,
+		LabelTTLs:     cfg.LabelTTLs,
+		OwnedTTL:      cfg.OwnedTTL,
+		OrphanTTL:     cfg.OrphanTTL,
+
+		SafeMode:          cfg.SafeMode,
+		DryRunNamespaces:  cfg.DryRunNamespaces,
+		Mode:              cfg.Mode,
+		AllowedNamespaces: cfg.WatchNamespaces,
+
+		OwnerResolutionDepth:  cfg.OwnerResolutionDepth,
+		TransitionUpdatesOnly: cfg.TransitionUpdatesOnly,
+
+		UseJobTTL:                  cfg.UseJobTTL,
+		JobTTLSecondsAfterFinished: cfg.JobTTLSecondsAfterFinished,
+
+		PriorityClassFilter: cfg.PriorityClassFilter,
+		Reasons:             cfg.Reasons,
+		ExceptReasons:       cfg.ExceptReasons,
+		MaxPriority:         cfg.MaxPriority,
+
+		ContainerTerminationReasons: cfg.ContainerTerminationReasons,
+
+		RequireConsecutiveObservations: cfg.RequireConsecutiveObservations,
+
+		WaitForOwnerObserved: cfg.WaitForOwnerObserved,
+		WaitForNodeReady:     cfg.WaitForNodeReady,
+		ReapOnlyPreReboot:    cfg.ReapOnlyPreReboot,
+		WaitForLogsShipped:   cfg.WaitForLogsShipped,
+		LogsShippedTimeout:   cfg.LogsShippedTimeout,
+
+		ReapUnschedulable: cfg.ReapUnschedulable,
+		UnschedulableTTL:  cfg.UnschedulableTTL,
+
+		ReapCrashLoop:     cfg.ReapCrashLoop,
+		CrashLoopDuration: cfg.CrashLoopDuration,
+
+		MaxTrackedPods:    cfg.MaxTrackedPods,
+		DeleteConcurrency: cfg.DeleteConcurrency,
+		ReconcileDebounce: cfg.ReconcileDebounce,
+		StartupJitter:     cfg.StartupJitter,
+		HeartbeatInterval: cfg.HeartbeatInterval,
+		TeamLabelKey:      cfg.TeamLabelKey,
+		AuditAnnotations:  cfg.AuditAnnotations,
+		FieldManager:      cfg.FieldManager,
+		CountOwnerKinds:   cfg.CountOwnerKinds,
+
+		UseEvictionAPI:          cfg.UseEvictionAPI,
+		StandalonePolicy:        cfg.StandalonePolicy,
+		FutureStartTimePolicy:   cfg.FutureStartTimePolicy,
+		TTLAnchor:               cfg.TTLAnchor,
+		StampFirstSeen:          cfg.StampFirstSeen,
+		AnnotateSchedule:        cfg.AnnotateSchedule,
+		VerifyDelete:            cfg.VerifyDelete,
+		EvictionContainerPolicy: cfg.EvictionContainerPolicy,
+		SkipEmptySpec:           !cfg.ReapEmptySpec,
+		RequireAllTerminated:    cfg.RequireAllTerminated,
+	}
+	if cfg.NamespacePrefix != "" {
+		r.NamespacePrefix = cfg.NamespacePrefix
+		r.WatchNamespaces = cfg.WatchNamespaces
+	}
+	return r
+}
+
+// Leader election defaults used by controller-runtime when unset
+const (
+	defaultLeaseDuration = 15 * time.Second
+	defaultRenewDeadline = 10 * time.Second
+)
+
+// leaderElectionTimings holds the leader election timing overrides. Unset
+// values are left nil so the manager defaults apply.
+type leaderElectionTimings struct {
+	LeaseDuration *time.Duration
+	RenewDeadline *time.Duration
+	RetryPeriod   *time.Duration
+}
+
+func parseLeaderElectionTimings(lease, renew, retry string) (leaderElectionTimings, error) {
+	var timings leaderElectionTimings
+	var err error
+
+	if timings.LeaseDuration, err = parseOptionalDuration(lease); err != nil {
+		return timings, fmt.Errorf("invalid lease duration %q: %w", lease, err)
+	}
+	if timings.RenewDeadline, err = parseOptionalDuration(renew); err != nil {
+		return timings, fmt.Errorf("invalid renew deadline %q: %w", renew, err)
+	}
+	if timings.RetryPeriod, err = parseOptionalDuration(retry); err != nil {
+		return timings, fmt.Errorf("invalid retry period %q: %w", retry, err)
+	}
+
+	leaseDuration, renewDeadline := defaultLeaseDuration, defaultRenewDeadline
+	if timings.LeaseDuration != nil {
+		leaseDuration = *timings.LeaseDuration
+	}
+	if timings.RenewDeadline != nil {
+		renewDeadline = *timings.RenewDeadline
+	}
+	if renewDeadline >= leaseDuration {
+		return timings, fmt.Errorf("renew deadline %s must be less than lease duration %s", renewDeadline, leaseDuration)
+	}
+
+	return timings, nil
+}
+
+// apply sets the leader election timings on the manager options
+func (t leaderElectionTimings) apply(opts *ctrl.Options) {
+	opts.LeaseDuration = t.LeaseDuration
+	opts.RenewDeadline = t.RenewDeadline
+	opts.RetryPeriod = t.RetryPeriod
+}
+
+// metricsServerOptions builds the metrics server options. When a certificate
+// and key are given the endpoint is served over HTTPS, and when a client CA is
+// also given scrapers must present a certificate signed by it.
+func metricsServerOptions(bindAddress, certFile, keyFile, clientCAFile string) (metricsserver.Options, error) {
+	opts := metricsserver.Options{BindAddress: bindAddress}
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return opts, fmt.Errorf("client CA requires a TLS certificate and key")
+		}
+		return opts, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return opts, fmt.Errorf("both a TLS certificate and key are required")
+	}
+
+	// The metrics server loads (and reloads) the key pair from a directory
+	certDir := filepath.Dir(certFile)
+	keyName, err := filepath.Rel(certDir, keyFile)
+	if err != nil {
+		return opts, fmt.Errorf("invalid TLS key path %q: %w", keyFile, err)
+	}
+	opts.SecureServing = true
+	opts.CertDir = certDir
+	opts.CertName = filepath.Base(certFile)
+	opts.KeyName = keyName
+
+	if clientCAFile != "" {
+		caPEM, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return opts, fmt.Errorf("unable to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return opts, fmt.Errorf("no certificates found in client CA %q", clientCAFile)
+		}
+		opts.TLSOpts = append(opts.TLSOpts, func(cfg *tls.Config) {
+			cfg.ClientCAs = pool
+			cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		})
+	}
+	return opts, nil
+}
+
+// parseSelector parses an optional label selector, such as
+// `team=payments,env!=dev`
+func parseSelector(env string) (labels.Selector, error) {
 	if env == "" {
-		return []string{"default"}
+		return nil, nil
 	}
-	namespaces := strings.Split(env, ",")
-	for i := range namespaces {
-		namespaces[i] = strings.TrimSpace(namespaces[i])
+	return labels.Parse(env)
+}
+
+// parseObjectRef parses a `namespace/name` object reference
+func parseObjectRef(env string) (*types.NamespacedName, error) {
+	if env == "" {
+		return nil, nil
 	}
-	return namespaces
+	namespace, name, ok := strings.Cut(env, "/")
+	if !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("expected namespace/name, got %q", env)
+	}
+	return &types.NamespacedName{Namespace: namespace, Name: name}, nil
 }
 
-func parseTTL(env string) int {
+func parseOptionalDuration(env string) (*time.Duration, error) {
 	if env == "" {
-		return 300 // default 5 minutes
+		return nil, nil
+	}
+	d, err := time.ParseDuration(env)
+	if err != nil {
+		return nil, err
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("duration must be positive")
 	}
-	ttl, err := strconv.Atoi(env)
+	return &d, nil
+}
+
+func newPreDeleteHook(command string, timeout time.Duration, env []string) *controller.PreDeleteHook {
+	if command == "" {
+		return nil
+	}
+	return &controller.PreDeleteHook{Command: command, Timeout: timeout, Env: env}
+}
+
+func newNotifier(webhookURL string, batchWindow time.Duration) *notify.Notifier {
+	if webhookURL == "" {
+		return nil
+	}
+	return notify.NewNotifier(&notify.WebhookSender{URL: webhookURL}, batchWindow)
+}
+
+// newStatsdClient returns a StatsD client mirroring the deleted and skipped
+// counters to address, or nil if it is unset. StatsD is additive, so an
+// address that can't be resolved is logged instead of stopping the reaper.
+func newStatsdClient(address string) *statsd.Client {
+	if address == "" {
+		return nil
+	}
+	c, err := statsd.New(address)
 	if err != nil {
-		setupLog.Error(err, "invalid TTL value, using default", "value", env)
-		return 300
+		setupLog.Error(err, "unable to set up StatsD, only exporting to Prometheus", "address", address)
+		return nil
+	}
+	return c
+}
+
+// newArchiver returns the archiver configured by the REAPER_ARCHIVE_S3_*
+// settings, or nil if no bucket is set
+func newArchiver(cfg config.Config) (*archive.Archiver, error) {
+	if cfg.ArchiveS3Bucket == "" {
+		return nil, nil
+	}
+	uploader := &archive.S3Uploader{
+		Endpoint:        cfg.ArchiveS3Endpoint,
+		Bucket:          cfg.ArchiveS3Bucket,
+		Region:          cfg.ArchiveS3Region,
+		AccessKeyID:     cfg.ArchiveS3AccessKeyID,
+		SecretAccessKey: cfg.ArchiveS3SecretAccessKey,
+	}
+	if uploader.AccessKeyID == "" || uploader.SecretAccessKey == "" {
+		return nil, fmt.Errorf("REAPER_ARCHIVE_S3_ACCESS_KEY_ID and REAPER_ARCHIVE_S3_SECRET_ACCESS_KEY must be set to archive to a bucket")
+	}
+	if uploader.Region == "" {
+		uploader.Region = "us-east-1"
+	}
+	if uploader.Endpoint == "" {
+		uploader.Endpoint = "https://s3." + uploader.Region + ".amazonaws.com"
+	}
+	return archive.NewArchiver(uploader, cfg.ArchiveS3Prefix), nil
+}
+
+// newLoadShedder returns a load shedder for errorRate, or nil if shedding is
+// disabled
+func newLoadShedder(errorRate float64, window time.Duration) *controller.LoadShedder {
+	if errorRate == 0 {
+		return nil
 	}
-	return ttl
+	return controller.NewLoadShedder(errorRate, window)
 }
@@ -1,105 +1,281 @@
 package main
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"slices"
 	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/config"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
 )
 
-func TestParseNamespaces(t *testing.T) {
-	tests := []struct {
-		name     string
-		input    string
-		expected []string
-	}{
-		{
-			name:     "empty string returns default",
-			input:    "",
-			expected: []string{"default"},
-		},
-		{
-			name:     "single namespace",
-			input:    "kube-system",
-			expected: []string{"kube-system"},
-		},
-		{
-			name:     "multiple namespaces",
-			input:    "kube-system,monitoring,default",
-			expected: []string{"kube-system", "monitoring", "default"},
-		},
-		{
-			name:     "namespaces with spaces",
-			input:    "kube-system, monitoring , default",
-			expected: []string{"kube-system", "monitoring", "default"},
-		},
-		{
-			name:     "duplicate namespaces",
-			input:    "default,default,monitoring",
-			expected: []string{"default", "default", "monitoring"},
-		},
+func TestWithLiveConfig(t *testing.T) {
+	cfg := config.Config{
+		TTLToDelete:   300,
+		Reasons:       []string{"Evicted"},
+		ExceptReasons: []string{"Shutdown"},
+	}
+	if got := withLiveConfig(cfg, nil); len(config.Diff(cfg, got)) != 0 {
+		t.Errorf("withLiveConfig(nil) changed %v", config.Diff(cfg, got))
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := parseNamespaces(tt.input)
-
-			if len(result) != len(tt.expected) {
-				t.Errorf("parseNamespaces() returned %d namespaces, expected %d", len(result), len(tt.expected))
-			}
-
-			for i, ns := range result {
-				if i < len(tt.expected) && ns != tt.expected[i] {
-					t.Errorf("parseNamespaces()[%d] = %q, expected %q", i, ns, tt.expected[i])
-				}
-			}
-		})
+	ttl := 60
+	got := withLiveConfig(cfg, &controller.LiveConfig{
+		Disabled:    true,
+		TTLToDelete: &ttl,
+		Namespaces:  []string{"team-a"},
+		Reasons:     []string{"Shutdown"},
+	})
+	var fields []string
+	for _, c := range config.Diff(cfg, got) {
+		fields = append(fields, c.Field)
+	}
+	slices.Sort(fields)
+	want := []string{"disabled", "exceptReasons", "limitNamespaces", "reasons", "ttlToDelete"}
+	if !slices.Equal(fields, want) {
+		t.Errorf("changed fields = %v, want %v", fields, want)
 	}
 }
 
-func TestParseTTL(t *testing.T) {
+func TestParseLeaderElectionTimings(t *testing.T) {
 	tests := []struct {
-		name     string
-		input    string
-		expected int
+		name      string
+		lease     string
+		renew     string
+		retry     string
+		wantError bool
 	}{
 		{
-			name:     "empty string returns default",
-			input:    "",
-			expected: 300,
+			name: "all unset uses manager defaults",
+		},
+		{
+			name:  "valid overrides",
+			lease: "60s",
+			renew: "40s",
+			retry: "5s",
 		},
 		{
-			name:     "valid integer",
-			input:    "600",
-			expected: 600,
+			name:      "renew equal to lease is rejected",
+			lease:     "30s",
+			renew:     "30s",
+			wantError: true,
 		},
 		{
-			name:     "zero value",
-			input:    "0",
-			expected: 0,
+			name:      "renew above default lease is rejected",
+			renew:     "20s",
+			wantError: true,
 		},
 		{
-			name:     "negative value",
-			input:    "-100",
-			expected: -100,
+			name:  "lease above default renew is accepted",
+			lease: "30s",
 		},
 		{
-			name:     "invalid string returns default",
-			input:    "not-a-number",
-			expected: 300,
+			name:      "invalid duration is rejected",
+			retry:     "soon",
+			wantError: true,
 		},
 		{
-			name:     "very large number",
-			input:    "86400",
-			expected: 86400,
+			name:      "negative duration is rejected",
+			lease:     "-15s",
+			wantError: true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Capture log output to avoid test noise
-			result := parseTTL(tt.input)
+			_, err := parseLeaderElectionTimings(tt.lease, tt.renew, tt.retry)
+			if (err != nil) != tt.wantError {
+				t.Errorf("parseLeaderElectionTimings() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestLeaderElectionTimings_Apply(t *testing.T) {
+	timings, err := parseLeaderElectionTimings("60s", "40s", "5s")
+	if err != nil {
+		t.Fatalf("parseLeaderElectionTimings() error = %v", err)
+	}
+
+	opts := ctrl.Options{}
+	timings.apply(&opts)
+
+	if opts.LeaseDuration == nil || *opts.LeaseDuration != 60*time.Second {
+		t.Errorf("LeaseDuration = %v, expected 60s", opts.LeaseDuration)
+	}
+	if opts.RenewDeadline == nil || *opts.RenewDeadline != 40*time.Second {
+		t.Errorf("RenewDeadline = %v, expected 40s", opts.RenewDeadline)
+	}
+	if opts.RetryPeriod == nil || *opts.RetryPeriod != 5*time.Second {
+		t.Errorf("RetryPeriod = %v, expected 5s", opts.RetryPeriod)
+	}
 
-			if result != tt.expected {
-				t.Errorf("parseTTL(%q) = %d, expected %d", tt.input, result, tt.expected)
+	unset, err := parseLeaderElectionTimings("", "", "")
+	if err != nil {
+		t.Fatalf("parseLeaderElectionTimings() error = %v", err)
+	}
+	opts = ctrl.Options{}
+	unset.apply(&opts)
+	if opts.LeaseDuration != nil || opts.RenewDeadline != nil || opts.RetryPeriod != nil {
+		t.Errorf("Expected unset timings to leave manager defaults, got %v/%v/%v",
+			opts.LeaseDuration, opts.RenewDeadline, opts.RetryPeriod)
+	}
+}
+
+// writeCA writes a self-signed CA certificate in PEM format and returns its path
+func writeCA(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("Failed to write CA: %v", err)
+	}
+	return path
+}
+
+func TestMetricsServerOptions(t *testing.T) {
+	t.Run("plain HTTP by default", func(t *testing.T) {
+		opts, err := metricsServerOptions(":8080", "", "", "")
+		if err != nil {
+			t.Fatalf("metricsServerOptions() error = %v", err)
+		}
+		if opts.SecureServing || opts.BindAddress != ":8080" {
+			t.Errorf("metricsServerOptions() = %+v, want insecure on :8080", opts)
+		}
+	})
+
+	t.Run("certificate and key enable HTTPS", func(t *testing.T) {
+		opts, err := metricsServerOptions(":8443", "/certs/tls.crt", "/certs/private/tls.key", "")
+		if err != nil {
+			t.Fatalf("metricsServerOptions() error = %v", err)
+		}
+		if !opts.SecureServing {
+			t.Error("SecureServing = false, want true")
+		}
+		if opts.CertDir != "/certs" || opts.CertName != "tls.crt" || opts.KeyName != "private/tls.key" {
+			t.Errorf("cert options = %q %q %q, want /certs tls.crt private/tls.key", opts.CertDir, opts.CertName, opts.KeyName)
+		}
+		if len(opts.TLSOpts) != 0 {
+			t.Errorf("TLSOpts = %d entries, want none without a client CA", len(opts.TLSOpts))
+		}
+	})
+
+	t.Run("client CA requires client certificates", func(t *testing.T) {
+		opts, err := metricsServerOptions(":8443", "/certs/tls.crt", "/certs/tls.key", writeCA(t))
+		if err != nil {
+			t.Fatalf("metricsServerOptions() error = %v", err)
+		}
+		cfg := &tls.Config{}
+		for _, opt := range opts.TLSOpts {
+			opt(cfg)
+		}
+		if cfg.ClientAuth != tls.RequireAndVerifyClientCert || cfg.ClientCAs == nil {
+			t.Errorf("tls config ClientAuth = %v, ClientCAs set = %v, want mTLS", cfg.ClientAuth, cfg.ClientCAs != nil)
+		}
+	})
+
+	errorTests := []struct {
+		name                string
+		cert, key, clientCA string
+	}{
+		{name: "certificate without key", cert: "/certs/tls.crt"},
+		{name: "key without certificate", key: "/certs/tls.key"},
+		{name: "client CA without certificate", clientCA: "/certs/ca.crt"},
+		{name: "missing client CA file", cert: "/certs/tls.crt", key: "/certs/tls.key", clientCA: "/does/not/exist"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := metricsServerOptions(":8443", tt.cert, tt.key, tt.clientCA); err == nil {
+				t.Error("metricsServerOptions() expected an error")
 			}
 		})
 	}
 }
+
+func TestParseMaintenanceConfigMap(t *testing.T) {
+	if got, err := parseObjectRef(""); got != nil || err != nil {
+		t.Errorf("parseObjectRef(\"\") = %v, %v, expected nil", got, err)
+	}
+	got, err := parseObjectRef("kube-system/reaper-maintenance")
+	if err != nil || got == nil || got.Namespace != "kube-system" || got.Name != "reaper-maintenance" {
+		t.Errorf("parseObjectRef(\"kube-system/reaper-maintenance\") = %v, %v", got, err)
+	}
+	for _, invalid := range []string{"reaper-maintenance", "/reaper-maintenance", "kube-system/"} {
+		if _, err := parseObjectRef(invalid); err == nil {
+			t.Errorf("parseObjectRef(%q) expected an error", invalid)
+		}
+	}
+}
+
+func TestParseSelector(t *testing.T) {
+	if got, err := parseSelector(""); got != nil || err != nil {
+		t.Errorf("parseSelector(\"\") = %v, %v, expected nil", got, err)
+	}
+	got, err := parseSelector("team=payments,env!=dev")
+	if err != nil || got == nil || !got.Matches(labels.Set{"team": "payments", "env": "prod"}) {
+		t.Errorf("parseSelector(\"team=payments,env!=dev\") = %v, %v", got, err)
+	}
+	if _, err := parseSelector("team in payments"); err == nil {
+		t.Error("parseSelector(\"team in payments\") expected an error")
+	}
+}
+
+func TestNewArchiver(t *testing.T) {
+	t.Run("unset", func(t *testing.T) {
+		archiver, err := newArchiver(config.Config{})
+		if archiver != nil || err != nil {
+			t.Errorf("newArchiver() = %v, %v, want nil, nil", archiver, err)
+		}
+	})
+
+	t.Run("missing credentials", func(t *testing.T) {
+		if _, err := newArchiver(config.Config{ArchiveS3Bucket: "pods"}); err == nil {
+			t.Error("Expected an error without credentials")
+		}
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		archiver, err := newArchiver(config.Config{
+			ArchiveS3Bucket:          "pods",
+			ArchiveS3AccessKeyID:     "key",
+			ArchiveS3SecretAccessKey: "secret",
+			ArchiveS3Prefix:          "reaped",
+		})
+		if err != nil || archiver == nil {
+			t.Fatalf("newArchiver() = %v, %v", archiver, err)
+		}
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "1"}}
+		if key := archiver.Key(pod); key != "reaped/default/web-1.json" {
+			t.Errorf("Key() = %q, want reaped/default/web-1.json", key)
+		}
+	})
+}
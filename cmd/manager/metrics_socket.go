@@ -0,0 +1,79 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// metricsSocketServer serves metrics over a Unix domain socket instead of
+// TCP, for scrapers running alongside the reaper such as a sidecar agent
+type metricsSocketServer struct {
+	path string
+	mux  *http.ServeMux
+}
+
+// newMetricsSocketServer serves the gatherer's metrics on /metrics at the
+// socket path
+func newMetricsSocketServer(path string, gatherer prometheus.Gatherer) *metricsSocketServer {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.HTTPErrorOnError,
+	}))
+	return &metricsSocketServer{path: path, mux: mux}
+}
+
+// AddExtraHandler serves an additional handler next to the metrics
+func (s *metricsSocketServer) AddExtraHandler(path string, handler http.Handler) {
+	s.mux.Handle(path, handler)
+}
+
+// listen listens on the socket, replacing one left behind by a previous run
+func (s *metricsSocketServer) listen() (net.Listener, error) {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to remove stale metrics socket %q: %w", s.path, err)
+	}
+	listener, err := net.Listen("unix", s.path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to listen on metrics socket %q: %w", s.path, err)
+	}
+	return listener, nil
+}
+
+// Start serves metrics until the context is cancelled
+func (s *metricsSocketServer) Start(ctx context.Context) error {
+	listener, err := s.listen()
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: s.mux, ReadHeaderTimeout: 10 * time.Second}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	setupLog.Info("serving metrics", "socket", s.path)
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-done
+	return nil
+}
+
+// NeedLeaderElection serves metrics on every replica, like the built-in
+// metrics server
+func (s *metricsSocketServer) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestMetricsSocketServer(t *testing.T) {
+	// Unix socket paths are limited in length, keep it short
+	dir, err := os.MkdirTemp("", "reaper")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "metrics.sock")
+
+	// A socket left behind by a previous run is replaced
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatalf("Failed to write stale socket: %v", err)
+	}
+
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_total", Help: "test"})
+	registry.MustRegister(counter)
+	counter.Add(3)
+
+	server := newMetricsSocketServer(path, registry)
+	server.AddExtraHandler("/extra", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "extra")
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() { errs <- server.Start(ctx) }()
+
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", path)
+			},
+		},
+		Timeout: 5 * time.Second,
+	}
+	get := func(urlPath string) string {
+		t.Helper()
+		var lastErr error
+		for i := 0; i < 50; i++ {
+			resp, err := httpClient.Get("http://reaper" + urlPath)
+			if err != nil {
+				lastErr = err
+				time.Sleep(20 * time.Millisecond)
+				continue
+			}
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("Failed to read response: %v", err)
+			}
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("Expected status 200 for %s, got %d", urlPath, resp.StatusCode)
+			}
+			return string(body)
+		}
+		t.Fatalf("Failed to scrape %s over the socket: %v", urlPath, lastErr)
+		return ""
+	}
+
+	if body := get("/metrics"); !strings.Contains(body, "test_total 3") {
+		t.Errorf("Expected metrics to contain test_total 3, got:\n%s", body)
+	}
+	if body := get("/extra"); body != "extra" {
+		t.Errorf("Expected extra handler response, got %q", body)
+	}
+
+	cancel()
+	select {
+	case err := <-errs:
+		if err != nil {
+			t.Errorf("Start() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected server to stop when the context is cancelled")
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// pushJobName is the Pushgateway job one-shot runs push their metrics under
+const pushJobName = "evicted-pod-reaper"
+
+// runReap implements `manager reap --once`, running a single sweep over the
+// watched namespaces with the reaper configured from the environment
+func runReap(ctx context.Context, args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("reap", flag.ContinueOnError)
+	fs.SetOutput(out)
+	once := fs.Bool("once", false, "Run a single sweep and exit.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*once {
+		return fmt.Errorf("reap currently only supports --once, run without a subcommand to start the controller")
+	}
+
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return fmt.Errorf("unable to create client: %w", err)
+	}
+	cfg := loadConfig()
+	maintenanceConfigMap, err := parseObjectRef(cfg.MaintenanceConfigMap)
+	if err != nil {
+		return err
+	}
+	reaperConfig, err := parseObjectRef(cfg.ConfigResource)
+	if err != nil {
+		return err
+	}
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetricsWithConfig(cfg.Metrics)
+	podMetrics.Register(registry)
+	statsdClient := newStatsdClient(cfg.StatsdAddress)
+	if statsdClient != nil {
+		podMetrics.SetMirror(statsdClient)
+	}
+
+	reconciler := reconcilerFromConfig(cfg)
+	reconciler.Client = c
+	reconciler.Scheme = scheme
+	reconciler.Metrics = podMetrics
+	podMetrics.SetNamespaceTTLs(reconciler.NamespaceTTLs)
+	reconciler.PreDeleteHook = newPreDeleteHook(cfg.PreDeleteHook, cfg.PreDeleteHookTimeout, cfg.PreDeleteHookEnv)
+	reconciler.MaintenanceConfigMap = maintenanceConfigMap
+	orphanedObjectSelector, err := parseSelector(cfg.OrphanedObjectSelector)
+	if err != nil {
+		return fmt.Errorf("invalid orphaned pod object selector: %w", err)
+	}
+	reconciler.OrphanedObjectSelector = orphanedObjectSelector
+	notifier := newNotifier(cfg.NotifyWebhookURL, cfg.NotifyBatchWindow)
+	reconciler.Notifier = notifier
+	archiver, err := newArchiver(cfg)
+	if err != nil {
+		return err
+	}
+	reconciler.Archiver = archiver
+	// Apply the ReaperConfig once, as the controller does on startup
+	if reaperConfig != nil {
+		configReconciler := &controller.ReaperConfigReconciler{Client: c, Key: *reaperConfig, Target: reconciler}
+		if _, err := configReconciler.Reconcile(ctx, ctrl.Request{NamespacedName: *reaperConfig}); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.CheckNamespaceFallback(); err != nil {
+		return err
+	}
+	if err := cfg.CheckSafeMode(); err != nil {
+		return err
+	}
+	if err := cfg.CheckReasons(); err != nil {
+		return err
+	}
+	namespaces := cfg.Namespaces()
+	if cfg.WatchesAllNamespaces() {
+		namespaces = []string{corev1.NamespaceAll}
+	}
+	sweepErr := sweep(ctx, reconciler, namespaces, cfg.SweepWorkers)
+
+	if notifier != nil {
+		notifier.Flush()
+	}
+	if statsdClient != nil {
+		_ = statsdClient.Close()
+	}
+
+	// Metrics vanish when a one-shot run exits, so hand them to a Pushgateway
+	if url := cfg.PushgatewayURL; url != "" {
+		if err := pushMetrics(url, registry); err != nil {
+			setupLog.Error(err, "unable to push metrics", "url", url)
+		}
+	}
+	return sweepErr
+}
+
+// sweep reconciles every pod in the given namespaces once, spread over up
+// to workers goroutines. Deletions still go through the reconciler's own
+// limits. Requeues are dropped, the pod is picked up again by the next run.
+func sweep(ctx context.Context, r *controller.PodReconciler, namespaces []string, workers int) error {
+	if workers < 1 {
+		workers = 1
+	}
+	var failed atomic.Int64
+	reqs := make(chan ctrl.Request)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range reqs {
+				if _, err := r.Reconcile(ctx, req); err != nil {
+					failed.Add(1)
+				}
+			}
+		}()
+	}
+
+	var listErr error
+	for _, ns := range namespaces {
+		pods := &corev1.PodList{}
+		if err := r.List(ctx, pods, client.InNamespace(ns)); err != nil {
+			listErr = fmt.Errorf("unable to list pods in namespace %q: %w", ns, err)
+			break
+		}
+		for i := range pods.Items {
+			reqs <- ctrl.Request{NamespacedName: types.NamespacedName{
+				Namespace: pods.Items[i].Namespace,
+				Name:      pods.Items[i].Name,
+			}}
+		}
+	}
+	close(reqs)
+	wg.Wait()
+
+	if listErr != nil {
+		return listErr
+	}
+	if n := failed.Load(); n > 0 {
+		return fmt.Errorf("%d pods failed to reconcile", n)
+	}
+	return nil
+}
+
+// pushMetrics pushes everything in the registry to a Pushgateway, replacing
+// the previous run's metrics
+func pushMetrics(url string, g prometheus.Gatherer) error {
+	return push.New(url, pushJobName).Gatherer(g).Push()
+}
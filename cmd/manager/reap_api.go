@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// reapAPIServer serves the reap API, which reaps listed pods on demand
+type reapAPIServer struct {
+	addr string
+	mux  *http.ServeMux
+}
+
+func newReapAPIServer(addr string) *reapAPIServer {
+	return &reapAPIServer{addr: addr, mux: http.NewServeMux()}
+}
+
+// Handle serves a cluster's reap handler at path
+func (s *reapAPIServer) Handle(path string, handler http.Handler) {
+	s.mux.Handle(path, handler)
+}
+
+// Start serves the reap API until the context is cancelled
+func (s *reapAPIServer) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: s.mux, ReadHeaderTimeout: 10 * time.Second}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	setupLog.Info("serving reap API", "addr", listener.Addr().String())
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	<-done
+	return nil
+}
+
+// NeedLeaderElection keeps the reap API to the leader, the only replica
+// reaping
+func (s *reapAPIServer) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestSweep(t *testing.T) {
+	evicted := func(name, namespace string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+			},
+		}
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(evicted("evicted-1", "default"), evicted("evicted-2", "default"), evicted("other", "other"), running).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	r := &controller.PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           podMetrics,
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+	}
+
+	if err := sweep(context.Background(), r, []string{"default"}, 1); err != nil {
+		t.Fatalf("sweep() error = %v", err)
+	}
+
+	pods := &corev1.PodList{}
+	if err := fakeClient.List(context.Background(), pods); err != nil {
+		t.Fatalf("Failed to list pods: %v", err)
+	}
+	remaining := map[string]bool{}
+	for _, pod := range pods.Items {
+		remaining[pod.Name] = true
+	}
+	if len(remaining) != 2 || !remaining["running"] || !remaining["other"] {
+		t.Errorf("Expected only running and other to remain, got %v", remaining)
+	}
+}
+
+func TestSweep_Workers(t *testing.T) {
+	const workers = 4
+	var objs []runtime.Object
+	for i := 0; i < 2*workers; i++ {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("evicted-%d", i), Namespace: "default"},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+			},
+		})
+	}
+
+	// Hold every delete until all workers are in one at the same time
+	var (
+		mu       sync.Mutex
+		inFlight int
+		peak     int
+	)
+	release := make(chan struct{})
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				mu.Lock()
+				inFlight++
+				if inFlight > peak {
+					peak = inFlight
+				}
+				if peak == workers {
+					select {
+					case <-release:
+					default:
+						close(release)
+					}
+				}
+				mu.Unlock()
+				select {
+				case <-release:
+				case <-time.After(5 * time.Second):
+				}
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+				if strings.HasSuffix(obj.GetName(), "-0") {
+					return errors.New("boom")
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &controller.PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           metrics.NewPodMetrics(),
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+	}
+
+	err := sweep(context.Background(), r, []string{"default"}, workers)
+	if err == nil || !strings.Contains(err.Error(), "1 pods failed") {
+		t.Errorf("Expected a single failed pod, got %v", err)
+	}
+	if peak != workers {
+		t.Errorf("Expected %d deletes in flight at once, got %d", workers, peak)
+	}
+
+	pods := &corev1.PodList{}
+	if err := fakeClient.List(context.Background(), pods); err != nil {
+		t.Fatalf("Failed to list pods: %v", err)
+	}
+	if len(pods.Items) != 1 || pods.Items[0].Name != "evicted-0" {
+		t.Errorf("Expected only the failing pod to remain, got %d pods", len(pods.Items))
+	}
+}
+
+func TestPushMetrics(t *testing.T) {
+	var (
+		method, path string
+		families     = map[string]*dto.MetricFamily{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		dec := expfmt.NewDecoder(r.Body, expfmt.ResponseFormat(r.Header))
+		for {
+			mf := &dto.MetricFamily{}
+			if err := dec.Decode(mf); err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("Failed to decode push payload: %v", err)
+				}
+				break
+			}
+			families[mf.GetName()] = mf
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetrics()
+	podMetrics.Register(registry)
+	podMetrics.IncDeleted("default", "BestEffort")
+	podMetrics.IncDeleted("default", "BestEffort")
+
+	if err := pushMetrics(server.URL, registry); err != nil {
+		t.Fatalf("pushMetrics() error = %v", err)
+	}
+
+	if method != http.MethodPut {
+		t.Errorf("push method = %s, want PUT", method)
+	}
+	if want := "/metrics/job/" + pushJobName; path != want {
+		t.Errorf("push path = %s, want %s", path, want)
+	}
+	mf, ok := families["evicted_pods_deleted_total"]
+	if !ok {
+		t.Fatalf("evicted_pods_deleted_total missing from push payload, got %d families", len(families))
+	}
+	if got := mf.GetMetric()[0].GetCounter().GetValue(); got != 2 {
+		t.Errorf("pushed evicted_pods_deleted_total = %v, want 2", got)
+	}
+}
+
+func TestPushMetrics_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	if err := pushMetrics(server.URL, prometheus.NewRegistry()); err == nil {
+		t.Error("pushMetrics() expected an error for a non-2xx response")
+	}
+}
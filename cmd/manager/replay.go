@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// manifestExtensions are the files replay loads from its directory
+var manifestExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true}
+
+// runReplay implements `manager replay --dir manifests/`, printing a table
+// of how the reaper, configured from the environment, would handle every pod
+// manifest in a directory. Manifests that can't be loaded are reported in the
+// table and fail the run once every manifest has been replayed.
+func runReplay(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(out)
+	dir := fs.String("dir", "", "Directory of pod manifests (YAML or JSON), one pod per file.")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return fmt.Errorf("--dir is required")
+	}
+
+	entries, err := os.ReadDir(*dir)
+	if err != nil {
+		return fmt.Errorf("unable to read manifest directory: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() && manifestExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			files = append(files, entry.Name())
+		}
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no pod manifests found in %s", *dir)
+	}
+	sort.Strings(files)
+
+	reconciler := reconcilerFromConfig(loadConfig())
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tPOD\tDECISION")
+	failed := 0
+	for _, file := range files {
+		pod, err := loadPod(filepath.Join(*dir, file))
+		if err != nil {
+			failed++
+			fmt.Fprintf(w, "%s\t-\terror: %v\n", file, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s/%s\t%s\n", file, pod.Namespace, pod.Name, reconciler.Decide(pod))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d manifests could not be loaded", failed, len(files))
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeManifests writes pod manifests to a temporary directory by file name
+// and returns it
+func writeManifests(t *testing.T, manifests map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, manifest := range manifests {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(manifest), 0o600); err != nil {
+			t.Fatalf("Failed to write manifest: %v", err)
+		}
+	}
+	return dir
+}
+
+func evictedManifest(name, startTime string, annotations string) string {
+	return `apiVersion: v1
+kind: Pod
+metadata:
+  name: ` + name + `
+  namespace: default
+` + annotations + `status:
+  phase: Failed
+  reason: Evicted
+  startTime: "` + startTime + `"
+`
+}
+
+func TestRunReplay(t *testing.T) {
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+
+	dir := writeManifests(t, map[string]string{
+		"a-old.yaml":       evictedManifest("old", old, ""),
+		"b-recent.yml":     evictedManifest("recent", recent, ""),
+		"c-preserved.yaml": evictedManifest("preserved", old, "  annotations:\n    pod-reaper.kyos.com/preserve: \"true\"\n"),
+		"d-running.json":   `{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "running", "namespace": "default"}, "status": {"phase": "Running"}}`,
+		"notes.txt":        "not a manifest",
+	})
+
+	var out bytes.Buffer
+	if err := runReplay([]string{"--dir", dir}, &out); err != nil {
+		t.Fatalf("runReplay() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("Expected a header and 4 rows, got:\n%s", out.String())
+	}
+	if fields := strings.Fields(lines[0]); strings.Join(fields, " ") != "FILE POD DECISION" {
+		t.Errorf("Unexpected header %q", lines[0])
+	}
+
+	want := []struct {
+		file, pod, decision string
+	}{
+		{"a-old.yaml", "default/old", "would delete"},
+		{"b-recent.yml", "default/recent", "requeue in "},
+		{"c-preserved.yaml", "default/preserved", "skip, pod is preserved"},
+		{"d-running.json", "default/running", "ignore, pod is not evicted"},
+	}
+	for i, w := range want {
+		row := lines[i+1]
+		if !strings.HasPrefix(row, w.file) || !strings.Contains(row, w.pod) || !strings.Contains(row, w.decision) {
+			t.Errorf("row %d = %q, want %s %s %s", i, row, w.file, w.pod, w.decision)
+		}
+	}
+}
+
+func TestRunReplay_ConfigFromEnv(t *testing.T) {
+	t.Setenv("REAPER_TTL_TO_DELETE", "86400")
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	dir := writeManifests(t, map[string]string{"pod.yaml": evictedManifest("old", old, "")})
+
+	var out bytes.Buffer
+	if err := runReplay([]string{"--dir", dir}, &out); err != nil {
+		t.Fatalf("runReplay() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "requeue in ") {
+		t.Errorf("Expected a longer TTL to requeue the pod, got:\n%s", out.String())
+	}
+}
+
+func TestRunReplay_Errors(t *testing.T) {
+	var out bytes.Buffer
+	if err := runReplay(nil, &out); err == nil {
+		t.Error("Expected an error without --dir")
+	}
+	if err := runReplay([]string{"--dir", filepath.Join(t.TempDir(), "missing")}, &out); err == nil {
+		t.Error("Expected an error for a missing directory")
+	}
+	if err := runReplay([]string{"--dir", t.TempDir()}, &out); err == nil {
+		t.Error("Expected an error for a directory without manifests")
+	}
+
+	old := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	dir := writeManifests(t, map[string]string{
+		"good.yaml": evictedManifest("old", old, ""),
+		"bad.yaml":  "kind: Deployment\n",
+	})
+	out.Reset()
+	err := runReplay([]string{"--dir", dir}, &out)
+	if err == nil {
+		t.Error("Expected an error when a manifest can't be loaded")
+	}
+	if !strings.Contains(out.String(), "would delete") || !strings.Contains(out.String(), "error: ") {
+		t.Errorf("Expected every manifest to be replayed, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/rest"
+)
+
+// setupExitCode is the exit code used when setup fails, from
+// REAPER_EXIT_CODE_ON_SETUP_ERROR
+var setupExitCode = 1
+
+// exitOnSetupError logs an unrecoverable setup error and exits with
+// setupExitCode
+func exitOnSetupError(err error, msg string, keysAndValues ...any) {
+	keysAndValues = append(keysAndValues, "transient", isTransientStartupError(err), "exitCode", setupExitCode)
+	setupLog.Error(err, msg, keysAndValues...)
+	os.Exit(setupExitCode)
+}
+
+// isTransientStartupError checks if a startup error may clear up on its
+// own, such as the API server being unreachable or overloaded, rather than
+// pointing at the configuration or credentials
+func isTransientStartupError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) || apierrors.IsTooManyRequests(err) ||
+		apierrors.IsServiceUnavailable(err) || apierrors.IsInternalError(err) {
+		return true
+	}
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// startupRetry retries transient startup errors with exponential backoff
+// for up to timeout. A zero timeout disables retries.
+type startupRetry struct {
+	timeout  time.Duration
+	delay    time.Duration
+	maxDelay time.Duration
+}
+
+func newStartupRetry(timeout time.Duration) startupRetry {
+	return startupRetry{timeout: timeout, delay: time.Second, maxDelay: 30 * time.Second}
+}
+
+// do runs fn until it succeeds, fails with an error that isn't transient,
+// or the retry timeout would be exceeded, returning its last error
+func (s startupRetry) do(ctx context.Context, step string, fn func() error) error {
+	deadline := time.Now().Add(s.timeout)
+	delay := s.delay
+	for {
+		err := fn()
+		if err == nil || !isTransientStartupError(err) || time.Now().Add(delay).After(deadline) {
+			return err
+		}
+		setupLog.Info("transient startup error, retrying", "step", step, "error", err.Error(), "retryIn", delay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, s.maxDelay)
+	}
+}
+
+// checkAPIServer checks that the API server is reachable and serving
+func checkAPIServer(cfg *rest.Config) error {
+	client, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	_, err = client.ServerVersion()
+	return err
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransientStartupError(t *testing.T) {
+	refused := &url.Error{Op: "Get", URL: "https://10.0.0.1:6443/version", Err: &net.OpError{
+		Op: "dial", Net: "tcp", Err: errors.New("connect: connection refused"),
+	}}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "connection refused", err: refused, want: true},
+		{name: "wrapped connection refused", err: fmt.Errorf("checking API server: %w", refused), want: true},
+		{name: "DNS lookup failure", err: &net.DNSError{Err: "no such host", Name: "api.example.com"}, want: true},
+		{name: "deadline exceeded", err: context.DeadlineExceeded, want: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("starting"), want: true},
+		{name: "too many requests", err: apierrors.NewTooManyRequests("slow down", 1), want: true},
+		{name: "server timeout", err: apierrors.NewServerTimeout(schema.GroupResource{Resource: "pods"}, "list", 1), want: true},
+		{name: "unauthorized", err: apierrors.NewUnauthorized("bad token"), want: false},
+		{name: "forbidden", err: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("denied")), want: false},
+		{name: "configuration error", err: errors.New("invalid kubeconfig"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isTransientStartupError(tt.err); got != tt.want {
+				t.Errorf("isTransientStartupError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartupRetry(t *testing.T) {
+	transient := apierrors.NewServiceUnavailable("starting")
+	fatal := apierrors.NewUnauthorized("bad token")
+
+	tests := []struct {
+		name      string
+		timeout   time.Duration
+		errs      []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "transient error is retried until it clears",
+			timeout:   time.Second,
+			errs:      []error{transient, transient, nil},
+			wantCalls: 3,
+		},
+		{
+			name:      "non-transient error exits immediately",
+			timeout:   time.Second,
+			errs:      []error{fatal, nil},
+			wantCalls: 1,
+			wantErr:   fatal,
+		},
+		{
+			name:      "transient then non-transient error stops retrying",
+			timeout:   time.Second,
+			errs:      []error{transient, fatal, nil},
+			wantCalls: 2,
+			wantErr:   fatal,
+		},
+		{
+			name:      "zero timeout disables retries",
+			errs:      []error{transient, nil},
+			wantCalls: 1,
+			wantErr:   transient,
+		},
+		{
+			name:      "retries stop at the timeout",
+			timeout:   20 * time.Millisecond,
+			wantCalls: -1,
+			wantErr:   transient,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			retry := startupRetry{timeout: tt.timeout, delay: time.Millisecond, maxDelay: 5 * time.Millisecond}
+			calls := 0
+			err := retry.do(context.Background(), "test", func() error {
+				calls++
+				if calls > len(tt.errs) {
+					return transient
+				}
+				return tt.errs[calls-1]
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("do() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantCalls >= 0 && calls != tt.wantCalls {
+				t.Errorf("Expected %d calls, got %d", tt.wantCalls, calls)
+			}
+		})
+	}
+}
+
+func TestStartupRetry_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	retry := startupRetry{timeout: time.Minute, delay: time.Minute, maxDelay: time.Minute}
+	calls := 0
+	err := retry.do(ctx, "test", func() error {
+		calls++
+		return context.DeadlineExceeded
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("Expected a single failed attempt, got %d calls, error %v", calls, err)
+	}
+}
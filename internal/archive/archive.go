@@ -0,0 +1,53 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Uploader stores an object under a key
+type Uploader interface {
+	Upload(ctx context.Context, key string, body []byte) error
+}
+
+// Archiver uploads the manifests of pods about to be reaped, so they can
+// still be inspected once deleted
+type Archiver struct {
+	uploader Uploader
+	prefix   string
+}
+
+// NewArchiver creates an Archiver uploading through uploader, under keys
+// starting with prefix
+func NewArchiver(uploader Uploader, prefix string) *Archiver {
+	return &Archiver{uploader: uploader, prefix: strings.Trim(prefix, "/")}
+}
+
+// Archive uploads a pod's manifest as JSON
+func (a *Archiver) Archive(ctx context.Context, pod *corev1.Pod) error {
+	body, err := Manifest(pod)
+	if err != nil {
+		return err
+	}
+	return a.uploader.Upload(ctx, a.Key(pod), body)
+}
+
+// Key returns the object key of a pod's manifest, unique per pod instance
+// since names are reused
+func (a *Archiver) Key(pod *corev1.Pod) string {
+	return path.Join(a.prefix, pod.Namespace, pod.Name+"-"+string(pod.UID)+".json")
+}
+
+// Manifest serializes a pod the way kubectl would show it, without managed
+// fields, which only take up space
+func Manifest(pod *corev1.Pod) ([]byte, error) {
+	pod = pod.DeepCopy()
+	pod.APIVersion = "v1"
+	pod.Kind = "Pod"
+	pod.ManagedFields = nil
+	return json.MarshalIndent(pod, "", "  ")
+}
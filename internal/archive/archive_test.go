@@ -0,0 +1,90 @@
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// recordingUploader captures uploaded objects
+type recordingUploader struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (u *recordingUploader) Upload(ctx context.Context, key string, body []byte) error {
+	if u.err != nil {
+		return u.err
+	}
+	if u.objects == nil {
+		u.objects = make(map[string][]byte)
+	}
+	u.objects[key] = body
+	return nil
+}
+
+func testPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "web-1",
+			Namespace:     "default",
+			UID:           "1234",
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubelet"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodFailed,
+			Reason: "Evicted",
+		},
+	}
+}
+
+func TestArchiver_Archive(t *testing.T) {
+	tests := []struct {
+		name    string
+		prefix  string
+		wantKey string
+	}{
+		{name: "no prefix", prefix: "", wantKey: "default/web-1-1234.json"},
+		{name: "prefix", prefix: "reaped", wantKey: "reaped/default/web-1-1234.json"},
+		{name: "prefix with slashes", prefix: "/archive/reaped/", wantKey: "archive/reaped/default/web-1-1234.json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploader := &recordingUploader{}
+			a := NewArchiver(uploader, tt.prefix)
+			if err := a.Archive(context.Background(), testPod()); err != nil {
+				t.Fatalf("Archive() error = %v", err)
+			}
+
+			body, ok := uploader.objects[tt.wantKey]
+			if !ok {
+				t.Fatalf("Expected an object under %q, got %v", tt.wantKey, uploader.objects)
+			}
+			var pod corev1.Pod
+			if err := json.Unmarshal(body, &pod); err != nil {
+				t.Fatalf("Failed to decode manifest: %v", err)
+			}
+			if pod.Kind != "Pod" || pod.APIVersion != "v1" {
+				t.Errorf("Expected kind Pod and apiVersion v1, got %q %q", pod.Kind, pod.APIVersion)
+			}
+			if pod.Name != "web-1" || pod.Status.Reason != "Evicted" {
+				t.Errorf("Expected the pod's manifest, got %+v", pod)
+			}
+			if len(pod.ManagedFields) != 0 {
+				t.Errorf("Expected managed fields to be dropped, got %v", pod.ManagedFields)
+			}
+		})
+	}
+}
+
+func TestArchiver_UploadError(t *testing.T) {
+	a := NewArchiver(&recordingUploader{err: errors.New("unavailable")}, "")
+	if err := a.Archive(context.Background(), testPod()); err == nil {
+		t.Error("Expected the upload error to be returned")
+	}
+}
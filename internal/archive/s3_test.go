@@ -0,0 +1,69 @@
+package archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestS3Uploader_Upload(t *testing.T) {
+	var gotPath, gotAuth, gotDate, gotHash string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected PUT, got %s", r.Method)
+		}
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		gotDate = r.Header.Get("X-Amz-Date")
+		gotHash = r.Header.Get("X-Amz-Content-Sha256")
+		gotBody, _ = io.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	u := &S3Uploader{
+		Endpoint:        server.URL + "/",
+		Bucket:          "pods",
+		Region:          "eu-west-1",
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "secret",
+		now:             func() time.Time { return time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC) },
+	}
+	if err := u.Upload(context.Background(), "reaped/default/web 1.json", []byte(`{}`)); err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+
+	if want := "/pods/reaped/default/web%201.json"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if string(gotBody) != "{}" {
+		t.Errorf("body = %q, want {}", gotBody)
+	}
+	if gotDate != "20240501T123000Z" {
+		t.Errorf("X-Amz-Date = %q, want 20240501T123000Z", gotDate)
+	}
+	if want := sha256Hex([]byte(`{}`)); gotHash != want {
+		t.Errorf("X-Amz-Content-Sha256 = %q, want %q", gotHash, want)
+	}
+	authPattern := regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240501/eu-west-1/s3/aws4_request, ` +
+		`SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=[0-9a-f]{64}$`)
+	if !authPattern.MatchString(gotAuth) {
+		t.Errorf("Authorization = %q, unexpected format", gotAuth)
+	}
+}
+
+func TestS3Uploader_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "AccessDenied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	u := &S3Uploader{Endpoint: server.URL, Bucket: "pods", Region: "us-east-1"}
+	if err := u.Upload(context.Background(), "key.json", []byte(`{}`)); err == nil {
+		t.Error("Expected an error for a 403 response")
+	}
+}
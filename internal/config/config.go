@@ -0,0 +1,238 @@
+// Package config describes the reaper's operator-facing settings, loads them
+// from the environment and reports how they change between loads
+package config
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+)
+
+// Config holds the reaper's settings. The fields up to LimitNamespaces
+// decide which pods are reaped and are the ones Diff compares, the rest tune
+// how pods are reaped and how the manager runs.
+type Config struct {
+	TTLToDelete         int
+	WatchAllNamespaces  bool
+	WatchNamespaces     []string
+	NamespacePrefix     string
+	NoDefaultFallback   bool
+	SafeMode            bool
+	Mode                string
+	StandalonePolicy    string
+	PriorityClassFilter []string
+	Reasons             []string
+	ExceptReasons       []string
+	UseEvictionAPI      bool
+	ReapUnschedulable   bool
+	ReapCrashLoop       bool
+
+	// Disabled and LimitNamespaces are only set by a ReaperConfig, pausing
+	// reaping or limiting it to some of the watched namespaces
+	Disabled        bool
+	LimitNamespaces []string
+
+	NamespaceTTLs map[string]int
+	ReasonTTLs    map[string]int
+	LabelTTLs     []controller.LabelTTL
+	OwnedTTL      *int
+	OrphanTTL     *int
+
+	DryRunNamespaces      []string
+	OwnerResolutionDepth  int
+	TransitionUpdatesOnly bool
+
+	UseJobTTL                  bool
+	JobTTLSecondsAfterFinished int32
+
+	MaxPriority                    *int32
+	ContainerTerminationReasons    []string
+	RequireConsecutiveObservations bool
+
+	WaitForOwnerObserved bool
+	WaitForNodeReady     bool
+	ReapOnlyPreReboot    bool
+	WaitForLogsShipped   bool
+	LogsShippedTimeout   time.Duration
+
+	UnschedulableTTL  int
+	CrashLoopDuration time.Duration
+
+	MaxTrackedPods    int
+	DeleteConcurrency int
+	ReconcileDebounce time.Duration
+	StartupJitter     time.Duration
+	HeartbeatInterval time.Duration
+	TeamLabelKey      string
+	AuditAnnotations  []string
+	FieldManager      string
+	CountOwnerKinds   bool
+
+	FutureStartTimePolicy   string
+	TTLAnchor               string
+	StampFirstSeen          bool
+	AnnotateSchedule        bool
+	VerifyDelete            bool
+	EvictionContainerPolicy string
+	ReapEmptySpec           bool
+	RequireAllTerminated    bool
+	DeferUntilCacheSynced   bool
+	FirstPassDryRun         bool
+
+	PreDeleteHook        string
+	PreDeleteHookTimeout time.Duration
+	PreDeleteHookEnv     []string
+
+	NotifyWebhookURL  string
+	NotifyBatchWindow time.Duration
+
+	// ShedErrorRate is zero when load shedding is disabled
+	ShedErrorRate    float64
+	ShedWindow       time.Duration
+	MaxDeleteLatency time.Duration
+
+	ArchiveS3Bucket          string
+	ArchiveS3Endpoint        string
+	ArchiveS3Region          string
+	ArchiveS3AccessKeyID     string
+	ArchiveS3SecretAccessKey string
+	ArchiveS3Prefix          string
+
+	Metrics            metrics.MetricsConfig
+	MetricsTLSCert     string
+	MetricsTLSKey      string
+	MetricsTLSClientCA string
+	MetricsSocket      string
+	StatsdAddress      string
+	PushgatewayURL     string
+
+	ReapAPIAddr  string
+	ReapAPIToken string
+
+	// The selectors, object references and leader election timings below
+	// stop the manager when invalid rather than falling back, so they are
+	// kept as written and parsed where they are used
+	NamespaceSelector      string
+	OrphanedObjectSelector string
+	MaintenanceConfigMap   string
+	ConfigResource         string
+	LeaseDuration          string
+	RenewDeadline          string
+	RetryPeriod            string
+
+	RequireLeader        bool
+	Kubeconfigs          []string
+	PodNamespace         string
+	PodName              string
+	CacheSyncTimeout     time.Duration
+	StartupRetryTimeout  time.Duration
+	ExitCodeOnSetupError int
+	SweepWorkers         int
+}
+
+// Change is a single setting that differs between two configs. Scalar
+// settings fill Old and New, list settings fill Added and Removed.
+type Change struct {
+	Field   string
+	Old     string
+	New     string
+	Added   []string
+	Removed []string
+}
+
+// String formats the change as e.g. "ttlToDelete: 300 -> 600" or
+// "watchNamespaces: +team-a -default"
+func (c Change) String() string {
+	if c.Added == nil && c.Removed == nil {
+		return c.Field + ": " + c.Old + " -> " + c.New
+	}
+	parts := make([]string, 0, len(c.Added)+len(c.Removed))
+	for _, v := range c.Added {
+		parts = append(parts, "+"+v)
+	}
+	for _, v := range c.Removed {
+		parts = append(parts, "-"+v)
+	}
+	return c.Field + ": " + strings.Join(parts, " ")
+}
+
+// Diff lists the settings deciding which pods are reaped that differ from
+// old to new, in field order
+func Diff(old, new Config) []Change {
+	var changes []Change
+	scalar := func(field, o, n string) {
+		if o != n {
+			changes = append(changes, Change{Field: field, Old: o, New: n})
+		}
+	}
+	list := func(field string, o, n []string) {
+		added, removed := setDiff(o, n)
+		if len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, Change{Field: field, Added: added, Removed: removed})
+		}
+	}
+
+	scalar("ttlToDelete", strconv.Itoa(old.TTLToDelete), strconv.Itoa(new.TTLToDelete))
+	scalar("watchAllNamespaces", strconv.FormatBool(old.WatchAllNamespaces), strconv.FormatBool(new.WatchAllNamespaces))
+	list("watchNamespaces", old.WatchNamespaces, new.WatchNamespaces)
+	scalar("namespacePrefix", old.NamespacePrefix, new.NamespacePrefix)
+	scalar("noDefaultFallback", strconv.FormatBool(old.NoDefaultFallback), strconv.FormatBool(new.NoDefaultFallback))
+	scalar("safeMode", strconv.FormatBool(old.SafeMode), strconv.FormatBool(new.SafeMode))
+	scalar("mode", old.Mode, new.Mode)
+	scalar("standalonePolicy", old.StandalonePolicy, new.StandalonePolicy)
+	list("priorityClassFilter", old.PriorityClassFilter, new.PriorityClassFilter)
+	list("reasons", old.Reasons, new.Reasons)
+	list("exceptReasons", old.ExceptReasons, new.ExceptReasons)
+	scalar("useEvictionAPI", strconv.FormatBool(old.UseEvictionAPI), strconv.FormatBool(new.UseEvictionAPI))
+	scalar("reapUnschedulable", strconv.FormatBool(old.ReapUnschedulable), strconv.FormatBool(new.ReapUnschedulable))
+	scalar("reapCrashLoop", strconv.FormatBool(old.ReapCrashLoop), strconv.FormatBool(new.ReapCrashLoop))
+	scalar("disabled", strconv.FormatBool(old.Disabled), strconv.FormatBool(new.Disabled))
+	list("limitNamespaces", old.LimitNamespaces, new.LimitNamespaces)
+	return changes
+}
+
+// LogDiff logs a structured summary of what changed from old to new, one
+// entry per changed setting, so reloads are auditable
+func LogDiff(logger logr.Logger, old, new Config) {
+	changes := Diff(old, new)
+	if len(changes) == 0 {
+		logger.Info("config reloaded, no changes")
+		return
+	}
+	for _, c := range changes {
+		if c.Added == nil && c.Removed == nil {
+			logger.Info("config changed", "field", c.Field, "old", c.Old, "new", c.New)
+		} else {
+			logger.Info("config changed", "field", c.Field, "added", c.Added, "removed", c.Removed)
+		}
+	}
+	logger.Info("config reloaded", "changes", len(changes))
+}
+
+// setDiff returns the sorted values only in n and only in o
+func setDiff(o, n []string) (added, removed []string) {
+	inOld := make(map[string]bool, len(o))
+	for _, v := range o {
+		inOld[v] = true
+	}
+	inNew := make(map[string]bool, len(n))
+	for _, v := range n {
+		inNew[v] = true
+		if !inOld[v] {
+			added = append(added, v)
+		}
+	}
+	for _, v := range o {
+		if !inNew[v] {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
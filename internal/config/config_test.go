@@ -0,0 +1,120 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+)
+
+func TestDiff(t *testing.T) {
+	base := Config{
+		TTLToDelete:      300,
+		WatchNamespaces:  []string{"default", "monitoring"},
+		StandalonePolicy: "ttl",
+	}
+
+	tests := []struct {
+		name string
+		old  Config
+		new  Config
+		want []string
+	}{
+		{
+			name: "no changes",
+			old:  base,
+			new:  base,
+			want: nil,
+		},
+		{
+			name: "namespace order is not a change",
+			old:  base,
+			new: Config{
+				TTLToDelete:      300,
+				WatchNamespaces:  []string{"monitoring", "default"},
+				StandalonePolicy: "ttl",
+			},
+			want: nil,
+		},
+		{
+			name: "TTL and namespaces changed",
+			old:  base,
+			new: Config{
+				TTLToDelete:      600,
+				WatchNamespaces:  []string{"monitoring", "team-b", "team-a"},
+				StandalonePolicy: "ttl",
+			},
+			want: []string{
+				"ttlToDelete: 300 -> 600",
+				"watchNamespaces: +team-a +team-b -default",
+			},
+		},
+		{
+			name: "switched to watching all namespaces in safe mode",
+			old:  base,
+			new: Config{
+				TTLToDelete:        300,
+				WatchAllNamespaces: true,
+				WatchNamespaces:    []string{"default", "monitoring"},
+				SafeMode:           true,
+				StandalonePolicy:   "preserve",
+			},
+			want: []string{
+				"watchAllNamespaces: false -> true",
+				"safeMode: false -> true",
+				"standalonePolicy: ttl -> preserve",
+			},
+		},
+		{
+			name: "priority filter cleared",
+			old:  Config{PriorityClassFilter: []string{"low"}},
+			new:  Config{},
+			want: []string{"priorityClassFilter: -low"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			changes := Diff(tt.old, tt.new)
+			var got []string
+			for _, c := range changes {
+				got = append(got, c.String())
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Diff() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogDiff(t *testing.T) {
+	var lines []string
+	logger := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{})
+
+	LogDiff(logger,
+		Config{TTLToDelete: 300, WatchNamespaces: []string{"default"}},
+		Config{TTLToDelete: 600, WatchNamespaces: []string{"default", "team-a"}},
+	)
+
+	want := []string{
+		`"msg"="config changed" "field"="ttlToDelete" "old"="300" "new"="600"`,
+		`"msg"="config changed" "field"="watchNamespaces" "added"=["team-a"] "removed"=[]`,
+		`"msg"="config reloaded" "changes"=2`,
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d log lines, got %d: %v", len(want), len(lines), lines)
+	}
+	for i := range want {
+		if !strings.Contains(lines[i], want[i]) {
+			t.Errorf("log line %d = %s, want %s", i, lines[i], want[i])
+		}
+	}
+
+	lines = nil
+	LogDiff(logger, Config{TTLToDelete: 300}, Config{TTLToDelete: 300})
+	if len(lines) != 1 || !strings.Contains(lines[0], "no changes") {
+		t.Errorf("Expected a single no changes line, got %v", lines)
+	}
+}
@@ -0,0 +1,464 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/notify"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultTTLToDelete is the TTL in seconds when REAPER_TTL_TO_DELETE is
+	// unset or invalid
+	DefaultTTLToDelete = 300
+	// DefaultNamespace is watched when no namespaces are configured
+	DefaultNamespace = "default"
+)
+
+// LoadFromEnv reads a Config from the REAPER_* environment variables. Invalid
+// values fall back to their defaults and are reported together in the
+// returned error, so a partially valid environment still loads.
+func LoadFromEnv() (Config, error) {
+	l := &loader{}
+	cfg := Config{
+		TTLToDelete:         l.ttl("REAPER_TTL_TO_DELETE"),
+		WatchAllNamespaces:  l.bool("REAPER_WATCH_ALL_NAMESPACES"),
+		WatchNamespaces:     l.namespaces("REAPER_WATCH_NAMESPACES"),
+		NamespacePrefix:     os.Getenv("REAPER_WATCH_NAMESPACE_PREFIX"),
+		NoDefaultFallback:   l.bool("REAPER_NO_DEFAULT_FALLBACK"),
+		SafeMode:            l.bool("REAPER_SAFE_MODE"),
+		Mode:                l.mode("REAPER_MODE"),
+		StandalonePolicy:    l.standalonePolicy("REAPER_STANDALONE_POLICY"),
+		PriorityClassFilter: l.list("REAPER_PRIORITY_CLASS_FILTER"),
+		Reasons:             l.list("REAPER_REAP_REASONS"),
+		ExceptReasons:       l.list("REAPER_REAP_REASONS_EXCEPT"),
+		UseEvictionAPI:      l.bool("REAPER_USE_EVICTION_API"),
+		ReapUnschedulable:   l.bool("REAPER_REAP_UNSCHEDULABLE"),
+		ReapCrashLoop:       l.bool("REAPER_REAP_CRASHLOOP"),
+
+		NamespaceTTLs: l.ttls("REAPER_NAMESPACE_TTLS"),
+		ReasonTTLs:    l.ttls("REAPER_REASON_TTL"),
+		LabelTTLs:     l.labelTTLs("REAPER_LABEL_TTL"),
+		OwnedTTL:      l.optionalTTL("REAPER_OWNED_TTL_SECONDS"),
+		OrphanTTL:     l.optionalTTL("REAPER_ORPHAN_TTL_SECONDS"),
+
+		DryRunNamespaces:      l.list("REAPER_DRY_RUN_NAMESPACES"),
+		OwnerResolutionDepth:  l.int("REAPER_OWNER_RESOLUTION_DEPTH", 5),
+		TransitionUpdatesOnly: l.bool("REAPER_TRANSITION_UPDATES_ONLY"),
+
+		UseJobTTL:                  l.bool("REAPER_USE_JOB_TTL"),
+		JobTTLSecondsAfterFinished: l.int32("REAPER_JOB_TTL_SECONDS_AFTER_FINISHED"),
+
+		MaxPriority:                    l.optionalInt32("REAPER_MAX_PRIORITY"),
+		ContainerTerminationReasons:    l.list("REAPER_CONTAINER_TERMINATION_REASONS"),
+		RequireConsecutiveObservations: l.bool("REAPER_REQUIRE_CONSECUTIVE_OBSERVATIONS"),
+
+		WaitForOwnerObserved: l.bool("REAPER_WAIT_FOR_OWNER_OBSERVED"),
+		WaitForNodeReady:     l.bool("REAPER_WAIT_FOR_NODE_READY"),
+		ReapOnlyPreReboot:    l.bool("REAPER_REAP_ONLY_PRE_REBOOT"),
+		WaitForLogsShipped:   l.bool("REAPER_WAIT_FOR_LOGS_SHIPPED"),
+		LogsShippedTimeout:   l.duration("REAPER_LOGS_SHIPPED_TIMEOUT", 0),
+
+		UnschedulableTTL:  l.int("REAPER_UNSCHEDULABLE_TTL", 3600),
+		CrashLoopDuration: l.duration("REAPER_CRASHLOOP_DURATION", time.Hour),
+
+		MaxTrackedPods:    l.int("REAPER_MAX_TRACKED_PODS", 10000),
+		DeleteConcurrency: l.int("REAPER_DELETE_CONCURRENCY", 0),
+		ReconcileDebounce: l.duration("REAPER_RECONCILE_DEBOUNCE", 0),
+		StartupJitter:     l.duration("REAPER_STARTUP_JITTER", 0),
+		HeartbeatInterval: l.duration("REAPER_HEARTBEAT_INTERVAL", 30*time.Second),
+		TeamLabelKey:      os.Getenv("REAPER_TEAM_LABEL_KEY"),
+		AuditAnnotations:  l.list("REAPER_AUDIT_ANNOTATIONS"),
+		FieldManager:      os.Getenv("REAPER_FIELD_MANAGER"),
+		CountOwnerKinds:   l.bool("REAPER_OWNER_KIND_METRIC"),
+
+		FutureStartTimePolicy:   l.futureStartTimePolicy("REAPER_FUTURE_STARTTIME_POLICY"),
+		TTLAnchor:               l.ttlAnchor("REAPER_TTL_ANCHOR"),
+		StampFirstSeen:          l.bool("REAPER_STAMP_FIRST_SEEN"),
+		AnnotateSchedule:        l.bool("REAPER_ANNOTATE_SCHEDULE"),
+		VerifyDelete:            l.bool("REAPER_VERIFY_DELETE"),
+		EvictionContainerPolicy: l.evictionContainerPolicy("REAPER_EVICTION_CONTAINER_POLICY"),
+		ReapEmptySpec:           l.boolOr("REAPER_REAP_EMPTY_SPEC", true),
+		RequireAllTerminated:    l.bool("REAPER_REQUIRE_ALL_TERMINATED"),
+		DeferUntilCacheSynced:   l.bool("REAPER_DEFER_UNTIL_CACHE_SYNCED"),
+		FirstPassDryRun:         l.bool("REAPER_FIRST_PASS_DRY_RUN"),
+
+		PreDeleteHook:        os.Getenv("REAPER_PRE_DELETE_HOOK"),
+		PreDeleteHookTimeout: l.duration("REAPER_PRE_DELETE_HOOK_TIMEOUT", 0),
+		PreDeleteHookEnv:     l.list("REAPER_PRE_DELETE_HOOK_ENV"),
+
+		NotifyWebhookURL:  os.Getenv("REAPER_NOTIFY_WEBHOOK_URL"),
+		NotifyBatchWindow: l.duration("REAPER_NOTIFY_BATCH_WINDOW", notify.DefaultBatchWindow),
+
+		ShedErrorRate:    l.rate("REAPER_SHED_ERROR_RATE"),
+		ShedWindow:       l.duration("REAPER_SHED_WINDOW", time.Minute),
+		MaxDeleteLatency: time.Duration(l.int("REAPER_MAX_DELETE_LATENCY_MS", 0)) * time.Millisecond,
+
+		ArchiveS3Bucket:          os.Getenv("REAPER_ARCHIVE_S3_BUCKET"),
+		ArchiveS3Endpoint:        os.Getenv("REAPER_ARCHIVE_S3_ENDPOINT"),
+		ArchiveS3Region:          os.Getenv("REAPER_ARCHIVE_S3_REGION"),
+		ArchiveS3AccessKeyID:     os.Getenv("REAPER_ARCHIVE_S3_ACCESS_KEY_ID"),
+		ArchiveS3SecretAccessKey: os.Getenv("REAPER_ARCHIVE_S3_SECRET_ACCESS_KEY"),
+		ArchiveS3Prefix:          os.Getenv("REAPER_ARCHIVE_S3_PREFIX"),
+
+		Metrics: metrics.MetricsConfig{
+			ConstLabels:        l.constLabels("REAPER_METRICS_CONST_LABELS"),
+			Help:               l.help("REAPER_METRICS_HELP"),
+			MaxNamespaces:      l.int("REAPER_MAX_METRIC_NAMESPACES", 0),
+			NamespaceAllowlist: l.list("REAPER_METRICS_NAMESPACE_ALLOWLIST"),
+		},
+		MetricsTLSCert:     os.Getenv("REAPER_METRICS_TLS_CERT"),
+		MetricsTLSKey:      os.Getenv("REAPER_METRICS_TLS_KEY"),
+		MetricsTLSClientCA: os.Getenv("REAPER_METRICS_TLS_CLIENT_CA"),
+		MetricsSocket:      os.Getenv("REAPER_METRICS_SOCKET"),
+		StatsdAddress:      os.Getenv("REAPER_STATSD_ADDRESS"),
+		PushgatewayURL:     os.Getenv("REAPER_PUSHGATEWAY_URL"),
+
+		ReapAPIAddr:  os.Getenv("REAPER_REAP_API_ADDR"),
+		ReapAPIToken: os.Getenv("REAPER_REAP_API_TOKEN"),
+
+		NamespaceSelector:      os.Getenv("REAPER_WATCH_NAMESPACE_SELECTOR"),
+		OrphanedObjectSelector: os.Getenv("REAPER_DELETE_ORPHANED_POD_OBJECTS"),
+		MaintenanceConfigMap:   os.Getenv("REAPER_MAINTENANCE_CONFIGMAP"),
+		ConfigResource:         os.Getenv("REAPER_CONFIG_RESOURCE"),
+		LeaseDuration:          os.Getenv("REAPER_LEASE_DURATION"),
+		RenewDeadline:          os.Getenv("REAPER_RENEW_DEADLINE"),
+		RetryPeriod:            os.Getenv("REAPER_RETRY_PERIOD"),
+
+		RequireLeader:        l.bool("REAPER_REQUIRE_LEADER"),
+		Kubeconfigs:          l.list("REAPER_KUBECONFIGS"),
+		PodNamespace:         os.Getenv("POD_NAMESPACE"),
+		PodName:              os.Getenv("POD_NAME"),
+		CacheSyncTimeout:     l.duration("REAPER_CACHE_SYNC_TIMEOUT", 0),
+		StartupRetryTimeout:  l.duration("REAPER_STARTUP_RETRY_TIMEOUT", 0),
+		ExitCodeOnSetupError: l.exitCode("REAPER_EXIT_CODE_ON_SETUP_ERROR"),
+		SweepWorkers:         l.int("REAPER_SWEEP_WORKERS", 1),
+	}
+	return cfg, errors.Join(l.errs...)
+}
+
+// Namespaces returns the namespaces to watch, the default namespace if none
+// are listed
+func (c Config) Namespaces() []string {
+	if len(c.WatchNamespaces) == 0 {
+		return []string{DefaultNamespace}
+	}
+	return c.WatchNamespaces
+}
+
+// WatchesAllNamespaces reports whether the cache covers every namespace.
+// Namespaces created later can't be added to the cache, so prefix mode
+// watches everything and filters in the reconciler.
+func (c Config) WatchesAllNamespaces() bool {
+	return c.WatchAllNamespaces || c.NamespacePrefix != ""
+}
+
+// CheckNamespaceFallback fails when NoDefaultFallback is set and nothing
+// selects the namespaces to reap, rather than silently limiting the reaper
+// to the default namespace
+func (c Config) CheckNamespaceFallback() error {
+	if c.NoDefaultFallback && !c.WatchesAllNamespaces() && len(c.WatchNamespaces) == 0 {
+		return fmt.Errorf("no namespaces configured, set REAPER_WATCH_NAMESPACES, REAPER_WATCH_ALL_NAMESPACES " +
+			"or REAPER_WATCH_NAMESPACE_PREFIX")
+	}
+	return nil
+}
+
+// CheckSafeMode fails when safe mode is on without REAPER_WATCH_NAMESPACES,
+// rather than silently allowing deletions in the default namespace only
+func (c Config) CheckSafeMode() error {
+	if c.SafeMode && len(c.WatchNamespaces) == 0 {
+		return fmt.Errorf("REAPER_SAFE_MODE requires REAPER_WATCH_NAMESPACES to list the namespaces deletions are allowed in")
+	}
+	return nil
+}
+
+// CheckReasons fails when both an allowlist and a denylist of reasons are
+// set, as it is unclear which one should win
+func (c Config) CheckReasons() error {
+	if len(c.Reasons) > 0 && len(c.ExceptReasons) > 0 {
+		return fmt.Errorf("REAPER_REAP_REASONS and REAPER_REAP_REASONS_EXCEPT are mutually exclusive, set only one")
+	}
+	return nil
+}
+
+// loader reads environment variables, collecting the invalid ones
+type loader struct {
+	errs []error
+}
+
+func (l *loader) invalid(key, value string, fallback any) {
+	l.errs = append(l.errs, fmt.Errorf("invalid %s %q, using %v", key, value, fallback))
+}
+
+// ignored reports an invalid optional setting, or entry of a list setting,
+// that is left out
+func (l *loader) ignored(key, value string) {
+	l.errs = append(l.errs, fmt.Errorf("invalid %s %q, ignoring", key, value))
+}
+
+func (l *loader) bool(key string) bool {
+	return l.boolOr(key, false)
+}
+
+// boolOr reads "true" or "false", fallback if unset or invalid
+func (l *loader) boolOr(key string, fallback bool) bool {
+	switch env := os.Getenv(key); env {
+	case "":
+		return fallback
+	case "true", "false":
+		return env == "true"
+	default:
+		l.invalid(key, env, fallback)
+		return fallback
+	}
+}
+
+// list reads a comma separated list, dropping empty entries
+func (l *loader) list(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// namespaces reads a comma separated list of namespaces, nil if blank
+func (l *loader) namespaces(key string) []string {
+	env := os.Getenv(key)
+	if strings.TrimSpace(env) == "" {
+		return nil
+	}
+	namespaces := strings.Split(env, ",")
+	for i := range namespaces {
+		namespaces[i] = strings.TrimSpace(namespaces[i])
+	}
+	return namespaces
+}
+
+// ttl reads a TTL in seconds, DefaultTTLToDelete if unset or invalid
+func (l *loader) ttl(key string) int {
+	return l.int(key, DefaultTTLToDelete)
+}
+
+// optionalTTL reads a TTL in seconds, nil if unset or invalid
+func (l *loader) optionalTTL(key string) *int {
+	env := os.Getenv(key)
+	if env == "" {
+		return nil
+	}
+	ttl, err := strconv.Atoi(env)
+	if err != nil || ttl < 0 {
+		l.ignored(key, env)
+		return nil
+	}
+	return &ttl
+}
+
+// ttls reads "key=seconds" pairs, such as namespace or reason TTLs, skipping
+// invalid ones
+func (l *loader) ttls(key string) map[string]int {
+	var ttls map[string]int
+	for _, pair := range l.list(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		ttl, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || err != nil || ttl < 0 {
+			l.ignored(key, pair)
+			continue
+		}
+		if ttls == nil {
+			ttls = make(map[string]int)
+		}
+		ttls[strings.TrimSpace(name)] = ttl
+	}
+	return ttls
+}
+
+// labelTTLs reads "key=value:ttl" label rules, keeping their order and
+// skipping invalid ones
+func (l *loader) labelTTLs(key string) []controller.LabelTTL {
+	var rules []controller.LabelTTL
+	for _, rule := range l.list(key) {
+		selector, value, ok := strings.Cut(rule, ":")
+		label, labelValue, hasValue := strings.Cut(selector, "=")
+		ttl, err := strconv.Atoi(strings.TrimSpace(value))
+		if !ok || !hasValue || strings.TrimSpace(label) == "" || err != nil || ttl < 0 {
+			l.ignored(key, rule)
+			continue
+		}
+		rules = append(rules, controller.LabelTTL{
+			Key:   strings.TrimSpace(label),
+			Value: strings.TrimSpace(labelValue),
+			TTL:   ttl,
+		})
+	}
+	return rules
+}
+
+func (l *loader) int(key string, fallback int) int {
+	env := os.Getenv(key)
+	if env == "" {
+		return fallback
+	}
+	value, err := strconv.Atoi(env)
+	if err != nil {
+		l.invalid(key, env, fallback)
+		return fallback
+	}
+	return value
+}
+
+func (l *loader) int32(key string) int32 {
+	env := os.Getenv(key)
+	if env == "" {
+		return 0
+	}
+	value, err := strconv.ParseInt(env, 10, 32)
+	if err != nil {
+		l.invalid(key, env, 0)
+		return 0
+	}
+	return int32(value)
+}
+
+func (l *loader) optionalInt32(key string) *int32 {
+	env := os.Getenv(key)
+	if env == "" {
+		return nil
+	}
+	value, err := strconv.ParseInt(env, 10, 32)
+	if err != nil {
+		l.ignored(key, env)
+		return nil
+	}
+	v := int32(value)
+	return &v
+}
+
+func (l *loader) duration(key string, fallback time.Duration) time.Duration {
+	env := os.Getenv(key)
+	if env == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(env)
+	if err != nil {
+		l.invalid(key, env, fallback)
+		return fallback
+	}
+	return d
+}
+
+// rate reads a fraction strictly between 0 and 1, zero if unset or invalid
+func (l *loader) rate(key string) float64 {
+	env := os.Getenv(key)
+	if env == "" {
+		return 0
+	}
+	rate, err := strconv.ParseFloat(env, 64)
+	if err != nil || rate <= 0 || rate >= 1 {
+		l.ignored(key, env)
+		return 0
+	}
+	return rate
+}
+
+// exitCode reads the exit code used on setup errors. Zero would report
+// success to whatever restarts the reaper, so it and codes outside 1-125,
+// which shells reserve, fall back to 1.
+func (l *loader) exitCode(key string) int {
+	code := l.int(key, 1)
+	if code < 1 || code > 125 {
+		l.invalid(key, os.Getenv(key), 1)
+		return 1
+	}
+	return code
+}
+
+// oneOf reads one of the given values, fallback if unset or invalid
+func (l *loader) oneOf(key, fallback string, values ...string) string {
+	switch env := os.Getenv(key); {
+	case env == "":
+		return fallback
+	case env == fallback || slices.Contains(values, env):
+		return env
+	default:
+		l.invalid(key, env, fallback)
+		return fallback
+	}
+}
+
+func (l *loader) mode(key string) string {
+	return l.oneOf(key, controller.ModeDelete, controller.ModeQuarantine)
+}
+
+func (l *loader) standalonePolicy(key string) string {
+	return l.oneOf(key, controller.StandalonePolicyTTL, controller.StandalonePolicyReap, controller.StandalonePolicyPreserve)
+}
+
+func (l *loader) futureStartTimePolicy(key string) string {
+	return l.oneOf(key, controller.FutureStartTimePolicyZero, controller.FutureStartTimePolicyCreation)
+}
+
+func (l *loader) ttlAnchor(key string) string {
+	return l.oneOf(key, controller.TTLAnchorStart, controller.TTLAnchorAuto)
+}
+
+// evictionContainerPolicy reads the eviction container policy, empty to
+// follow the pod phase
+func (l *loader) evictionContainerPolicy(key string) string {
+	return l.oneOf(key, "", controller.EvictionContainerPolicyAll, controller.EvictionContainerPolicyAny)
+}
+
+// labelNamePattern matches valid Prometheus label names
+var labelNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// constLabels reads constant metric labels from "name=value" pairs, skipping
+// invalid ones
+func (l *loader) constLabels(key string) prometheus.Labels {
+	var labels prometheus.Labels
+	for _, pair := range l.list(key) {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !labelNamePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			l.ignored(key, pair)
+			continue
+		}
+		if labels == nil {
+			labels = make(prometheus.Labels)
+		}
+		labels[name] = strings.TrimSpace(value)
+	}
+	return labels
+}
+
+// help reads metric help text overrides from "metric=help" entries separated
+// by semicolons, since help text may contain commas, skipping invalid ones
+func (l *loader) help(key string) map[string]string {
+	var help map[string]string
+	for _, entry := range strings.Split(os.Getenv(key), ";") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, text, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(name) == "" || strings.TrimSpace(text) == "" {
+			l.ignored(key, entry)
+			continue
+		}
+		if help == nil {
+			help = make(map[string]string)
+		}
+		help[strings.TrimSpace(name)] = strings.TrimSpace(text)
+	}
+	return help
+}
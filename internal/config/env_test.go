@@ -0,0 +1,600 @@
+package config
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/controller"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/notify"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultConfig is the Config loaded from an empty environment
+func defaultConfig() Config {
+	return Config{
+		TTLToDelete:           DefaultTTLToDelete,
+		Mode:                  controller.ModeDelete,
+		StandalonePolicy:      controller.StandalonePolicyTTL,
+		OwnerResolutionDepth:  5,
+		UnschedulableTTL:      3600,
+		CrashLoopDuration:     time.Hour,
+		MaxTrackedPods:        10000,
+		HeartbeatInterval:     30 * time.Second,
+		FutureStartTimePolicy: controller.FutureStartTimePolicyZero,
+		TTLAnchor:             controller.TTLAnchorStart,
+		ReapEmptySpec:         true,
+		NotifyBatchWindow:     notify.DefaultBatchWindow,
+		ShedWindow:            time.Minute,
+		ExitCodeOnSetupError:  1,
+		SweepWorkers:          1,
+	}
+}
+
+func TestLoadFromEnv_Defaults(t *testing.T) {
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+
+	if want := defaultConfig(); !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadFromEnv() = %+v, want %+v", cfg, want)
+	}
+	if got := cfg.Namespaces(); !slices.Equal(got, []string{DefaultNamespace}) {
+		t.Errorf("Namespaces() = %v, want [%s]", got, DefaultNamespace)
+	}
+	if cfg.WatchesAllNamespaces() {
+		t.Error("Expected only the listed namespaces to be watched by default")
+	}
+}
+
+func TestLoadFromEnv_AllFields(t *testing.T) {
+	env := map[string]string{
+		"REAPER_TTL_TO_DELETE":                    "600",
+		"REAPER_WATCH_ALL_NAMESPACES":             "true",
+		"REAPER_WATCH_NAMESPACES":                 "team-a, team-b",
+		"REAPER_WATCH_NAMESPACE_PREFIX":           "team-",
+		"REAPER_NO_DEFAULT_FALLBACK":              "true",
+		"REAPER_SAFE_MODE":                        "true",
+		"REAPER_MODE":                             controller.ModeQuarantine,
+		"REAPER_STANDALONE_POLICY":                controller.StandalonePolicyPreserve,
+		"REAPER_PRIORITY_CLASS_FILTER":            "low,,batch",
+		"REAPER_REAP_REASONS":                     "Evicted",
+		"REAPER_REAP_REASONS_EXCEPT":              "Shutdown",
+		"REAPER_USE_EVICTION_API":                 "true",
+		"REAPER_REAP_UNSCHEDULABLE":               "true",
+		"REAPER_REAP_CRASHLOOP":                   "true",
+		"REAPER_NAMESPACE_TTLS":                   "batch=60",
+		"REAPER_REASON_TTL":                       "Evicted=300",
+		"REAPER_LABEL_TTL":                        "spark-role=driver:600",
+		"REAPER_OWNED_TTL_SECONDS":                "120",
+		"REAPER_ORPHAN_TTL_SECONDS":               "0",
+		"REAPER_DRY_RUN_NAMESPACES":               "staging",
+		"REAPER_OWNER_RESOLUTION_DEPTH":           "2",
+		"REAPER_TRANSITION_UPDATES_ONLY":          "true",
+		"REAPER_USE_JOB_TTL":                      "true",
+		"REAPER_JOB_TTL_SECONDS_AFTER_FINISHED":   "30",
+		"REAPER_MAX_PRIORITY":                     "1000",
+		"REAPER_CONTAINER_TERMINATION_REASONS":    "OOMKilled",
+		"REAPER_REQUIRE_CONSECUTIVE_OBSERVATIONS": "true",
+		"REAPER_WAIT_FOR_OWNER_OBSERVED":          "true",
+		"REAPER_WAIT_FOR_NODE_READY":              "true",
+		"REAPER_REAP_ONLY_PRE_REBOOT":             "true",
+		"REAPER_WAIT_FOR_LOGS_SHIPPED":            "true",
+		"REAPER_LOGS_SHIPPED_TIMEOUT":             "10m",
+		"REAPER_UNSCHEDULABLE_TTL":                "600",
+		"REAPER_CRASHLOOP_DURATION":               "30m",
+		"REAPER_MAX_TRACKED_PODS":                 "500",
+		"REAPER_DELETE_CONCURRENCY":               "4",
+		"REAPER_RECONCILE_DEBOUNCE":               "2s",
+		"REAPER_STARTUP_JITTER":                   "5s",
+		"REAPER_HEARTBEAT_INTERVAL":               "1m",
+		"REAPER_TEAM_LABEL_KEY":                   "team",
+		"REAPER_AUDIT_ANNOTATIONS":                "owner",
+		"REAPER_FIELD_MANAGER":                    "reaper",
+		"REAPER_OWNER_KIND_METRIC":                "true",
+		"REAPER_FUTURE_STARTTIME_POLICY":          controller.FutureStartTimePolicyCreation,
+		"REAPER_TTL_ANCHOR":                       controller.TTLAnchorAuto,
+		"REAPER_STAMP_FIRST_SEEN":                 "true",
+		"REAPER_ANNOTATE_SCHEDULE":                "true",
+		"REAPER_VERIFY_DELETE":                    "true",
+		"REAPER_EVICTION_CONTAINER_POLICY":        controller.EvictionContainerPolicyAll,
+		"REAPER_REAP_EMPTY_SPEC":                  "false",
+		"REAPER_REQUIRE_ALL_TERMINATED":           "true",
+		"REAPER_DEFER_UNTIL_CACHE_SYNCED":         "true",
+		"REAPER_FIRST_PASS_DRY_RUN":               "true",
+		"REAPER_PRE_DELETE_HOOK":                  "/bin/check",
+		"REAPER_PRE_DELETE_HOOK_TIMEOUT":          "5s",
+		"REAPER_PRE_DELETE_HOOK_ENV":              "HOME",
+		"REAPER_NOTIFY_WEBHOOK_URL":               "https://hooks.example",
+		"REAPER_NOTIFY_BATCH_WINDOW":              "1m",
+		"REAPER_SHED_ERROR_RATE":                  "0.5",
+		"REAPER_SHED_WINDOW":                      "2m",
+		"REAPER_MAX_DELETE_LATENCY_MS":            "250",
+		"REAPER_ARCHIVE_S3_BUCKET":                "pods",
+		"REAPER_ARCHIVE_S3_ENDPOINT":              "https://s3.example",
+		"REAPER_ARCHIVE_S3_REGION":                "eu-west-1",
+		"REAPER_ARCHIVE_S3_ACCESS_KEY_ID":         "key",
+		"REAPER_ARCHIVE_S3_SECRET_ACCESS_KEY":     "secret",
+		"REAPER_ARCHIVE_S3_PREFIX":                "reaped",
+		"REAPER_METRICS_CONST_LABELS":             "service=reaper",
+		"REAPER_METRICS_HELP":                     "reaper_reconciles_total=Reconciles",
+		"REAPER_MAX_METRIC_NAMESPACES":            "50",
+		"REAPER_METRICS_NAMESPACE_ALLOWLIST":      "team-a",
+		"REAPER_METRICS_TLS_CERT":                 "/certs/tls.crt",
+		"REAPER_METRICS_TLS_KEY":                  "/certs/tls.key",
+		"REAPER_METRICS_TLS_CLIENT_CA":            "/certs/ca.crt",
+		"REAPER_METRICS_SOCKET":                   "/run/metrics.sock",
+		"REAPER_STATSD_ADDRESS":                   "statsd:8125",
+		"REAPER_PUSHGATEWAY_URL":                  "http://pushgateway:9091",
+		"REAPER_REAP_API_ADDR":                    ":8082",
+		"REAPER_REAP_API_TOKEN":                   "token",
+		"REAPER_WATCH_NAMESPACE_SELECTOR":         "team=payments",
+		"REAPER_DELETE_ORPHANED_POD_OBJECTS":      "app=web",
+		"REAPER_MAINTENANCE_CONFIGMAP":            "kube-system/maintenance",
+		"REAPER_CONFIG_RESOURCE":                  "kube-system/reaper",
+		"REAPER_LEASE_DURATION":                   "60s",
+		"REAPER_RENEW_DEADLINE":                   "40s",
+		"REAPER_RETRY_PERIOD":                     "5s",
+		"REAPER_REQUIRE_LEADER":                   "true",
+		"REAPER_KUBECONFIGS":                      "/kube/a.yaml, /kube/b.yaml#edge",
+		"POD_NAMESPACE":                           "reaper",
+		"POD_NAME":                                "reaper-0",
+		"REAPER_CACHE_SYNC_TIMEOUT":               "2m",
+		"REAPER_STARTUP_RETRY_TIMEOUT":            "1m",
+		"REAPER_EXIT_CODE_ON_SETUP_ERROR":         "78",
+		"REAPER_SWEEP_WORKERS":                    "8",
+	}
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("LoadFromEnv() error = %v", err)
+	}
+
+	ownedTTL, orphanTTL, maxPriority := 120, 0, int32(1000)
+	want := Config{
+		TTLToDelete:         600,
+		WatchAllNamespaces:  true,
+		WatchNamespaces:     []string{"team-a", "team-b"},
+		NamespacePrefix:     "team-",
+		NoDefaultFallback:   true,
+		SafeMode:            true,
+		Mode:                controller.ModeQuarantine,
+		StandalonePolicy:    controller.StandalonePolicyPreserve,
+		PriorityClassFilter: []string{"low", "batch"},
+		Reasons:             []string{"Evicted"},
+		ExceptReasons:       []string{"Shutdown"},
+		UseEvictionAPI:      true,
+		ReapUnschedulable:   true,
+		ReapCrashLoop:       true,
+
+		NamespaceTTLs: map[string]int{"batch": 60},
+		ReasonTTLs:    map[string]int{"Evicted": 300},
+		LabelTTLs:     []controller.LabelTTL{{Key: "spark-role", Value: "driver", TTL: 600}},
+		OwnedTTL:      &ownedTTL,
+		OrphanTTL:     &orphanTTL,
+
+		DryRunNamespaces:      []string{"staging"},
+		OwnerResolutionDepth:  2,
+		TransitionUpdatesOnly: true,
+
+		UseJobTTL:                  true,
+		JobTTLSecondsAfterFinished: 30,
+
+		MaxPriority:                    &maxPriority,
+		ContainerTerminationReasons:    []string{"OOMKilled"},
+		RequireConsecutiveObservations: true,
+
+		WaitForOwnerObserved: true,
+		WaitForNodeReady:     true,
+		ReapOnlyPreReboot:    true,
+		WaitForLogsShipped:   true,
+		LogsShippedTimeout:   10 * time.Minute,
+
+		UnschedulableTTL:  600,
+		CrashLoopDuration: 30 * time.Minute,
+
+		MaxTrackedPods:    500,
+		DeleteConcurrency: 4,
+		ReconcileDebounce: 2 * time.Second,
+		StartupJitter:     5 * time.Second,
+		HeartbeatInterval: time.Minute,
+		TeamLabelKey:      "team",
+		AuditAnnotations:  []string{"owner"},
+		FieldManager:      "reaper",
+		CountOwnerKinds:   true,
+
+		FutureStartTimePolicy:   controller.FutureStartTimePolicyCreation,
+		TTLAnchor:               controller.TTLAnchorAuto,
+		StampFirstSeen:          true,
+		AnnotateSchedule:        true,
+		VerifyDelete:            true,
+		EvictionContainerPolicy: controller.EvictionContainerPolicyAll,
+		ReapEmptySpec:           false,
+		RequireAllTerminated:    true,
+		DeferUntilCacheSynced:   true,
+		FirstPassDryRun:         true,
+
+		PreDeleteHook:        "/bin/check",
+		PreDeleteHookTimeout: 5 * time.Second,
+		PreDeleteHookEnv:     []string{"HOME"},
+
+		NotifyWebhookURL:  "https://hooks.example",
+		NotifyBatchWindow: time.Minute,
+
+		ShedErrorRate:    0.5,
+		ShedWindow:       2 * time.Minute,
+		MaxDeleteLatency: 250 * time.Millisecond,
+
+		ArchiveS3Bucket:          "pods",
+		ArchiveS3Endpoint:        "https://s3.example",
+		ArchiveS3Region:          "eu-west-1",
+		ArchiveS3AccessKeyID:     "key",
+		ArchiveS3SecretAccessKey: "secret",
+		ArchiveS3Prefix:          "reaped",
+
+		Metrics: metrics.MetricsConfig{
+			ConstLabels:        prometheus.Labels{"service": "reaper"},
+			Help:               map[string]string{"reaper_reconciles_total": "Reconciles"},
+			MaxNamespaces:      50,
+			NamespaceAllowlist: []string{"team-a"},
+		},
+		MetricsTLSCert:     "/certs/tls.crt",
+		MetricsTLSKey:      "/certs/tls.key",
+		MetricsTLSClientCA: "/certs/ca.crt",
+		MetricsSocket:      "/run/metrics.sock",
+		StatsdAddress:      "statsd:8125",
+		PushgatewayURL:     "http://pushgateway:9091",
+
+		ReapAPIAddr:  ":8082",
+		ReapAPIToken: "token",
+
+		NamespaceSelector:      "team=payments",
+		OrphanedObjectSelector: "app=web",
+		MaintenanceConfigMap:   "kube-system/maintenance",
+		ConfigResource:         "kube-system/reaper",
+		LeaseDuration:          "60s",
+		RenewDeadline:          "40s",
+		RetryPeriod:            "5s",
+
+		RequireLeader:        true,
+		Kubeconfigs:          []string{"/kube/a.yaml", "/kube/b.yaml#edge"},
+		PodNamespace:         "reaper",
+		PodName:              "reaper-0",
+		CacheSyncTimeout:     2 * time.Minute,
+		StartupRetryTimeout:  time.Minute,
+		ExitCodeOnSetupError: 78,
+		SweepWorkers:         8,
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("LoadFromEnv() = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadFromEnv_Invalid(t *testing.T) {
+	t.Setenv("REAPER_TTL_TO_DELETE", "not-a-number")
+	t.Setenv("REAPER_STANDALONE_POLICY", "sometimes")
+	t.Setenv("REAPER_SAFE_MODE", "true")
+
+	cfg, err := LoadFromEnv()
+	if err == nil {
+		t.Fatal("Expected the invalid settings to be reported")
+	}
+	for _, key := range []string{"REAPER_TTL_TO_DELETE", "REAPER_STANDALONE_POLICY"} {
+		if !strings.Contains(err.Error(), key) {
+			t.Errorf("Expected the error to name %s, got %v", key, err)
+		}
+	}
+
+	// The rest of the config still loads
+	if cfg.TTLToDelete != DefaultTTLToDelete || cfg.StandalonePolicy != controller.StandalonePolicyTTL || !cfg.SafeMode {
+		t.Errorf("LoadFromEnv() = %+v, want defaults for the invalid settings only", cfg)
+	}
+}
+
+func TestLoadFromEnv_InvalidFallsBackToDefault(t *testing.T) {
+	tests := map[string]string{
+		"REAPER_TTL_TO_DELETE":                    "soon",
+		"REAPER_WATCH_ALL_NAMESPACES":             "yes",
+		"REAPER_NO_DEFAULT_FALLBACK":              "yes",
+		"REAPER_SAFE_MODE":                        "yes",
+		"REAPER_MODE":                             "dry-run",
+		"REAPER_STANDALONE_POLICY":                "delete",
+		"REAPER_USE_EVICTION_API":                 "yes",
+		"REAPER_REAP_UNSCHEDULABLE":               "yes",
+		"REAPER_REAP_CRASHLOOP":                   "yes",
+		"REAPER_NAMESPACE_TTLS":                   "batch",
+		"REAPER_REASON_TTL":                       "Evicted=-1",
+		"REAPER_LABEL_TTL":                        "spark-role:600",
+		"REAPER_OWNED_TTL_SECONDS":                "-1",
+		"REAPER_ORPHAN_TTL_SECONDS":               "abc",
+		"REAPER_OWNER_RESOLUTION_DEPTH":           "deep",
+		"REAPER_TRANSITION_UPDATES_ONLY":          "yes",
+		"REAPER_USE_JOB_TTL":                      "yes",
+		"REAPER_JOB_TTL_SECONDS_AFTER_FINISHED":   "99999999999",
+		"REAPER_MAX_PRIORITY":                     "high",
+		"REAPER_REQUIRE_CONSECUTIVE_OBSERVATIONS": "yes",
+		"REAPER_WAIT_FOR_OWNER_OBSERVED":          "yes",
+		"REAPER_WAIT_FOR_NODE_READY":              "yes",
+		"REAPER_REAP_ONLY_PRE_REBOOT":             "yes",
+		"REAPER_WAIT_FOR_LOGS_SHIPPED":            "yes",
+		"REAPER_LOGS_SHIPPED_TIMEOUT":             "later",
+		"REAPER_UNSCHEDULABLE_TTL":                "1h",
+		"REAPER_CRASHLOOP_DURATION":               "60",
+		"REAPER_MAX_TRACKED_PODS":                 "many",
+		"REAPER_DELETE_CONCURRENCY":               "some",
+		"REAPER_RECONCILE_DEBOUNCE":               "2",
+		"REAPER_STARTUP_JITTER":                   "5",
+		"REAPER_HEARTBEAT_INTERVAL":               "often",
+		"REAPER_OWNER_KIND_METRIC":                "yes",
+		"REAPER_FUTURE_STARTTIME_POLICY":          "skip",
+		"REAPER_TTL_ANCHOR":                       "ready",
+		"REAPER_STAMP_FIRST_SEEN":                 "yes",
+		"REAPER_ANNOTATE_SCHEDULE":                "yes",
+		"REAPER_VERIFY_DELETE":                    "yes",
+		"REAPER_EVICTION_CONTAINER_POLICY":        "some",
+		"REAPER_REAP_EMPTY_SPEC":                  "no",
+		"REAPER_REQUIRE_ALL_TERMINATED":           "yes",
+		"REAPER_DEFER_UNTIL_CACHE_SYNCED":         "yes",
+		"REAPER_FIRST_PASS_DRY_RUN":               "yes",
+		"REAPER_PRE_DELETE_HOOK_TIMEOUT":          "5",
+		"REAPER_NOTIFY_BATCH_WINDOW":              "1",
+		"REAPER_SHED_ERROR_RATE":                  "1.5",
+		"REAPER_SHED_WINDOW":                      "1",
+		"REAPER_MAX_DELETE_LATENCY_MS":            "1s",
+		"REAPER_METRICS_CONST_LABELS":             "bad-name=x",
+		"REAPER_METRICS_HELP":                     "invalid",
+		"REAPER_MAX_METRIC_NAMESPACES":            "all",
+		"REAPER_REQUIRE_LEADER":                   "yes",
+		"REAPER_CACHE_SYNC_TIMEOUT":               "30",
+		"REAPER_STARTUP_RETRY_TIMEOUT":            "30",
+		"REAPER_EXIT_CODE_ON_SETUP_ERROR":         "126",
+		"REAPER_SWEEP_WORKERS":                    "four",
+	}
+
+	for key, value := range tests {
+		t.Run(key, func(t *testing.T) {
+			t.Setenv(key, value)
+			cfg, err := LoadFromEnv()
+			if err == nil || !strings.Contains(err.Error(), key) {
+				t.Errorf("LoadFromEnv() error = %v, want one naming %s", err, key)
+			}
+			if want := defaultConfig(); !reflect.DeepEqual(cfg, want) {
+				t.Errorf("LoadFromEnv() = %+v, want the defaults %+v", cfg, want)
+			}
+		})
+	}
+}
+
+func TestLoadFromEnv_TTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+		wantErr  bool
+	}{
+		{name: "empty string returns default", input: "", expected: 300},
+		{name: "valid integer", input: "600", expected: 600},
+		{name: "zero value", input: "0", expected: 0},
+		{name: "negative value", input: "-100", expected: -100},
+		{name: "invalid string returns default", input: "not-a-number", expected: 300, wantErr: true},
+		{name: "very large number", input: "86400", expected: 86400},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REAPER_TTL_TO_DELETE", tt.input)
+			cfg, err := LoadFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cfg.TTLToDelete != tt.expected {
+				t.Errorf("TTLToDelete = %d, expected %d", cfg.TTLToDelete, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadFromEnv_StandalonePolicy(t *testing.T) {
+	tests := map[string]string{
+		"":         "ttl",
+		"ttl":      "ttl",
+		"reap":     "reap",
+		"preserve": "preserve",
+		"delete":   "ttl",
+	}
+	for input, expected := range tests {
+		t.Setenv("REAPER_STANDALONE_POLICY", input)
+		cfg, err := LoadFromEnv()
+		if (err != nil) != (input == "delete") {
+			t.Errorf("LoadFromEnv() with policy %q error = %v", input, err)
+		}
+		if cfg.StandalonePolicy != expected {
+			t.Errorf("StandalonePolicy for %q = %q, expected %q", input, cfg.StandalonePolicy, expected)
+		}
+	}
+}
+
+func TestLoadFromEnv_Mode(t *testing.T) {
+	tests := map[string]string{
+		"":           "delete",
+		"delete":     "delete",
+		"quarantine": "quarantine",
+		"dry-run":    "delete",
+	}
+	for input, expected := range tests {
+		t.Setenv("REAPER_MODE", input)
+		cfg, err := LoadFromEnv()
+		if (err != nil) != (input == "dry-run") {
+			t.Errorf("LoadFromEnv() with mode %q error = %v", input, err)
+		}
+		if cfg.Mode != expected {
+			t.Errorf("Mode for %q = %q, expected %q", input, cfg.Mode, expected)
+		}
+	}
+}
+
+func TestConfig_Namespaces(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{name: "empty string returns default", input: "", expected: []string{"default"}},
+		{name: "blank string returns default", input: " ", expected: []string{"default"}},
+		{name: "single namespace", input: "kube-system", expected: []string{"kube-system"}},
+		{name: "multiple namespaces", input: "kube-system,monitoring,default", expected: []string{"kube-system", "monitoring", "default"}},
+		{name: "namespaces with spaces", input: "kube-system, monitoring , default", expected: []string{"kube-system", "monitoring", "default"}},
+		{name: "duplicate namespaces", input: "default,default,monitoring", expected: []string{"default", "default", "monitoring"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("REAPER_WATCH_NAMESPACES", tt.input)
+			cfg, err := LoadFromEnv()
+			if err != nil {
+				t.Fatalf("LoadFromEnv() error = %v", err)
+			}
+			if got := cfg.Namespaces(); !slices.Equal(got, tt.expected) {
+				t.Errorf("Namespaces() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConfig_CheckNamespaceFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "fallback to default allowed", wantErr: false},
+		{name: "no fallback without namespaces", cfg: Config{NoDefaultFallback: true}, wantErr: true},
+		{name: "no fallback with namespaces", cfg: Config{NoDefaultFallback: true, WatchNamespaces: []string{"team-a"}}, wantErr: false},
+		{name: "no fallback watching all namespaces", cfg: Config{NoDefaultFallback: true, WatchAllNamespaces: true}, wantErr: false},
+		{name: "no fallback with a prefix", cfg: Config{NoDefaultFallback: true, NamespacePrefix: "team-"}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckNamespaceFallback()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckNamespaceFallback() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_CheckSafeMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "safe mode off", wantErr: false},
+		{name: "safe mode without namespaces", cfg: Config{SafeMode: true}, wantErr: true},
+		{name: "safe mode watching all namespaces", cfg: Config{SafeMode: true, WatchAllNamespaces: true}, wantErr: true},
+		{name: "safe mode with namespaces", cfg: Config{SafeMode: true, WatchNamespaces: []string{"team-a"}}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckSafeMode()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckSafeMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_CheckReasons(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{name: "neither set", wantErr: false},
+		{name: "allowlist only", cfg: Config{Reasons: []string{"Evicted", "Shutdown"}}, wantErr: false},
+		{name: "denylist only", cfg: Config{ExceptReasons: []string{"OOMKilled"}}, wantErr: false},
+		{name: "both set", cfg: Config{Reasons: []string{"Evicted"}, ExceptReasons: []string{"OOMKilled"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.CheckReasons()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckReasons() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadFromEnv_TTLs(t *testing.T) {
+	t.Setenv("REAPER_NAMESPACE_TTLS", "batch=60, prod = 86400,broken,negative=-1,nan=abc")
+	t.Setenv("REAPER_REASON_TTL", "Evicted=300,DeadlineExceeded=60,NodeAffinity=0")
+	t.Setenv("REAPER_LABEL_TTL", "spark-role=driver:600, workflows.argoproj.io/completed = true : 60,broken,noval:10,neg=x:-1,nan=x:abc")
+
+	cfg, err := LoadFromEnv()
+	if err == nil {
+		t.Error("Expected the invalid entries to be reported")
+	}
+	if want := map[string]int{"batch": 60, "prod": 86400}; !maps.Equal(cfg.NamespaceTTLs, want) {
+		t.Errorf("NamespaceTTLs = %v, expected %v", cfg.NamespaceTTLs, want)
+	}
+	if want := map[string]int{"Evicted": 300, "DeadlineExceeded": 60, "NodeAffinity": 0}; !maps.Equal(cfg.ReasonTTLs, want) {
+		t.Errorf("ReasonTTLs = %v, expected %v", cfg.ReasonTTLs, want)
+	}
+	want := []controller.LabelTTL{
+		{Key: "spark-role", Value: "driver", TTL: 600},
+		{Key: "workflows.argoproj.io/completed", Value: "true", TTL: 60},
+	}
+	if !slices.Equal(cfg.LabelTTLs, want) {
+		t.Errorf("LabelTTLs = %v, expected %v", cfg.LabelTTLs, want)
+	}
+}
+
+func TestLoadFromEnv_Metrics(t *testing.T) {
+	t.Setenv("REAPER_METRICS_CONST_LABELS", "service=reaper, team = platform,bad-name=x,__reserved=x,novalue")
+	t.Setenv("REAPER_METRICS_HELP", "evicted_pods_deleted_total=Pods reaped, by namespace; reaper_reconciles_total=Reconciles;invalid")
+
+	cfg, err := LoadFromEnv()
+	if err == nil {
+		t.Error("Expected the invalid entries to be reported")
+	}
+	if want := (prometheus.Labels{"service": "reaper", "team": "platform"}); !maps.Equal(cfg.Metrics.ConstLabels, want) {
+		t.Errorf("ConstLabels = %v, expected %v", cfg.Metrics.ConstLabels, want)
+	}
+	wantHelp := map[string]string{
+		"evicted_pods_deleted_total": "Pods reaped, by namespace",
+		"reaper_reconciles_total":    "Reconciles",
+	}
+	if !maps.Equal(cfg.Metrics.Help, wantHelp) {
+		t.Errorf("Help = %v, expected %v", cfg.Metrics.Help, wantHelp)
+	}
+}
+
+func TestLoadFromEnv_ExitCode(t *testing.T) {
+	tests := map[string]int{
+		"":    1,
+		"1":   1,
+		"78":  78,
+		"0":   1,
+		"126": 1,
+		"-1":  1,
+		"abc": 1,
+	}
+	for input, expected := range tests {
+		t.Setenv("REAPER_EXIT_CODE_ON_SETUP_ERROR", input)
+		cfg, _ := LoadFromEnv()
+		if cfg.ExitCodeOnSetupError != expected {
+			t.Errorf("ExitCodeOnSetupError for %q = %d, expected %d", input, cfg.ExitCodeOnSetupError, expected)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Timestamps a pod's TTL is measured from
+const (
+	// TTLAnchorStart measures the TTL from the pod's StartTime
+	TTLAnchorStart = "start"
+	// TTLAnchorAuto measures the TTL from the most recent sensible timestamp
+	// among the StartTime, creationTimestamp and condition transitions, for
+	// pods whose StartTime is unreliable
+	TTLAnchorAuto = "auto"
+)
+
+// anchorTimeAnnotation pins the time a pod's age is measured from, as an
+// RFC3339 timestamp, for replayed or imported pods whose own timestamps are
+// misleading
+const anchorTimeAnnotation = "pod-reaper.kyos.com/anchor-time"
+
+// pinnedAnchor returns the time a pod's anchor-time annotation pins its age
+// to. It returns false if the annotation is missing or not a valid RFC3339
+// timestamp.
+func pinnedAnchor(pod *corev1.Pod) (time.Time, bool) {
+	value, ok := pod.Annotations[anchorTimeAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil || t.IsZero() {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// bestAnchor returns the most recent sensible timestamp of a pod among its
+// StartTime, creationTimestamp and the last transitions of its Ready and
+// DisruptionTarget conditions. Timestamps too far in the future or too old
+// to be real are ignored. It returns false if none is sensible.
+func bestAnchor(pod *corev1.Pod, now time.Time) (time.Time, bool) {
+	candidates := []time.Time{pod.CreationTimestamp.Time}
+	if pod.Status.StartTime != nil {
+		candidates = append(candidates, pod.Status.StartTime.Time)
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady || cond.Type == corev1.DisruptionTarget {
+			candidates = append(candidates, cond.LastTransitionTime.Time)
+		}
+	}
+
+	var best time.Time
+	for _, t := range candidates {
+		if t.IsZero() || t.Sub(now) > futureStartTimeTolerance || now.Sub(t) > maxPodAge {
+			continue
+		}
+		if t.After(best) {
+			best = t
+		}
+	}
+	return best, !best.IsZero()
+}
+
+// ageStart returns when a pod's age is measured from: the time pinned by its
+// anchor-time annotation if valid, else when it was first seen evicted if
+// stamped, else its TTL anchor. Pods starting in the future are
+// measured according to FutureStartTimePolicy. It returns false if there is
+// nothing to measure from.
+func (r *PodReconciler) ageStart(pod *corev1.Pod) (time.Time, bool) {
+	if pinned, ok := pinnedAnchor(pod); ok {
+		return pinned, true
+	}
+	if start, stamped := r.firstSeen(pod); stamped {
+		return start, true
+	}
+	if r.TTLAnchor == TTLAnchorAuto {
+		return bestAnchor(pod, time.Now())
+	}
+	if pod.Status.StartTime == nil {
+		return time.Time{}, false
+	}
+	if hasFutureStartTime(pod) && r.FutureStartTimePolicy == FutureStartTimePolicyCreation &&
+		!pod.CreationTimestamp.IsZero() {
+		return pod.CreationTimestamp.Time, true
+	}
+	return pod.Status.StartTime.Time, true
+}
@@ -0,0 +1,268 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestBestAnchor(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	condition := func(condType corev1.PodConditionType, at time.Time) corev1.PodCondition {
+		return corev1.PodCondition{Type: condType, Status: corev1.ConditionFalse, LastTransitionTime: metav1.Time{Time: at}}
+	}
+
+	tests := []struct {
+		name       string
+		created    time.Time
+		started    *time.Time
+		conditions []corev1.PodCondition
+		want       time.Time
+		wantOK     bool
+	}{
+		{
+			name:    "start time after creation",
+			created: ago(time.Hour),
+			started: ptrTime(ago(50 * time.Minute)),
+			want:    ago(50 * time.Minute),
+			wantOK:  true,
+		},
+		{
+			name:       "ready transition is most recent",
+			created:    ago(time.Hour),
+			started:    ptrTime(ago(50 * time.Minute)),
+			conditions: []corev1.PodCondition{condition(corev1.PodReady, ago(10*time.Minute))},
+			want:       ago(10 * time.Minute),
+			wantOK:     true,
+		},
+		{
+			name:    "disruption target transition is most recent",
+			created: ago(time.Hour),
+			conditions: []corev1.PodCondition{
+				condition(corev1.PodReady, ago(20*time.Minute)),
+				condition(corev1.DisruptionTarget, ago(5*time.Minute)),
+			},
+			want:   ago(5 * time.Minute),
+			wantOK: true,
+		},
+		{
+			name:       "other conditions are ignored",
+			created:    ago(time.Hour),
+			conditions: []corev1.PodCondition{condition(corev1.PodScheduled, ago(time.Minute))},
+			want:       ago(time.Hour),
+			wantOK:     true,
+		},
+		{
+			name:    "epoch start time is ignored",
+			created: ago(time.Hour),
+			started: ptrTime(time.Unix(0, 0)),
+			want:    ago(time.Hour),
+			wantOK:  true,
+		},
+		{
+			name:    "future start time is ignored",
+			created: ago(time.Hour),
+			started: ptrTime(now.Add(time.Hour)),
+			want:    ago(time.Hour),
+			wantOK:  true,
+		},
+		{
+			name:   "nothing sensible",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.Time{Time: tt.created}},
+				Status:     corev1.PodStatus{Conditions: tt.conditions},
+			}
+			if tt.started != nil {
+				pod.Status.StartTime = &metav1.Time{Time: *tt.started}
+			}
+			got, ok := bestAnchor(pod, now)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("bestAnchor() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestPodReconciler_TTLAnchorAuto(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		anchor        string
+		startTime     *metav1.Time
+		readyAt       time.Time
+		expectDeleted bool
+	}{
+		{
+			name:          "epoch start time is deleted by start anchor",
+			anchor:        TTLAnchorStart,
+			startTime:     &metav1.Time{Time: time.Unix(0, 0)},
+			readyAt:       time.Now().Add(-time.Minute),
+			expectDeleted: true,
+		},
+		{
+			name:      "epoch start time waits on recent ready transition with auto anchor",
+			anchor:    TTLAnchorAuto,
+			startTime: &metav1.Time{Time: time.Unix(0, 0)},
+			readyAt:   time.Now().Add(-time.Minute),
+		},
+		{
+			name:          "missing start time is deleted by start anchor",
+			anchor:        TTLAnchorStart,
+			readyAt:       time.Now().Add(-time.Minute),
+			expectDeleted: true,
+		},
+		{
+			name:    "missing start time waits on recent ready transition with auto anchor",
+			anchor:  TTLAnchorAuto,
+			readyAt: time.Now().Add(-time.Minute),
+		},
+		{
+			name:          "old ready transition is deleted with auto anchor",
+			anchor:        TTLAnchorAuto,
+			startTime:     &metav1.Time{Time: time.Unix(0, 0)},
+			readyAt:       time.Now().Add(-10 * time.Minute),
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: tt.startTime,
+					Conditions: []corev1.PodCondition{{
+						Type:               corev1.PodReady,
+						Status:             corev1.ConditionFalse,
+						LastTransitionTime: metav1.Time{Time: tt.readyAt},
+					}},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     metrics.NewPodMetrics(),
+				TTLToDelete: 300,
+				TTLAnchor:   tt.anchor,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("pod deleted = %v, want %v", deleted, tt.expectDeleted)
+			}
+			if !tt.expectDeleted && (result.RequeueAfter <= 0 || result.RequeueAfter > 300*time.Second) {
+				t.Errorf("Expected a requeue within the TTL, got %v", result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_AnchorTimeAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		startTime     time.Time
+		anchorTime    string
+		expectDeleted bool
+	}{
+		{
+			name:          "anchor makes a fresh-looking pod eligible",
+			startTime:     time.Now().Add(-time.Minute),
+			anchorTime:    time.Now().Add(-time.Hour).Format(time.RFC3339),
+			expectDeleted: true,
+		},
+		{
+			name:          "anchor makes an old-looking pod ineligible",
+			startTime:     time.Now().Add(-time.Hour),
+			anchorTime:    time.Now().Add(-time.Minute).Format(time.RFC3339),
+			expectDeleted: false,
+		},
+		{
+			name:          "invalid anchor is ignored",
+			startTime:     time.Now().Add(-time.Hour),
+			anchorTime:    "yesterday",
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   "default",
+					Annotations: map[string]string{anchorTimeAnnotation: tt.anchorTime},
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: tt.startTime},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				AllowedNamespaces: []string{"default"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted = %v, got %v", tt.expectDeleted, deleted)
+			}
+			if !tt.expectDeleted && (result.RequeueAfter <= 0 || result.RequeueAfter > 5*time.Minute) {
+				t.Errorf("Expected a requeue within the TTL measured from the anchor, got %v", result.RequeueAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// reapNowAnnotation set to "true" deletes an evicted pod without waiting
+	// for its TTL
+	reapNowAnnotation = "pod-reaper.kyos.com/reap-now"
+	// reapAfterAnnotation replaces the TTL of an evicted pod with a
+	// duration, such as "10m"
+	reapAfterAnnotation = "pod-reaper.kyos.com/reap-after"
+	// silentAnnotation set to "true" reaps a pod without notifying or
+	// logging its audit annotations, for noisy batch workloads
+	silentAnnotation = "pod-reaper.kyos.com/silent"
+	// noMetricsAnnotation set to "true" reaps a pod without recording it in
+	// the per-pod metrics, to keep the cardinality of noisy namespaces down
+	noMetricsAnnotation = "pod-reaper.kyos.com/no-metrics"
+)
+
+// annotationAction is what a pod's reaper annotations ask for
+type annotationAction int
+
+const (
+	// actionDefault applies the configured TTL
+	actionDefault annotationAction = iota
+	// actionReapNow deletes the pod without waiting for its TTL
+	actionReapNow
+	// actionPreserve never deletes the pod
+	actionPreserve
+	// actionReapAfter deletes the pod after the duration it asks for
+	actionReapAfter
+)
+
+// isSilent checks if a pod asks to be reaped without a notification or
+// audit entry
+func isSilent(pod *corev1.Pod) bool {
+	return pod.Annotations[silentAnnotation] == "true"
+}
+
+// hasNoMetrics checks if a pod asks to be left out of the metrics
+func hasNoMetrics(pod *corev1.Pod) bool {
+	return pod.Annotations[noMetricsAnnotation] == "true"
+}
+
+// resolveAnnotations decides what a pod's reaper annotations ask for when
+// they conflict. reap-now takes precedence over preserve, which takes
+// precedence over reap-after. A reap-after that isn't a valid duration is
+// ignored.
+func resolveAnnotations(pod *corev1.Pod) (annotationAction, time.Duration) {
+	if pod.Annotations[reapNowAnnotation] == "true" {
+		return actionReapNow, 0
+	}
+	if pod.Annotations[preserveAnnotation] == "true" {
+		return actionPreserve, 0
+	}
+	if value, ok := pod.Annotations[reapAfterAnnotation]; ok {
+		if after, err := time.ParseDuration(value); err == nil && after >= 0 {
+			return actionReapAfter, after
+		}
+	}
+	return actionDefault, 0
+}
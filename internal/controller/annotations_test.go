@@ -0,0 +1,232 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_AnnotationPrecedence(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// Pods are 10 minutes old with a 1 hour TTL
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantAction  annotationAction
+		wantResult  string
+	}{
+		{
+			name:       "no annotations",
+			wantAction: actionDefault,
+			wantResult: metrics.ReconcileRequeued,
+		},
+		{
+			name:        "reap-now",
+			annotations: map[string]string{reapNowAnnotation: "true"},
+			wantAction:  actionReapNow,
+			wantResult:  metrics.ReconcileDeleted,
+		},
+		{
+			name:        "preserve",
+			annotations: map[string]string{preserveAnnotation: "true"},
+			wantAction:  actionPreserve,
+			wantResult:  metrics.ReconcileSkipped,
+		},
+		{
+			name:        "reap-after past",
+			annotations: map[string]string{reapAfterAnnotation: "5m"},
+			wantAction:  actionReapAfter,
+			wantResult:  metrics.ReconcileDeleted,
+		},
+		{
+			name:        "reap-after not yet",
+			annotations: map[string]string{reapAfterAnnotation: "2h"},
+			wantAction:  actionReapAfter,
+			wantResult:  metrics.ReconcileRequeued,
+		},
+		{
+			name:        "invalid reap-after is ignored",
+			annotations: map[string]string{reapAfterAnnotation: "soon"},
+			wantAction:  actionDefault,
+			wantResult:  metrics.ReconcileRequeued,
+		},
+		{
+			name:        "reap-now over preserve",
+			annotations: map[string]string{reapNowAnnotation: "true", preserveAnnotation: "true"},
+			wantAction:  actionReapNow,
+			wantResult:  metrics.ReconcileDeleted,
+		},
+		{
+			name:        "reap-now over reap-after",
+			annotations: map[string]string{reapNowAnnotation: "true", reapAfterAnnotation: "2h"},
+			wantAction:  actionReapNow,
+			wantResult:  metrics.ReconcileDeleted,
+		},
+		{
+			name:        "preserve over reap-after",
+			annotations: map[string]string{preserveAnnotation: "true", reapAfterAnnotation: "5m"},
+			wantAction:  actionPreserve,
+			wantResult:  metrics.ReconcileSkipped,
+		},
+		{
+			name: "reap-now over preserve and reap-after",
+			annotations: map[string]string{
+				reapNowAnnotation:   "true",
+				preserveAnnotation:  "true",
+				reapAfterAnnotation: "2h",
+			},
+			wantAction: actionReapNow,
+			wantResult: metrics.ReconcileDeleted,
+		},
+		{
+			name:        "reap-now other than true is ignored",
+			annotations: map[string]string{reapNowAnnotation: "yes", preserveAnnotation: "true"},
+			wantAction:  actionPreserve,
+			wantResult:  metrics.ReconcileSkipped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			if action, _ := resolveAnnotations(pod); action != tt.wantAction {
+				t.Errorf("resolveAnnotations() = %v, want %v", action, tt.wantAction)
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				TTLToDelete:       3600,
+				AllowedNamespaces: []string{"default"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			deleted := err != nil
+			switch tt.wantResult {
+			case metrics.ReconcileDeleted:
+				if !deleted {
+					t.Error("Expected pod to be deleted")
+				}
+			case metrics.ReconcileRequeued:
+				if deleted || result.RequeueAfter == 0 {
+					t.Errorf("Expected pod to be requeued, deleted=%v requeueAfter=%v", deleted, result.RequeueAfter)
+				}
+			case metrics.ReconcileSkipped:
+				if deleted || result.RequeueAfter != 0 {
+					t.Errorf("Expected pod to be skipped, deleted=%v requeueAfter=%v", deleted, result.RequeueAfter)
+				}
+			}
+		})
+	}
+}
+
+func TestPodReconciler_NoMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		expectDeleted bool
+	}{
+		{
+			name:          "reaped without metrics",
+			annotations:   map[string]string{noMetricsAnnotation: "true"},
+			expectDeleted: true,
+		},
+		{
+			name:        "skipped without metrics",
+			annotations: map[string]string{noMetricsAnnotation: "true", preserveAnnotation: "true"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+
+			mfs, err := registry.Gather()
+			if err != nil {
+				t.Fatalf("Failed to gather metrics: %v", err)
+			}
+			for _, mf := range mfs {
+				for _, m := range mf.GetMetric() {
+					for _, label := range m.GetLabel() {
+						if label.GetName() == "namespace" {
+							t.Errorf("Expected no per-pod metrics, got %s%v", mf.GetName(), m.GetLabel())
+						}
+					}
+				}
+			}
+		})
+	}
+}
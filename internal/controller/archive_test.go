@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/archive"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mockUploader records uploads, failing them if err is set
+type mockUploader struct {
+	objects map[string][]byte
+	err     error
+}
+
+func (u *mockUploader) Upload(ctx context.Context, key string, body []byte) error {
+	if u.err != nil {
+		return u.err
+	}
+	u.objects[key] = body
+	return nil
+}
+
+func TestPodReconciler_Archive(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		uploadErr   error
+		wantDeleted bool
+		wantErr     bool
+	}{
+		{name: "archived then deleted", wantDeleted: true},
+		{name: "upload failure keeps the pod", uploadErr: errors.New("bucket unavailable"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "evicted-pod",
+					Namespace: "default",
+					UID:       "abc-123",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+			uploader := &mockUploader{objects: make(map[string][]byte), err: tt.uploadErr}
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     metrics.NewPodMetrics(),
+				TTLToDelete: 300,
+				Archiver:    archive.NewArchiver(uploader, "reaped"),
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			_, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			getErr := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := getErr != nil; deleted != tt.wantDeleted {
+				t.Errorf("pod deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			if tt.uploadErr != nil {
+				return
+			}
+
+			body, ok := uploader.objects["reaped/default/evicted-pod-abc-123.json"]
+			if !ok {
+				t.Fatalf("Expected the manifest to be uploaded, got keys %v", uploader.objects)
+			}
+			var archived corev1.Pod
+			if err := json.Unmarshal(body, &archived); err != nil {
+				t.Fatalf("Failed to decode archived manifest: %v", err)
+			}
+			if archived.Name != pod.Name || archived.Status.Reason != "Evicted" {
+				t.Errorf("Expected the pod's manifest, got %+v", archived.ObjectMeta)
+			}
+		})
+	}
+}
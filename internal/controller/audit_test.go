@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/notify"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// messageSender captures sent notifications
+type messageSender struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (s *messageSender) Send(ctx context.Context, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func TestPodReconciler_AuditAnnotations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"team":                   "payments",
+				"app.kubernetes.io/name": "checkout",
+				"unlisted":               "secret",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+	sender := &messageSender{}
+	notifier := notify.NewNotifier(sender, time.Hour)
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           metrics.NewPodMetrics(),
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+		Notifier:          notifier,
+		AuditAnnotations:  []string{"team", "app.kubernetes.io/name", "missing"},
+	}
+
+	var logs []string
+	logger := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{})
+	ctx := log.IntoContext(context.Background(), logger)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	notifier.Flush()
+
+	var deletion string
+	for _, line := range logs {
+		if strings.Contains(line, "successfully deleted evicted pod") {
+			deletion = line
+		}
+	}
+	for _, want := range []string{`"annotations"=`, `"app.kubernetes.io/name"="checkout"`, `"team"="payments"`} {
+		if !strings.Contains(deletion, want) {
+			t.Errorf("Expected deletion log to contain %s, got %q", want, deletion)
+		}
+	}
+
+	if len(sender.messages) != 1 {
+		t.Fatalf("Expected 1 notification, got %v", sender.messages)
+	}
+	if msg := sender.messages[0]; !strings.HasSuffix(msg, "[app.kubernetes.io/name=checkout, team=payments]") {
+		t.Errorf("Expected notification to carry the audit annotations, got %q", msg)
+	}
+	for _, record := range append(sender.messages, deletion) {
+		if strings.Contains(record, "secret") {
+			t.Errorf("Expected unlisted annotations to be left out, got %q", record)
+		}
+	}
+}
+
+func TestPodReconciler_SilentPod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			Annotations: map[string]string{
+				silentAnnotation: "true",
+				"team":           "payments",
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+	sender := &messageSender{}
+	notifier := notify.NewNotifier(sender, time.Hour)
+	r := &PodReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Metrics:          metrics.NewPodMetrics(),
+		TTLToDelete:      300,
+		Notifier:         notifier,
+		AuditAnnotations: []string{"team"},
+	}
+
+	var logs []string
+	logger := funcr.New(func(prefix, args string) {
+		logs = append(logs, args)
+	}, funcr.Options{})
+	ctx := log.IntoContext(context.Background(), logger)
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+	if _, err := r.Reconcile(ctx, req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	notifier.Flush()
+
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+		t.Error("Expected the silent pod to be deleted")
+	}
+	if len(sender.messages) != 0 {
+		t.Errorf("Expected no notification for a silent pod, got %v", sender.messages)
+	}
+	for _, line := range logs {
+		if strings.Contains(line, "successfully deleted evicted pod") || strings.Contains(line, "payments") {
+			t.Errorf("Expected no audit entry for a silent pod, got %q", line)
+		}
+	}
+}
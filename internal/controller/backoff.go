@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// backoffSweepInterval is how often entries for pods that no longer exist
+// are dropped from the failed deletion tracker
+const backoffSweepInterval = 10 * time.Minute
+
+// backoffEntry counts the consecutive failed deletions of a pod
+type backoffEntry struct {
+	uid      types.UID
+	attempts int
+}
+
+// recordDeleteFailure tracks a failed deletion and returns how many times
+// deleting the pod has failed in a row
+func (r *PodReconciler) recordDeleteFailure(key types.NamespacedName, uid types.UID) int {
+	entry := r.backoff.Update(key, func(entry backoffEntry, ok bool) backoffEntry {
+		if !ok || entry.uid != uid {
+			entry = backoffEntry{uid: uid}
+		}
+		entry.attempts++
+		return entry
+	})
+	r.Metrics.SetBackoffEntries(r.backoff.Len())
+	return entry.attempts
+}
+
+// clearDeleteFailures stops tracking a pod once it is deleted
+func (r *PodReconciler) clearDeleteFailures(key types.NamespacedName) {
+	r.backoff.Delete(key)
+	r.Metrics.SetBackoffEntries(r.backoff.Len())
+}
+
+// sweepBackoff drops entries for pods that no longer exist, or were
+// replaced by a pod of the same name, which would otherwise never be cleared
+func (r *PodReconciler) sweepBackoff(ctx context.Context) error {
+	for _, key := range r.backoff.Keys() {
+		pod := &corev1.Pod{}
+		err := r.Get(ctx, key, pod)
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		if entry, ok := r.backoff.Get(key); ok && (errors.IsNotFound(err) || pod.UID != entry.uid) {
+			r.backoff.Delete(key)
+		}
+	}
+	r.Metrics.SetBackoffEntries(r.backoff.Len())
+	return nil
+}
+
+// runBackoffSweep sweeps the failed deletion tracker every
+// backoffSweepInterval until the context is cancelled
+func (r *PodReconciler) runBackoffSweep(ctx context.Context) error {
+	ticker := time.NewTicker(backoffSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.sweepBackoff(ctx); err != nil {
+				log.FromContext(ctx).Error(err, "unable to sweep failed deletion tracker")
+			}
+		}
+	}
+}
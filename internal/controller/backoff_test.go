@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_BackoffTracking(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "uid-1",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	// Fail the first two deletions
+	failures := 2
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if failures > 0 {
+					failures--
+					return errors.New("etcd unavailable")
+				}
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetrics()
+	podMetrics.Register(registry)
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           podMetrics,
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if _, err := r.Reconcile(context.Background(), req); err == nil {
+			t.Fatal("Expected the deletion to fail")
+		}
+		entry, ok := r.backoff.Get(req.NamespacedName)
+		if !ok || entry.attempts != attempt {
+			t.Errorf("Expected %d tracked attempts, got %+v (tracked %v)", attempt, entry, ok)
+		}
+		if got := gatherGauge(t, registry, "reaper_backoff_entries"); got != 1 {
+			t.Errorf("reaper_backoff_entries = %v, want 1", got)
+		}
+	}
+
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if _, ok := r.backoff.Get(req.NamespacedName); ok {
+		t.Error("Expected the entry to be removed once the pod is deleted")
+	}
+	if got := gatherGauge(t, registry, "reaper_backoff_entries"); got != 0 {
+		t.Errorf("reaper_backoff_entries = %v, want 0", got)
+	}
+}
+
+func TestPodReconciler_sweepBackoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "present", Namespace: "default", UID: "uid-present"}},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "replaced", Namespace: "default", UID: "uid-new"}},
+		).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetrics()
+	podMetrics.Register(registry)
+	r := &PodReconciler{Client: fakeClient, Metrics: podMetrics}
+
+	present := types.NamespacedName{Namespace: "default", Name: "present"}
+	replaced := types.NamespacedName{Namespace: "default", Name: "replaced"}
+	gone := types.NamespacedName{Namespace: "default", Name: "gone"}
+	r.recordDeleteFailure(present, "uid-present")
+	r.recordDeleteFailure(replaced, "uid-old")
+	r.recordDeleteFailure(gone, "uid-gone")
+
+	if err := r.sweepBackoff(context.Background()); err != nil {
+		t.Fatalf("sweepBackoff() error = %v", err)
+	}
+
+	if _, ok := r.backoff.Get(present); !ok {
+		t.Error("Expected the entry for an existing pod to be kept")
+	}
+	if _, ok := r.backoff.Get(replaced); ok {
+		t.Error("Expected the entry for a replaced pod to be swept")
+	}
+	if _, ok := r.backoff.Get(gone); ok {
+		t.Error("Expected the entry for a missing pod to be swept")
+	}
+	if got := gatherGauge(t, registry, "reaper_backoff_entries"); got != 1 {
+		t.Errorf("reaper_backoff_entries = %v, want 1", got)
+	}
+}
+
+// gatherGauge returns the value of an unlabelled gauge, or 0 if it's missing
+func gatherGauge(t *testing.T, registry *prometheus.Registry, metricName string) float64 {
+	t.Helper()
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() == metricName && len(mf.GetMetric()) > 0 {
+			return mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,29 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// cacheSyncRequeueAfter is how long reconciles arriving before the cache
+// has synced are deferred
+const cacheSyncRequeueAfter = 2 * time.Second
+
+// cacheSyncer is the part of a manager's cache the sync gate waits on
+type cacheSyncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// cacheSyncGate returns a runnable that opens the gate deferring reconciles
+// once the cache has synced
+func (r *PodReconciler) cacheSyncGate(cache cacheSyncer) func(context.Context) error {
+	return func(ctx context.Context) error {
+		if cache.WaitForCacheSync(ctx) {
+			r.cacheSynced.Store(true)
+			log.FromContext(ctx).Info("cache synced, no longer deferring reconciles")
+		}
+		return nil
+	}
+}
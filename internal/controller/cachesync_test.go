@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type fakeCacheSyncer bool
+
+func (s fakeCacheSyncer) WaitForCacheSync(context.Context) bool {
+	return bool(s)
+}
+
+func TestPodReconciler_DeferUntilCacheSynced(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		gated         bool
+		synced        bool
+		expectDeleted bool
+	}{
+		{
+			name:  "reconcile before sync is deferred",
+			gated: true,
+		},
+		{
+			name:          "reconcile after sync goes ahead",
+			gated:         true,
+			synced:        true,
+			expectDeleted: true,
+		},
+		{
+			name:          "gate disabled",
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			var gets int
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						gets++
+						return c.Get(ctx, key, obj, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:                fakeClient,
+				Scheme:                scheme,
+				Metrics:               podMetrics,
+				TTLToDelete:           300,
+				DeferUntilCacheSynced: tt.gated,
+			}
+			if err := r.cacheSyncGate(fakeCacheSyncer(tt.synced))(context.Background()); err != nil {
+				t.Fatalf("cacheSyncGate() error = %v", err)
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if !tt.expectDeleted {
+				if result.RequeueAfter != cacheSyncRequeueAfter {
+					t.Errorf("Expected a requeue after %v, got %v", cacheSyncRequeueAfter, result.RequeueAfter)
+				}
+				if gets != 0 {
+					t.Errorf("Expected no reads before the cache synced, got %d", gets)
+				}
+			}
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			wantDeferred := 0.0
+			if !tt.expectDeleted {
+				wantDeferred = 1
+			}
+			if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferCacheSync); got != wantDeferred {
+				t.Errorf("reaper_deferred_total{reason=%q} = %v, want %v", metrics.DeferCacheSync, got, wantDeferred)
+			}
+		})
+	}
+}
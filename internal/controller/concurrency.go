@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// acquireDeleteSlot blocks until a delete may proceed under DeleteConcurrency
+// and returns a func releasing the slot. It is a no-op when unbounded.
+func (r *PodReconciler) acquireDeleteSlot(ctx context.Context) (func(), error) {
+	if r.deleteSlots == nil {
+		return func() {}, nil
+	}
+	select {
+	case r.deleteSlots <- struct{}{}:
+		return func() { <-r.deleteSlots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// deletePod deletes a pod while holding a delete slot, only if it still has
+// the same UID
+func (r *PodReconciler) deletePod(ctx context.Context, pod *corev1.Pod) error {
+	return r.withDeleteSlot(ctx, pod, func(ctx context.Context, pod *corev1.Pod) error {
+		var opts []client.DeleteOption
+		if uid := uidPrecondition(pod); uid != nil {
+			opts = append(opts, client.Preconditions(*uid))
+		}
+		err := r.Delete(ctx, pod, opts...)
+		r.recordAPIWrite(err)
+		return err
+	})
+}
+
+// removePod evicts or deletes a pod, depending on UseEvictionAPI, while
+// holding a delete slot
+func (r *PodReconciler) removePod(ctx context.Context, pod *corev1.Pod) error {
+	if !r.UseEvictionAPI {
+		return r.deletePod(ctx, pod)
+	}
+	return r.withDeleteSlot(ctx, pod, func(ctx context.Context, pod *corev1.Pod) error {
+		err := r.evictPod(ctx, pod)
+		// A PodDisruptionBudget refusing the eviction is no sign of load
+		if isEvictionBlocked(err) {
+			r.recordAPIWrite(nil)
+		} else {
+			r.recordAPIWrite(err)
+		}
+		return err
+	})
+}
+
+// withDeleteSlot makes the delete or eviction call remove while holding a
+// delete slot. Only the call itself is timed, not the wait for a slot, so
+// both paths feed the delete latency histogram and the LatencyGuard alike.
+func (r *PodReconciler) withDeleteSlot(ctx context.Context, pod *corev1.Pod, remove func(context.Context, *corev1.Pod) error) error {
+	release, err := r.acquireDeleteSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+	start := time.Now()
+	err = remove(ctx, pod)
+	elapsed := time.Since(start)
+	r.Metrics.ObserveDeleteAPIDuration(elapsed)
+	if r.LatencyGuard != nil {
+		r.LatencyGuard.Record(time.Now(), elapsed)
+	}
+	return err
+}
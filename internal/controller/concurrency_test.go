@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_DeleteConcurrency(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	const (
+		pods        = 20
+		concurrency = 3
+	)
+
+	var objs []runtime.Object
+	for i := 0; i < pods; i++ {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("pod-%d", i),
+				Namespace: "default",
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			},
+		})
+	}
+
+	// Deletes block until the gate is closed
+	gate := make(chan struct{})
+	var inFlight, maxInFlight atomic.Int32
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				n := inFlight.Add(1)
+				defer inFlight.Add(-1)
+				for {
+					m := maxInFlight.Load()
+					if n <= m || maxInFlight.CompareAndSwap(m, n) {
+						break
+					}
+				}
+				<-gate
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           metrics.NewPodMetrics(),
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+		DeleteConcurrency: concurrency,
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < pods; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("pod-%d", i), Namespace: "default"}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Errorf("Reconcile() error = %v", err)
+			}
+		}(i)
+	}
+
+	// Wait for the slots to fill, then give any excess deletes a chance to start
+	deadline := time.Now().Add(5 * time.Second)
+	for inFlight.Load() < concurrency && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if got := inFlight.Load(); got != concurrency {
+		t.Errorf("Expected %d deletes in flight while blocked, got %d", concurrency, got)
+	}
+
+	close(gate)
+	wg.Wait()
+
+	if got := maxInFlight.Load(); got > concurrency {
+		t.Errorf("Expected at most %d concurrent deletes, got %d", concurrency, got)
+	}
+
+	podList := &corev1.PodList{}
+	if err := fakeClient.List(context.Background(), podList); err != nil {
+		t.Fatalf("Failed to list pods: %v", err)
+	}
+	if len(podList.Items) != 0 {
+		t.Errorf("Expected all pods to be deleted, %d remain", len(podList.Items))
+	}
+}
+
+func TestPodReconciler_AcquireDeleteSlotCancelled(t *testing.T) {
+	r := &PodReconciler{DeleteConcurrency: 1}
+	r.init()
+
+	release, err := r.acquireDeleteSlot(context.Background())
+	if err != nil {
+		t.Fatalf("acquireDeleteSlot() error = %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := r.acquireDeleteSlot(ctx); err == nil {
+		t.Error("Expected an error acquiring a slot with a cancelled context")
+	}
+}
+
+func TestPodReconciler_DeleteAPIDuration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		useEvictionAPI bool
+	}{
+		{name: "delete"},
+		{name: "eviction", useEvictionAPI: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			const latency = 50 * time.Millisecond
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						time.Sleep(latency)
+						return c.Delete(ctx, obj, opts...)
+					},
+					SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+						time.Sleep(latency)
+						return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			guard := NewLatencyGuard(time.Millisecond)
+			r := &PodReconciler{
+				Client:         fakeClient,
+				Scheme:         scheme,
+				Metrics:        podMetrics,
+				TTLToDelete:    300,
+				UseEvictionAPI: tt.useEvictionAPI,
+				LatencyGuard:   guard,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if len(guard.samples) != 1 || guard.samples[0].duration < latency {
+				t.Errorf("Expected the call to be recorded by the latency guard, got %v", guard.samples)
+			}
+
+			mfs, err := registry.Gather()
+			if err != nil {
+				t.Fatalf("Failed to gather metrics: %v", err)
+			}
+			for _, mf := range mfs {
+				if mf.GetName() != "evicted_pod_delete_api_duration_seconds" {
+					continue
+				}
+				h := mf.GetMetric()[0].GetHistogram()
+				if h.GetSampleCount() != 1 {
+					t.Errorf("Expected 1 call observed, got %d", h.GetSampleCount())
+				}
+				if h.GetSampleSum() < latency.Seconds() {
+					t.Errorf("Expected the call to take at least %v, got %vs", latency, h.GetSampleSum())
+				}
+				return
+			}
+			t.Fatal("evicted_pod_delete_api_duration_seconds not found after deletion")
+		})
+	}
+}
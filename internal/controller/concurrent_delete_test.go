@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newConcurrentDeletePod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+	}
+}
+
+func TestPodReconciler_DeletedByAnotherReplica(t *testing.T) {
+	// Each case lets another replica delete the pod right before one of our
+	// writes, which then fails with NotFound
+	tests := []struct {
+		name  string
+		funcs interceptor.Funcs
+	}{
+		{
+			name: "before marking reaped",
+			funcs: interceptor.Funcs{
+				Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+					_ = c.Delete(ctx, obj)
+					return c.Patch(ctx, obj, patch, opts...)
+				},
+			},
+		},
+		{
+			name: "before deleting",
+			funcs: interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					_ = c.Delete(ctx, obj)
+					return c.Delete(ctx, obj, opts...)
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(newConcurrentDeletePod()).
+				WithInterceptorFuncs(tt.funcs).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				TTLToDelete:       300,
+				AllowedNamespaces: []string{"default"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Expected NotFound to be treated as success, got error: %v", err)
+			}
+			if result.RequeueAfter != 0 {
+				t.Errorf("Expected no requeue, got %v", result.RequeueAfter)
+			}
+			if got := gatherCounter(t, registry, "reaper_reconciles_total", "result", metrics.ReconcileNoop); got != 1 {
+				t.Errorf("Expected 1 noop reconcile, got %v", got)
+			}
+			if got := gatherCounter(t, registry, "reaper_reconciles_total", "result", metrics.ReconcileError); got != 0 {
+				t.Errorf("Expected no failed reconciles, got %v", got)
+			}
+			// The other replica counted the deletion
+			if got := gatherCounter(t, registry, "evicted_pods_deleted_total", "namespace", "default"); got != 0 {
+				t.Errorf("Expected the deletion not to be counted, got %v", got)
+			}
+			if r.backoff.Len() != 0 {
+				t.Errorf("Expected no failed deletions to be tracked, got %d", r.backoff.Len())
+			}
+		})
+	}
+}
+
+func TestPodReconciler_ConcurrentReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(newConcurrentDeletePod()).
+		Build()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+
+	const replicas = 4
+	registries := make([]*prometheus.Registry, replicas)
+	errs := make([]error, replicas)
+	var wg sync.WaitGroup
+	for i := 0; i < replicas; i++ {
+		podMetrics := metrics.NewPodMetrics()
+		registries[i] = prometheus.NewRegistry()
+		podMetrics.Register(registries[i])
+		r := &PodReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Metrics:           podMetrics,
+			TTLToDelete:       300,
+			AllowedNamespaces: []string{"default"},
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = r.Reconcile(context.Background(), req)
+		}(i)
+	}
+	wg.Wait()
+
+	deleted := 0.0
+	for i := 0; i < replicas; i++ {
+		if errs[i] != nil {
+			t.Errorf("replica %d: Reconcile() error = %v", i, errs[i])
+		}
+		deleted += gatherCounter(t, registries[i], "evicted_pods_deleted_total", "namespace", "default")
+	}
+	if deleted > 1 {
+		t.Errorf("Expected the deletion to be counted at most once, got %v", deleted)
+	}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); !errors.IsNotFound(err) {
+		t.Errorf("Expected pod to be deleted, got %v", err)
+	}
+}
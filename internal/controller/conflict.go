@@ -0,0 +1,53 @@
+package controller
+
+import (
+	"context"
+	stderrors "errors"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// errReplaced is returned when an object was deleted and another created
+// under its name while it was being patched
+var errReplaced = stderrors.New("object was replaced by another with the same name")
+
+// patchWithRetry applies mutate to obj and patches the change. The patch
+// carries obj's resourceVersion, so it conflicts with any write made since
+// obj was read; the latest version is then fetched from the API server,
+// as the cache is likely still at the conflicting one, and mutate applied to
+// it again. If the latest version has another UID, errReplaced is returned
+// and nothing is written. mutate returns false if there is nothing to
+// change. Every write to the API other than a deletion goes through here,
+// under the FieldManager if set.
+func (r *PodReconciler) patchWithRetry(ctx context.Context, obj client.Object, mutate func() bool) error {
+	uid := obj.GetUID()
+	attempt := 0
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if attempt > 0 {
+			if err := r.apiReader().Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+			if uid != "" && obj.GetUID() != uid {
+				return errReplaced
+			}
+		}
+		attempt++
+
+		patch := client.MergeFromWithOptions(obj.DeepCopyObject().(client.Object), client.MergeFromWithOptimisticLock{})
+		if !mutate() {
+			return nil
+		}
+		var opts []client.PatchOption
+		if r.FieldManager != "" {
+			opts = append(opts, client.FieldOwner(r.FieldManager))
+		}
+		err := r.Patch(ctx, obj, patch, opts...)
+		r.recordAPIWrite(err)
+		if errors.IsConflict(err) {
+			r.Metrics.IncUpdateConflict()
+		}
+		return err
+	})
+}
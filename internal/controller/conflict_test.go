@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_PatchConflicts(t *testing.T) {
+	tests := []struct {
+		name          string
+		conflicts     int
+		wantErr       bool
+		wantDeleted   bool
+		wantConflicts float64
+	}{
+		{
+			name:          "succeeds on retry",
+			conflicts:     2,
+			wantDeleted:   true,
+			wantConflicts: 2,
+		},
+		{
+			name:          "exhausts retries",
+			conflicts:     100,
+			wantErr:       true,
+			wantConflicts: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				},
+			}
+
+			patches := 0
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						patches++
+						if patches <= tt.conflicts {
+							return errors.NewConflict(schema.GroupResource{Resource: "pods"}, obj.GetName(), nil)
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				TTLToDelete:       300,
+				AllowedNamespaces: []string{"default"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+			_, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.IsConflict(err) {
+				t.Errorf("Expected a conflict error, got %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := errors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("Expected deleted = %v, got %v", tt.wantDeleted, deleted)
+			}
+
+			if got := gatherUpdateConflicts(t, registry); got != tt.wantConflicts {
+				t.Errorf("Expected %v update conflicts, got %v", tt.wantConflicts, got)
+			}
+		})
+	}
+}
+
+func TestPatchWithRetry_RefetchesOnConflict(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+	}
+
+	// Another writer stamps the pod between our read and our write
+	conflicted := false
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if !conflicted {
+					conflicted = true
+					current := &corev1.Pod{}
+					_ = c.Get(ctx, client.ObjectKeyFromObject(obj), current)
+					current.Annotations = map[string]string{firstSeenAnnotation: "2024-01-01T00:00:00Z"}
+					_ = c.Update(ctx, current)
+					return errors.NewConflict(schema.GroupResource{Resource: "pods"}, obj.GetName(), nil)
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	r := &PodReconciler{
+		Client:         fakeClient,
+		Metrics:        metrics.NewPodMetrics(),
+		StampFirstSeen: true,
+	}
+
+	stale := &corev1.Pod{}
+	_ = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(pod), stale)
+	if err := r.stampFirstSeen(context.Background(), stale, time.Now()); err != nil {
+		t.Fatalf("stampFirstSeen() error = %v", err)
+	}
+
+	current := &corev1.Pod{}
+	_ = fakeClient.Get(context.Background(), client.ObjectKeyFromObject(pod), current)
+	if got := current.Annotations[firstSeenAnnotation]; got != "2024-01-01T00:00:00Z" {
+		t.Errorf("Expected the concurrent stamp to be kept, got %q", got)
+	}
+}
+
+func TestPatchWithRetry_StaleRead(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: "pod-uid"},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+	r := &PodReconciler{Client: fakeClient, Metrics: podMetrics}
+
+	ctx := context.Background()
+	stale := &corev1.Pod{}
+	_ = fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), stale)
+
+	// Another writer changes the pod after our read
+	current := &corev1.Pod{}
+	_ = fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), current)
+	current.Labels = map[string]string{"team": "a"}
+	if err := fakeClient.Update(ctx, current); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	if err := r.markReaped(ctx, stale); err != nil {
+		t.Fatalf("markReaped() error = %v", err)
+	}
+	if got := gatherUpdateConflicts(t, registry); got != 1 {
+		t.Errorf("Expected the stale patch to conflict once, got %v", got)
+	}
+
+	_ = fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), current)
+	if !isReaped(current) || current.Labels["team"] != "a" {
+		t.Errorf("Expected both writes to be kept, got annotations %v labels %v", current.Annotations, current.Labels)
+	}
+
+	// A pod replaced under the same name is never patched
+	_ = fakeClient.Delete(ctx, current)
+	replacement := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: "new-uid"}}
+	if err := fakeClient.Create(ctx, replacement); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := r.markReaped(ctx, stale); err != errReplaced {
+		t.Fatalf("markReaped() error = %v, want errReplaced", err)
+	}
+	_ = fakeClient.Get(ctx, client.ObjectKeyFromObject(pod), current)
+	if isReaped(current) {
+		t.Error("Expected the replacement pod not to be marked as reaped")
+	}
+}
+
+func TestPatchWithRetry_StaleCache(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: "pod-uid"},
+	}
+	apiServer := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+	ctx := context.Background()
+	stale := &corev1.Pod{}
+	_ = apiServer.Get(ctx, client.ObjectKeyFromObject(pod), stale)
+
+	// Another writer changes the pod, the cache never catches up
+	current := stale.DeepCopy()
+	current.Labels = map[string]string{"team": "a"}
+	if err := apiServer.Update(ctx, current); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	cache := interceptor.NewClient(apiServer, interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			stale.DeepCopyInto(obj.(*corev1.Pod))
+			return nil
+		},
+	})
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+	r := &PodReconciler{Client: cache, APIReader: apiServer, Metrics: podMetrics}
+
+	if err := r.markReaped(ctx, stale.DeepCopy()); err != nil {
+		t.Fatalf("markReaped() error = %v", err)
+	}
+	if got := gatherUpdateConflicts(t, registry); got != 1 {
+		t.Errorf("Expected a single conflict before reading past the cache, got %v", got)
+	}
+	_ = apiServer.Get(ctx, client.ObjectKeyFromObject(pod), current)
+	if !isReaped(current) || current.Labels["team"] != "a" {
+		t.Errorf("Expected both writes to be kept, got annotations %v labels %v", current.Annotations, current.Labels)
+	}
+}
+
+// gatherUpdateConflicts returns the update conflicts counter from a registry
+func gatherUpdateConflicts(t *testing.T, registry *prometheus.Registry) float64 {
+	t.Helper()
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() == "reaper_update_conflicts_total" && len(mf.GetMetric()) > 0 {
+			return mf.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	return 0
+}
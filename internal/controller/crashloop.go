@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const crashLoopBackOffReason = "CrashLoopBackOff"
+
+// isCrashLooping checks if a Running pod has a container waiting in
+// CrashLoopBackOff
+func isCrashLooping(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning {
+		return false
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason == crashLoopBackOffReason {
+			return true
+		}
+	}
+	return false
+}
+
+// isCrashLoopingPodPredicate returns true if the object is a crashlooping pod
+func isCrashLoopingPodPredicate(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	return isCrashLooping(pod)
+}
+
+// crashLoopingSince returns when a crashlooping pod's containers stopped
+// being ready, falling back to its start time. It reports false when
+// neither is known.
+func crashLoopingSince(pod *corev1.Pod) (time.Time, bool) {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.ContainersReady && cond.Status == corev1.ConditionFalse && !cond.LastTransitionTime.IsZero() {
+			return cond.LastTransitionTime.Time, true
+		}
+	}
+	if pod.Status.StartTime != nil && !pod.Status.StartTime.IsZero() {
+		return pod.Status.StartTime.Time, true
+	}
+	return time.Time{}, false
+}
+
+// isOwnerGone checks if a pod has no owner, or its owner no longer exists.
+// Owners that can't be fetched for other reasons are assumed to exist.
+func (r *PodReconciler) isOwnerGone(ctx context.Context, pod *corev1.Pod) bool {
+	ref := ownerRef(pod.OwnerReferences)
+	if ref == nil {
+		return true
+	}
+
+	owner := &unstructured.Unstructured{}
+	owner.SetAPIVersion(ref.APIVersion)
+	owner.SetKind(ref.Kind)
+	key := client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}
+	if err := r.Get(ctx, key, owner); err != nil {
+		if errors.IsNotFound(err) {
+			return true
+		}
+		log.FromContext(ctx).V(1).Info("unable to fetch owner, assuming it exists",
+			"kind", ref.Kind, "name", ref.Name, "error", err.Error())
+		return false
+	}
+	// An owner recreated under the same name is a different object
+	return owner.GetUID() != ref.UID
+}
+
+// reconcileCrashLoop reaps a Running pod that has been crashlooping for
+// longer than CrashLoopDuration and whose owner is gone. It returns the
+// reconcile result to report.
+func (r *PodReconciler) reconcileCrashLoop(ctx context.Context, pod *corev1.Pod) (ctrl.Result, string, error) {
+	logger := log.FromContext(ctx)
+	key := client.ObjectKeyFromObject(pod)
+
+	if c := firstFailed(r.crashLoopChecks(pod)); c != nil {
+		res, result := r.handleFailedCheck(logger, pod, c)
+		return res, result, nil
+	}
+
+	if !r.isOwnerGone(ctx, pod) {
+		logger.V(1).Info("crashlooping pod still has an owner, skipping", "pod", key)
+		return ctrl.Result{}, metrics.ReconcileNoop, nil
+	}
+
+	if res, result, paused, err := r.pauseForMaintenance(ctx, pod); paused {
+		return res, result, err
+	}
+
+	if r.dryRun(ctx, pod) {
+		return ctrl.Result{}, r.skip(pod, metrics.SkipDryRun), nil
+	}
+
+	if wait, dryRun := r.firstPassDryRun(ctx, pod, time.Now()); dryRun {
+		return ctrl.Result{RequeueAfter: wait}, metrics.ReconcileRequeued, nil
+	}
+
+	if r.Mode == ModeQuarantine {
+		return r.quarantine(ctx, pod)
+	}
+
+	logger.Info("deleting ownerless crashlooping pod", "pod", key)
+	if err := r.deletePod(ctx, pod); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, metrics.ReconcileNoop, nil
+		}
+		logger.Error(err, "unable to delete pod", "pod", key)
+		return ctrl.Result{}, metrics.ReconcileError, fmt.Errorf("deleting crashlooping pod %s: %w", key, err)
+	}
+
+	logger.Info("successfully deleted crashlooping pod", "pod", key)
+	return ctrl.Result{}, metrics.ReconcileDeleted, nil
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// runningPod returns a Running pod whose containers stopped being ready
+// notReadyFor ago, waiting with the given reason
+func runningPod(waitingReason string, notReadyFor time.Duration, owners ...metav1.OwnerReference) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pod",
+			Namespace:       "default",
+			OwnerReferences: owners,
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodRunning,
+			StartTime: &metav1.Time{Time: time.Now().Add(-24 * time.Hour)},
+			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.ContainersReady,
+					Status:             corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{Time: time.Now().Add(-notReadyFor)},
+				},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "app", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+			},
+		},
+	}
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses[0].State = corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: waitingReason},
+		}
+	}
+	return pod
+}
+
+func TestPodReconciler_ReapCrashLoop(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "rs-uid"},
+	}
+
+	tests := []struct {
+		name          string
+		pod           *corev1.Pod
+		reapCrashLoop bool
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "crashlooping ownerless pod is deleted",
+			pod:           runningPod(crashLoopBackOffReason, 2*time.Hour),
+			reapCrashLoop: true,
+			expectDeleted: true,
+		},
+		{
+			name:          "healthy running pod is ignored",
+			pod:           runningPod("", 2*time.Hour),
+			reapCrashLoop: true,
+		},
+		{
+			name:          "crashlooping ownerless pod is ignored when disabled",
+			pod:           runningPod(crashLoopBackOffReason, 2*time.Hour),
+			reapCrashLoop: false,
+		},
+		{
+			name:          "recently crashlooping ownerless pod is requeued",
+			pod:           runningPod(crashLoopBackOffReason, time.Minute),
+			reapCrashLoop: true,
+			expectRequeue: true,
+		},
+		{
+			name: "crashlooping pod with no known start is requeued",
+			pod: func() *corev1.Pod {
+				pod := runningPod(crashLoopBackOffReason, 2*time.Hour)
+				pod.Status.Conditions, pod.Status.StartTime = nil, nil
+				return pod
+			}(),
+			reapCrashLoop: true,
+			expectRequeue: true,
+		},
+		{
+			name:          "crashlooping pod with an existing owner is kept",
+			pod:           runningPod(crashLoopBackOffReason, 2*time.Hour, controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")),
+			reapCrashLoop: true,
+		},
+		{
+			name:          "crashlooping pod whose owner was deleted is deleted",
+			pod:           runningPod(crashLoopBackOffReason, 2*time.Hour, controllerRef("apps/v1", "ReplicaSet", "gone", "gone-uid")),
+			reapCrashLoop: true,
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(tt.pod, replicaSet).
+				Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				ReapCrashLoop:     tt.reapCrashLoop,
+				CrashLoopDuration: time.Hour,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tt.pod.Name,
+					Namespace: tt.pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// debounce coalesces reconciles of the same pod within ReconcileDebounce of
+// the last one that went ahead, returning how long until the window ends
+func (r *PodReconciler) debounce(uid types.UID, now time.Time) (time.Duration, bool) {
+	if r.ReconcileDebounce <= 0 {
+		return 0, false
+	}
+	var wait time.Duration
+	r.lastReconciled.Update(uid, func(last time.Time, ok bool) time.Time {
+		if ok {
+			if w := r.ReconcileDebounce - now.Sub(last); w > 0 {
+				wait = w
+				return last
+			}
+		}
+		return now
+	})
+	return wait, wait > 0
+}
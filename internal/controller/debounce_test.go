@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_ReconcileDebounce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		debounce      time.Duration
+		expectDeletes int
+	}{
+		{
+			name:          "rapid reconciles are coalesced",
+			debounce:      time.Minute,
+			expectDeletes: 1,
+		},
+		{
+			name:          "no debounce acts on every reconcile",
+			expectDeletes: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+					UID:       "uid-1",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			// The pod lingers after deletion, as if held by a finalizer
+			var deletes int
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						deletes++
+						return nil
+					},
+				}).
+				Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				AllowedNamespaces: []string{"default"},
+				ReconcileDebounce: tt.debounce,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+			for i := 0; i < 5; i++ {
+				result, err := r.Reconcile(context.Background(), req)
+				if err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+				if i > 0 && tt.debounce > 0 {
+					if result.RequeueAfter <= 0 || result.RequeueAfter > tt.debounce {
+						t.Errorf("Expected a requeue within the debounce window, got %v", result.RequeueAfter)
+					}
+				}
+			}
+
+			if deletes != tt.expectDeletes {
+				t.Errorf("Expected %d deletes, got %d", tt.expectDeletes, deletes)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_DebounceWindowEnds(t *testing.T) {
+	r := &PodReconciler{ReconcileDebounce: time.Minute}
+	now := time.Now()
+
+	if _, ok := r.debounce("uid-1", now); ok {
+		t.Fatal("Expected the first reconcile to go ahead")
+	}
+	if wait, ok := r.debounce("uid-1", now.Add(20*time.Second)); !ok || wait != 40*time.Second {
+		t.Errorf("debounce() = %v, %v, want 40s, true", wait, ok)
+	}
+	if _, ok := r.debounce("uid-2", now.Add(20*time.Second)); ok {
+		t.Error("Expected another pod not to be debounced")
+	}
+	if _, ok := r.debounce("uid-1", now.Add(time.Minute)); ok {
+		t.Error("Expected a reconcile at the window end to go ahead")
+	}
+}
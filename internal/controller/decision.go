@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// checkTTL names the TTL check, which Reconcile follows up on when it fails
+const checkTTL = "ttl exceeded"
+
+// podKind is why a pod is a candidate for reaping
+type podKind int
+
+const (
+	kindNone podKind = iota
+	kindEvicted
+	kindUnschedulable
+	kindCrashLoop
+)
+
+// classify returns why a pod is a candidate for reaping, if it is one
+func (r *PodReconciler) classify(pod *corev1.Pod) podKind {
+	switch {
+	case r.isPodEvicted(pod):
+		return kindEvicted
+	case r.ReapUnschedulable && unschedulableCondition(pod) != nil:
+		return kindUnschedulable
+	case r.ReapCrashLoop && isCrashLooping(pod):
+		return kindCrashLoop
+	}
+	return kindNone
+}
+
+// podCheck is one of the checks deciding whether a pod is deleted that needs
+// nothing but the pod and the reconciler's settings. Reconcile acts on the
+// first one that fails, Explain prints them all.
+type podCheck struct {
+	name   string
+	pass   bool
+	detail string
+	// outcome describes what a failing check leads to
+	outcome string
+	// skip is the reason a failing check is counted under, or empty if it
+	// requeues the pod after requeueAfter instead
+	skip         string
+	requeueAfter time.Duration
+	// deferReason, if set, counts a requeue as deferred by a gate rather
+	// than waiting out a TTL
+	deferReason string
+}
+
+// firstFailed returns the first of the checks that failed, or nil
+func firstFailed(checks []podCheck) *podCheck {
+	for i := range checks {
+		if !checks[i].pass {
+			return &checks[i]
+		}
+	}
+	return nil
+}
+
+// handleFailedCheck logs and counts a pod stopped by a failed check,
+// returning the reconcile result to report
+func (r *PodReconciler) handleFailedCheck(logger logr.Logger, pod *corev1.Pod, c *podCheck) (ctrl.Result, string) {
+	key := client.ObjectKeyFromObject(pod)
+	switch {
+	case c.skip == metrics.SkipPreserved:
+		logPreserved(logger, pod, key)
+	case c.skip != "":
+		logger.Info("skipping deletion", "pod", key, "check", c.name, "detail", c.detail)
+	default:
+		logger.Info("pod not ready for deletion, requeuing", "pod", key, "check", c.name, "detail", c.detail,
+			"requeueAfter", c.requeueAfter)
+		if c.deferReason != "" {
+			r.Metrics.IncDeferred(c.deferReason)
+		}
+		return ctrl.Result{RequeueAfter: c.requeueAfter}, metrics.ReconcileRequeued
+	}
+	return ctrl.Result{}, r.skip(pod, c.skip)
+}
+
+// evictedChecks returns the checks an evicted pod goes through, in the order
+// Reconcile applies them ahead of the ones that need the API server. With
+// reapNow, as for pods reaped through the API, the TTL is ignored.
+func (r *PodReconciler) evictedChecks(pod *corev1.Pod, reapNow bool) []podCheck {
+	checks := []podCheck{
+		r.allowedCheck(pod),
+		{
+			name:    "priority",
+			pass:    r.matchesPriorityFilter(pod),
+			detail:  fmt.Sprintf("class %q", pod.Spec.PriorityClassName),
+			outcome: "skip, pod does not match the priority filter",
+			skip:    metrics.SkipPriority,
+		},
+	}
+	if len(r.ContainerTerminationReasons) > 0 {
+		checks = append(checks, podCheck{
+			name:    "termination",
+			pass:    r.matchesTerminationReason(pod),
+			detail:  fmt.Sprintf("reasons %q", terminationReasons(pod)),
+			outcome: "skip, no container terminated with a matching reason",
+			skip:    metrics.SkipTerminationReason,
+		})
+	}
+	if len(pod.Spec.Containers) == 0 {
+		checks = append(checks, podCheck{
+			name:    "containers",
+			pass:    !r.SkipEmptySpec,
+			detail:  "pod has no containers",
+			outcome: "skip, pod has no containers",
+			skip:    metrics.SkipEmptySpec,
+		})
+	}
+
+	action, _ := resolveAnnotations(pod)
+	detail := fmt.Sprintf("%s=%q", preserveAnnotation, pod.Annotations[preserveAnnotation])
+	if action == actionReapNow && r.shouldPreservePod(pod) {
+		detail += ", overridden by " + reapNowAnnotation
+	}
+	checks = append(checks, podCheck{
+		name:    "not preserved",
+		pass:    action != actionPreserve,
+		detail:  detail,
+		outcome: "skip, pod is preserved",
+		skip:    metrics.SkipPreserved,
+	})
+
+	policy := r.StandalonePolicy
+	if policy == "" {
+		policy = StandalonePolicyTTL
+	}
+	standalone := len(pod.OwnerReferences) == 0
+	if standalone {
+		checks = append(checks, podCheck{
+			name:    "standalone",
+			pass:    policy != StandalonePolicyPreserve,
+			detail:  fmt.Sprintf("no owner, policy %q", policy),
+			outcome: "skip, standalone policy is preserve",
+			skip:    metrics.SkipStandalone,
+		})
+	}
+
+	switch {
+	case action == actionReapNow:
+		checks = append(checks, podCheck{name: checkTTL, pass: true, detail: "ignored by " + reapNowAnnotation})
+	case reapNow && action != actionPreserve:
+		checks = append(checks, podCheck{name: checkTTL, pass: true, detail: "ignored when reaping through the API"})
+	case standalone && policy == StandalonePolicyReap:
+		checks = append(checks, podCheck{name: checkTTL, pass: true, detail: "ignored by standalone policy reap"})
+	default:
+		checks = append(checks, r.ttlCheck(pod))
+	}
+
+	if r.WaitForLogsShipped {
+		checks = append(checks, r.logsShippedCheck(pod))
+	}
+	if r.RequireAllTerminated {
+		checks = append(checks, terminatedCheck(pod))
+	}
+	return checks
+}
+
+// ttlCheck checks if an evicted pod has exceeded its TTL. Pods not stamped
+// first seen yet are waiting for the stamp, and a full TTL after it.
+func (r *PodReconciler) ttlCheck(pod *corev1.Pod) podCheck {
+	ttl := r.ttlFor(pod)
+	if _, stamped := r.firstSeen(pod); r.StampFirstSeen && !stamped {
+		return podCheck{
+			name:         checkTTL,
+			detail:       fmt.Sprintf("not stamped first seen yet, ttl %ds", ttl),
+			outcome:      fmt.Sprintf("stamp first seen and requeue in %ds", ttl),
+			requeueAfter: time.Duration(ttl) * time.Second,
+		}
+	}
+	detail := fmt.Sprintf("no start time, ttl %ds", ttl)
+	if _, ok := r.ageStart(pod); ok {
+		detail = fmt.Sprintf("age %s, ttl %ds", r.podAge(pod).Round(time.Second), ttl)
+	}
+	requeueAfter := r.calculateRequeueTime(pod)
+	return podCheck{
+		name:         checkTTL,
+		pass:         r.hasExceededTTL(pod),
+		detail:       detail,
+		outcome:      fmt.Sprintf("requeue in %s", requeueAfter.Round(time.Second)),
+		requeueAfter: requeueAfter,
+	}
+}
+
+// unschedulableChecks returns the checks an unschedulable pod goes through,
+// in the order reconcileUnschedulable applies them ahead of its owner lookup
+func (r *PodReconciler) unschedulableChecks(pod *corev1.Pod, cond *corev1.PodCondition) []podCheck {
+	requeueAfter := r.unschedulableRequeueTime(cond)
+	since := "no transition time"
+	if !cond.LastTransitionTime.IsZero() {
+		since = "unschedulable since " + cond.LastTransitionTime.UTC().Format(time.RFC3339)
+	}
+	return []podCheck{
+		r.allowedCheck(pod),
+		r.preservedCheck(pod),
+		{
+			name:         checkTTL,
+			pass:         requeueAfter == 0,
+			detail:       fmt.Sprintf("%s, ttl %ds", since, r.UnschedulableTTL),
+			outcome:      fmt.Sprintf("requeue in %s", requeueAfter.Round(time.Second)),
+			requeueAfter: requeueAfter,
+		},
+	}
+}
+
+// crashLoopChecks returns the checks a crashlooping pod goes through, in the
+// order reconcileCrashLoop applies them ahead of its owner lookup
+func (r *PodReconciler) crashLoopChecks(pod *corev1.Pod) []podCheck {
+	// Without a start the full duration is waited out from now
+	requeueAfter, detail := r.CrashLoopDuration, fmt.Sprintf("start unknown, duration %s", r.CrashLoopDuration)
+	if since, ok := crashLoopingSince(pod); ok {
+		requeueAfter = max(r.CrashLoopDuration-time.Since(since), 0)
+		detail = fmt.Sprintf("since %s, duration %s", since.UTC().Format(time.RFC3339), r.CrashLoopDuration)
+	}
+	return []podCheck{
+		r.allowedCheck(pod),
+		r.preservedCheck(pod),
+		{
+			name:         "duration",
+			pass:         requeueAfter == 0,
+			detail:       detail,
+			outcome:      fmt.Sprintf("requeue in %s", requeueAfter.Round(time.Second)),
+			requeueAfter: requeueAfter,
+		},
+	}
+}
+
+// allowedCheck checks the safe-mode allow-list
+func (r *PodReconciler) allowedCheck(pod *corev1.Pod) podCheck {
+	return podCheck{
+		name:    "allowed",
+		pass:    r.isNamespaceAllowed(pod.Namespace),
+		detail:  fmt.Sprintf("safe mode %t", r.SafeMode),
+		outcome: "skip, namespace is not in the safe-mode allow-list",
+		skip:    metrics.SkipSafeMode,
+	}
+}
+
+// preservedCheck checks the preserve annotation alone, for pods that don't
+// honour reap-now
+func (r *PodReconciler) preservedCheck(pod *corev1.Pod) podCheck {
+	return podCheck{
+		name:    "not preserved",
+		pass:    !r.shouldPreservePod(pod),
+		detail:  fmt.Sprintf("%s=%q", preserveAnnotation, pod.Annotations[preserveAnnotation]),
+		outcome: "skip, pod is preserved",
+		skip:    metrics.SkipPreserved,
+	}
+}
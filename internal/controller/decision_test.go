@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TestPodReconciler_DecideMatchesReconcile checks that the verdict Explain
+// gives for a pod is what Reconcile does with it
+func TestPodReconciler_DecideMatchesReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	evicted := func(age time.Duration, mutate func(*corev1.Pod)) *corev1.Pod {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "test-pod",
+				Namespace:       "default",
+				OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")},
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-age)},
+			},
+		}
+		if mutate != nil {
+			mutate(pod)
+		}
+		return pod
+	}
+
+	tests := []struct {
+		name      string
+		pod       *corev1.Pod
+		configure func(*PodReconciler)
+		verdict   string
+		result    string
+	}{
+		{name: "deleted", pod: evicted(time.Hour, nil), verdict: "would delete", result: metrics.ReconcileDeleted},
+		{name: "within TTL", pod: evicted(time.Minute, nil), verdict: "requeue in", result: metrics.ReconcileRequeued},
+		{
+			name:    "preserved",
+			pod:     evicted(time.Hour, func(p *corev1.Pod) { p.Annotations = map[string]string{preserveAnnotation: "true"} }),
+			verdict: "skip, pod is preserved",
+			result:  metrics.ReconcileSkipped,
+		},
+		{
+			name:      "outside the safe-mode allow-list",
+			pod:       evicted(time.Hour, nil),
+			configure: func(r *PodReconciler) { r.SafeMode, r.AllowedNamespaces = true, []string{"team-a"} },
+			verdict:   "skip, namespace is not in the safe-mode allow-list",
+			result:    metrics.ReconcileSkipped,
+		},
+		{
+			name:      "standalone preserved",
+			pod:       evicted(time.Hour, func(p *corev1.Pod) { p.OwnerReferences = nil }),
+			configure: func(r *PodReconciler) { r.StandalonePolicy = StandalonePolicyPreserve },
+			verdict:   "skip, standalone policy is preserve",
+			result:    metrics.ReconcileSkipped,
+		},
+		{
+			name: "containers still running",
+			pod: evicted(time.Hour, func(p *corev1.Pod) {
+				p.Status.ContainerStatuses = []corev1.ContainerStatus{
+					{Name: "app", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+				}
+			}),
+			configure: func(r *PodReconciler) { r.RequireAllTerminated = true },
+			verdict:   "requeue in",
+			result:    metrics.ReconcileRequeued,
+		},
+		{
+			name:      "unschedulable within TTL",
+			pod:       unschedulablePod(time.Minute),
+			configure: func(r *PodReconciler) { r.ReapUnschedulable, r.UnschedulableTTL = true, 3600 },
+			verdict:   "requeue in",
+			result:    metrics.ReconcileRequeued,
+		},
+		{
+			name:      "unschedulable past TTL",
+			pod:       unschedulablePod(2 * time.Hour),
+			configure: func(r *PodReconciler) { r.ReapUnschedulable, r.UnschedulableTTL = true, 3600 },
+			verdict:   "would delete",
+			result:    metrics.ReconcileDeleted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(tt.pod).Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+			if tt.configure != nil {
+				tt.configure(r)
+			}
+
+			if got := r.Decide(tt.pod); !strings.HasPrefix(got, tt.verdict) {
+				t.Errorf("Decide() = %q, want prefix %q", got, tt.verdict)
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: tt.pod.Name, Namespace: tt.pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if got := gatherCounter(t, registry, "reaper_reconciles_total", "result", tt.result); got != 1 {
+				t.Errorf("reaper_reconciles_total{result=%q} = %v, want 1", tt.result, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"context"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// dryRun logs a pod in one of the DryRunNamespaces that would be deleted
+// instead of deleting it, reporting whether it did. Like the first pass,
+// pods reaped through the API are deleted regardless.
+func (r *PodReconciler) dryRun(ctx context.Context, pod *corev1.Pod) bool {
+	if !r.isDryRunNamespace(pod.Namespace) || apiReapFrom(ctx) != nil {
+		return false
+	}
+	log.FromContext(ctx).Info("dry run namespace, would delete pod", "pod", client.ObjectKeyFromObject(pod))
+	return true
+}
+
+// isDryRunNamespace checks if a namespace is listed in DryRunNamespaces
+func (r *PodReconciler) isDryRunNamespace(namespace string) bool {
+	return slices.Contains(r.DryRunNamespaces, namespace)
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_DryRunNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name             string
+		firstPassDryRun  bool
+		expectDeleted    map[string]bool
+		expectFirstPass  map[string]int
+		expectSkippedDry float64
+	}{
+		{
+			name:             "dry-run namespace keeps its pods",
+			expectDeleted:    map[string]bool{"production": false, "staging": true},
+			expectSkippedDry: 1,
+		},
+		{
+			name:             "first pass only counts the other namespaces",
+			firstPassDryRun:  true,
+			expectDeleted:    map[string]bool{"production": false, "staging": false},
+			expectFirstPass:  map[string]int{"staging": 1},
+			expectSkippedDry: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []runtime.Object
+			for namespace := range tt.expectDeleted {
+				objs = append(objs, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: namespace},
+					Status: corev1.PodStatus{
+						Phase:     corev1.PodFailed,
+						Reason:    "Evicted",
+						StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+					},
+				})
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Metrics:          podMetrics,
+				TTLToDelete:      300,
+				DryRunNamespaces: []string{"production"},
+				FirstPassDryRun:  tt.firstPassDryRun,
+			}
+
+			for namespace, expectDeleted := range tt.expectDeleted {
+				req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: namespace}}
+				if _, err := r.Reconcile(context.Background(), req); err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+				err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+				if deleted := err != nil; deleted != expectDeleted {
+					t.Errorf("Expected pod in %s deleted=%v, got %v", namespace, expectDeleted, deleted)
+				}
+			}
+
+			if got := gatherCounter(t, registry, "evicted_pods_skipped_total", "reason", metrics.SkipDryRun); got != tt.expectSkippedDry {
+				t.Errorf("evicted_pods_skipped_total{reason=dry_run} = %v, want %v", got, tt.expectSkippedDry)
+			}
+			if len(r.firstPass.wouldDelete) != len(tt.expectFirstPass) {
+				t.Fatalf("First pass would delete %v, want %v", r.firstPass.wouldDelete, tt.expectFirstPass)
+			}
+			for namespace, count := range tt.expectFirstPass {
+				if r.firstPass.wouldDelete[namespace] != count {
+					t.Errorf("First pass would delete %v, want %v", r.firstPass.wouldDelete, tt.expectFirstPass)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:rbac:groups="",resources=pods/eviction,verbs=create
+
+// evictionBlockedRequeueAfter is how long to wait before retrying a pod whose
+// eviction was blocked by a PodDisruptionBudget
+const evictionBlockedRequeueAfter = time.Minute
+
+// evictPod removes a pod through the eviction API, so PodDisruptionBudgets
+// are honoured
+func (r *PodReconciler) evictPod(ctx context.Context, pod *corev1.Pod) error {
+	eviction := &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if uid := uidPrecondition(pod); uid != nil {
+		eviction.DeleteOptions = &metav1.DeleteOptions{Preconditions: uid}
+	}
+	return r.SubResource("eviction").Create(ctx, pod, eviction)
+}
+
+// isEvictionBlocked checks if an eviction was refused because it would
+// violate a PodDisruptionBudget
+func isEvictionBlocked(err error) bool {
+	return errors.IsTooManyRequests(err)
+}
@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_UseEvictionAPI(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		blockedByPDB  bool
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "pod is evicted",
+			expectDeleted: true,
+			expectRequeue: false,
+		},
+		{
+			name:          "eviction blocked by PDB requeues",
+			blockedByPDB:  true,
+			expectDeleted: false,
+			expectRequeue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			var evictions int
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						t.Error("Delete called while using the eviction API")
+						return c.Delete(ctx, obj, opts...)
+					},
+					SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+						evictions++
+						if tt.blockedByPDB {
+							return apierrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 10)
+						}
+						return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:         fakeClient,
+				Scheme:         scheme,
+				Metrics:        podMetrics,
+				TTLToDelete:    300,
+				UseEvictionAPI: true,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if evictions != 1 {
+				t.Errorf("Expected 1 eviction, got %d", evictions)
+			}
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+			blocked := gatherCounter(t, registry, "reaper_eviction_blocked_total", "namespace", "default")
+			if tt.blockedByPDB && blocked != 1 {
+				t.Errorf("Expected 1 blocked eviction, got %v", blocked)
+			}
+			if !tt.blockedByPDB && blocked != 0 {
+				t.Errorf("Expected no blocked evictions, got %v", blocked)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_ExceptReasons(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	except := []string{"OOMKilled"}
+	tests := []struct {
+		name          string
+		reason        string
+		reasons       []string
+		exceptReasons []string
+		live          *LiveConfig
+		expectDeleted bool
+	}{
+		{name: "Evicted is eligible", reason: "Evicted", exceptReasons: except, expectDeleted: true},
+		{name: "other reasons are eligible", reason: "Shutdown", exceptReasons: except, expectDeleted: true},
+		{name: "excepted reason is kept", reason: "OOMKilled", exceptReasons: except},
+		{name: "empty reason is kept", reason: "", exceptReasons: except},
+		{name: "allowlist keeps unlisted reasons", reason: "Shutdown", reasons: []string{"Evicted"}},
+		{name: "allowlist includes Shutdown", reason: "Shutdown", reasons: []string{"Evicted", "Shutdown"}, expectDeleted: true},
+		{name: "live reasons replace the denylist", reason: "OOMKilled", exceptReasons: except, live: &LiveConfig{Reasons: []string{"OOMKilled"}}, expectDeleted: true},
+		{name: "live reasons exclude the rest", reason: "Shutdown", exceptReasons: except, live: &LiveConfig{Reasons: []string{"OOMKilled"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    tt.reason,
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+			r := &PodReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Metrics:       metrics.NewPodMetrics(),
+				TTLToDelete:   300,
+				Reasons:       tt.reasons,
+				ExceptReasons: tt.exceptReasons,
+			}
+			if tt.live != nil {
+				r.SetLiveConfig(tt.live)
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected pod deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// explanation collects the checks applied to a pod and the first one that
+// stopped it from being deleted
+type explanation struct {
+	b          strings.Builder
+	verdict    string
+	quarantine bool
+}
+
+// check records a check and, if it failed and nothing failed before, the
+// outcome it leads to
+func (e *explanation) check(name string, pass bool, detail, outcome string) {
+	result := "yes"
+	if !pass {
+		result = "no"
+		if e.verdict == "" {
+			e.verdict = outcome
+		}
+	}
+	fmt.Fprintf(&e.b, "%-15s %s (%s)\n", name+":", result, detail)
+}
+
+// verdictOrDefault returns the verdict, which is to delete, or quarantine in
+// ModeQuarantine, if no check failed
+func (e *explanation) verdictOrDefault() string {
+	if e.verdict == "" && e.quarantine {
+		return "would quarantine"
+	}
+	if e.verdict == "" {
+		return "would delete"
+	}
+	return e.verdict
+}
+
+func (e *explanation) String() string {
+	return e.b.String() + "verdict: " + e.verdictOrDefault() + "\n"
+}
+
+// Explain describes how the reconciler would handle a pod, one check per
+// line in the order Reconcile applies them, followed by the verdict. Checks
+// that need the API server, such as maintenance windows, the pre-delete hook,
+// owner observation and Job TTL delegation, are not evaluated.
+func (r *PodReconciler) Explain(pod *corev1.Pod) string {
+	return r.explain(pod).String()
+}
+
+// Decide returns the verdict Explain would print for a pod, such as
+// "would delete" or "skip, pod is preserved"
+func (r *PodReconciler) Decide(pod *corev1.Pod) string {
+	return r.explain(pod).verdictOrDefault()
+}
+
+func (r *PodReconciler) explain(pod *corev1.Pod) *explanation {
+	e := &explanation{quarantine: r.Mode == ModeQuarantine}
+	fmt.Fprintf(&e.b, "pod %s/%s\n", pod.Namespace, pod.Name)
+
+	e.check("watched", r.isNamespaceWatched(pod.Namespace),
+		fmt.Sprintf("namespace %q, prefix %q", pod.Namespace, r.NamespacePrefix),
+		"ignore, namespace is not watched")
+	if r.Self != nil {
+		e.check("not self", !r.isSelf(pod), fmt.Sprintf("reaper namespace %q", r.Self.Namespace),
+			"skip, pod belongs to the reaper itself")
+	}
+
+	status := fmt.Sprintf("phase %q, reason %q", pod.Status.Phase, pod.Status.Reason)
+	kind := r.classify(pod)
+	var checks []podCheck
+	switch kind {
+	case kindEvicted:
+		e.check("evicted", true, status, "")
+		checks = r.evictedChecks(pod, false)
+	case kindUnschedulable:
+		e.check("unschedulable", true, status, "")
+		checks = r.unschedulableChecks(pod, unschedulableCondition(pod))
+	case kindCrashLoop:
+		e.check("crashlooping", true, status, "")
+		checks = r.crashLoopChecks(pod)
+	default:
+		e.check("evicted", false, status, "ignore, pod is not evicted")
+		return e
+	}
+	for _, c := range checks {
+		e.check(c.name, c.pass, c.detail, c.outcome)
+	}
+
+	// Unschedulable and crashlooping pods are only reaped once their owner
+	// is gone, which needs the API server unless they have none
+	if kind != kindEvicted {
+		if ref := ownerRef(pod.OwnerReferences); ref != nil {
+			e.check("owner gone", false, "needs the API server",
+				fmt.Sprintf("delete once %s %q is gone", ref.Kind, ref.Name))
+		} else {
+			e.check("owner gone", true, "no owner", "")
+		}
+	}
+	if len(r.DryRunNamespaces) > 0 {
+		e.check("not dry run", !r.isDryRunNamespace(pod.Namespace),
+			fmt.Sprintf("dry-run namespaces %q", r.DryRunNamespaces),
+			"skip, namespace is dry run")
+	}
+	return e
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// fastPathRequeue returns how long until a pod requeued before its TTL becomes
+// eligible, if it is not eligible yet. Entries whose time has come are dropped
+// so the pod is fetched and evaluated again.
+func (r *PodReconciler) fastPathRequeue(key types.NamespacedName) (time.Duration, bool) {
+	notBefore, ok := r.notBefore.Get(key)
+	if !ok {
+		return 0, false
+	}
+	remaining := time.Until(notBefore)
+	if remaining <= 0 {
+		r.notBefore.Delete(key)
+		return 0, false
+	}
+	return remaining, true
+}
+
+// invalidateFastPathPredicate drops the fast-path entry of any pod that
+// changes, so the next reconcile fetches it again. It never filters events.
+func (r *PodReconciler) invalidateFastPathPredicate() predicate.Funcs {
+	invalidate := func(obj client.Object) {
+		r.notBefore.Delete(client.ObjectKeyFromObject(obj))
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			invalidate(e.Object)
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			invalidate(e.ObjectNew)
+			return true
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			invalidate(e.Object)
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return true
+		},
+	}
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// getCountingClient counts Get calls made through it
+type getCountingClient struct {
+	client.Client
+	gets int
+}
+
+func (c *getCountingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	c.gets++
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func TestPodReconciler_FastPath(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-1 * time.Minute)},
+		},
+	}
+
+	newReconciler := func() (*PodReconciler, *getCountingClient) {
+		c := &getCountingClient{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build(),
+		}
+		return &PodReconciler{
+			Client:      c,
+			Scheme:      scheme,
+			Metrics:     metrics.NewPodMetrics(),
+			TTLToDelete: 300,
+		}, c
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+
+	t.Run("early requeue skips Get", func(t *testing.T) {
+		r, c := newReconciler()
+
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		result, err := r.Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+
+		if c.gets != 1 {
+			t.Errorf("Get called %d times, want 1", c.gets)
+		}
+		if result.RequeueAfter <= 0 || result.RequeueAfter > 4*time.Minute {
+			t.Errorf("Reconcile() RequeueAfter = %v, want remaining TTL", result.RequeueAfter)
+		}
+	})
+
+	t.Run("elapsed entry fetches the pod", func(t *testing.T) {
+		r, c := newReconciler()
+		r.notBefore.Set(req.NamespacedName, time.Now().Add(-time.Second))
+
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if c.gets != 1 {
+			t.Errorf("Get called %d times, want 1", c.gets)
+		}
+	})
+
+	t.Run("pod update invalidates the entry", func(t *testing.T) {
+		r, c := newReconciler()
+
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if !r.invalidateFastPathPredicate().Update(event.UpdateEvent{ObjectOld: pod, ObjectNew: pod}) {
+			t.Error("invalidateFastPathPredicate() filtered an update event")
+		}
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if c.gets != 2 {
+			t.Errorf("Get called %d times, want 2", c.gets)
+		}
+	})
+}
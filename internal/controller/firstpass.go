@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// firstPassWindow is how long after the first reconcile deletions are only
+// logged with FirstPassDryRun. The informer replays every existing pod on
+// startup, so this covers the first sweep.
+const firstPassWindow = time.Minute
+
+// firstPass tracks the dry-run first pass after startup
+type firstPass struct {
+	mu          sync.Mutex
+	ends        time.Time
+	wouldDelete map[string]int
+	done        bool
+}
+
+// firstPassDryRun logs a pod that would be deleted during the first pass
+// instead of deleting it, returning how long until the pass ends. Once the
+// pass is over it logs how many pods each namespace would have lost, and
+// returns false. Pods reaped through the API are deleted regardless. Each
+// pod held back is counted as deferred.
+func (r *PodReconciler) firstPassDryRun(ctx context.Context, pod *corev1.Pod, now time.Time) (time.Duration, bool) {
+	if !r.FirstPassDryRun || apiReapFrom(ctx) != nil {
+		return 0, false
+	}
+	logger := log.FromContext(ctx)
+
+	r.firstPass.mu.Lock()
+	defer r.firstPass.mu.Unlock()
+	if r.firstPass.done {
+		return 0, false
+	}
+	if !now.Before(r.firstPass.ends) {
+		r.firstPass.done = true
+		logger.Info("first pass dry run over, deleting from now on", "wouldDelete", r.firstPass.wouldDelete)
+		return 0, false
+	}
+
+	if r.firstPass.wouldDelete == nil {
+		r.firstPass.wouldDelete = make(map[string]int)
+	}
+	r.firstPass.wouldDelete[pod.Namespace]++
+	wait := r.firstPass.ends.Sub(now)
+	logger.Info("first pass dry run, would delete pod", "pod", client.ObjectKeyFromObject(pod),
+		"namespaceWouldDelete", r.firstPass.wouldDelete[pod.Namespace], "requeueAfter", wait)
+	r.Metrics.IncDeferred(metrics.DeferFirstPass)
+	return wait, true
+}
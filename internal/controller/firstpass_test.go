@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_FirstPassDryRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			},
+		}
+	}
+	pods := []*corev1.Pod{newPod("pod-a"), newPod("pod-b")}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pods[0], pods[1]).
+		Build()
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetrics()
+	podMetrics.Register(registry)
+	r := &PodReconciler{
+		Client:          fakeClient,
+		Scheme:          scheme,
+		Metrics:         podMetrics,
+		TTLToDelete:     300,
+		FirstPassDryRun: true,
+	}
+
+	reconcileAll := func() []reconcile.Result {
+		var results []reconcile.Result
+		for _, pod := range pods {
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			results = append(results, result)
+		}
+		return results
+	}
+	remaining := func() int {
+		list := &corev1.PodList{}
+		if err := fakeClient.List(context.Background(), list); err != nil {
+			t.Fatalf("Failed to list pods: %v", err)
+		}
+		return len(list.Items)
+	}
+
+	// The first pass only counts the pods it would delete
+	for _, result := range reconcileAll() {
+		if result.RequeueAfter <= 0 || result.RequeueAfter > firstPassWindow {
+			t.Errorf("Expected a requeue to the end of the first pass, got %v", result.RequeueAfter)
+		}
+	}
+	if n := remaining(); n != 2 {
+		t.Errorf("Expected no pods deleted during the first pass, %d of 2 remain", n)
+	}
+	if got := r.firstPass.wouldDelete["default"]; got != 2 {
+		t.Errorf("Expected 2 pods counted as would delete, got %d", got)
+	}
+	if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferFirstPass); got != 2 {
+		t.Errorf("Expected 2 first pass deferrals, got %v", got)
+	}
+
+	// Once the pass is over, the requeued pods are deleted
+	r.firstPass.ends = time.Now().Add(-time.Second)
+	for _, result := range reconcileAll() {
+		if result.RequeueAfter != 0 {
+			t.Errorf("Expected no requeue after the first pass, got %v", result.RequeueAfter)
+		}
+	}
+	if n := remaining(); n != 0 {
+		t.Errorf("Expected pods deleted after the first pass, %d remain", n)
+	}
+	if !r.firstPass.done {
+		t.Error("Expected the first pass to be over")
+	}
+}
+
+func TestPodReconciler_FirstPassDryRunUnschedulableAndCrashLoop(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name string
+		pod  *corev1.Pod
+	}{
+		{name: "unschedulable", pod: unschedulablePod(2 * time.Hour)},
+		{name: "crashloop", pod: runningPod(crashLoopBackOffReason, 2*time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(tt.pod).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				ReapUnschedulable: true,
+				UnschedulableTTL:  3600,
+				ReapCrashLoop:     true,
+				CrashLoopDuration: time.Hour,
+				FirstPassDryRun:   true,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: tt.pod.Name, Namespace: tt.pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if result.RequeueAfter <= 0 {
+				t.Errorf("Expected a requeue to the end of the first pass, got %v", result.RequeueAfter)
+			}
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err != nil {
+				t.Errorf("Expected pod to survive the first pass, got %v", err)
+			}
+			if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferFirstPass); got != 1 {
+				t.Errorf("reaper_deferred_total{reason=%q} = %v, want 1", metrics.DeferFirstPass, got)
+			}
+		})
+	}
+}
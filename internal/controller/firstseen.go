@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// firstSeenAnnotation records when the reaper first saw a pod evicted
+const firstSeenAnnotation = "pod-reaper.kyos.com/first-seen"
+
+// firstSeen returns when the reaper first saw the pod evicted, if
+// StampFirstSeen is enabled and the pod carries a valid stamp
+func (r *PodReconciler) firstSeen(pod *corev1.Pod) (time.Time, bool) {
+	if !r.StampFirstSeen {
+		return time.Time{}, false
+	}
+	value, ok := pod.Annotations[firstSeenAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	seen, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return seen, true
+}
+
+// stampFirstSeen sets the first-seen annotation on a pod, unless it is
+// already present
+func (r *PodReconciler) stampFirstSeen(ctx context.Context, pod *corev1.Pod, now time.Time) error {
+	return r.patchWithRetry(ctx, pod, func() bool {
+		if _, ok := pod.Annotations[firstSeenAnnotation]; ok {
+			return false
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[firstSeenAnnotation] = now.UTC().Format(time.RFC3339)
+		return true
+	})
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_StampFirstSeen(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+	}
+
+	var stamps int
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if _, ok := obj.GetAnnotations()[firstSeenAnnotation]; ok {
+					if _, reaped := obj.GetAnnotations()[reapedAnnotation]; !reaped {
+						stamps++
+					}
+				}
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	// A fresh reconciler per pass stands in for a restarted reaper, so
+	// nothing carries over from memory between reconciles
+	newReconciler := func() *PodReconciler {
+		return &PodReconciler{
+			Client:            fakeClient,
+			Scheme:            scheme,
+			Metrics:           metrics.NewPodMetrics(),
+			TTLToDelete:       300,
+			AllowedNamespaces: []string{"default"},
+			StampFirstSeen:    true,
+		}
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+
+	// The StartTime is past the TTL, but the pod has only just been seen
+	var firstStamp string
+	for i := 0; i < 2; i++ {
+		result, err := newReconciler().Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if result.RequeueAfter <= 0 || result.RequeueAfter > 300*time.Second {
+			t.Errorf("Expected a requeue against the first-seen time, got %v", result.RequeueAfter)
+		}
+
+		current := &corev1.Pod{}
+		if err := fakeClient.Get(context.Background(), req.NamespacedName, current); err != nil {
+			t.Fatalf("Expected pod to exist, got error: %v", err)
+		}
+		stamp := current.Annotations[firstSeenAnnotation]
+		if _, err := time.Parse(time.RFC3339, stamp); err != nil {
+			t.Fatalf("Expected an RFC3339 first-seen stamp, got %q", stamp)
+		}
+		if i == 0 {
+			firstStamp = stamp
+		} else if stamp != firstStamp {
+			t.Errorf("Expected the stamp to be kept, got %q then %q", firstStamp, stamp)
+		}
+	}
+	if stamps != 1 {
+		t.Errorf("Expected the stamp to be written once, got %d writes", stamps)
+	}
+
+	// Once the first-seen time is past the TTL the pod is deleted
+	current := &corev1.Pod{}
+	_ = fakeClient.Get(context.Background(), req.NamespacedName, current)
+	current.Annotations[firstSeenAnnotation] = time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339)
+	if err := fakeClient.Update(context.Background(), current); err != nil {
+		t.Fatalf("Failed to update pod: %v", err)
+	}
+	if _, err := newReconciler().Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+		t.Error("Expected pod to be deleted once its first-seen time is past the TTL")
+	}
+}
+
+func TestPodReconciler_FirstSeenDisabled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// A stale stamp is ignored when stamping is disabled
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{firstSeenAnnotation: time.Now().UTC().Format(time.RFC3339)},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           metrics.NewPodMetrics(),
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+	}
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+		t.Error("Expected pod to be deleted based on its StartTime")
+	}
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"context"
+	"time"
+)
+
+// runHeartbeat increments the heartbeat counter every HeartbeatInterval
+// until the context is cancelled. Like the controller it only runs on the
+// leader, so a counter that stops increasing means the reaper is hung or
+// has lost leadership without another replica taking over.
+func (r *PodReconciler) runHeartbeat(ctx context.Context) error {
+	ticker := time.NewTicker(r.HeartbeatInterval)
+	defer ticker.Stop()
+	r.Metrics.IncHeartbeat()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.Metrics.IncHeartbeat()
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPodReconciler_Heartbeat(t *testing.T) {
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Metrics:           podMetrics,
+		HeartbeatInterval: 10 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- r.runHeartbeat(ctx) }()
+
+	heartbeats := func() float64 {
+		t.Helper()
+		mfs, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Failed to gather metrics: %v", err)
+		}
+		for _, mf := range mfs {
+			if mf.GetName() == "evicted_pod_reaper_heartbeat_total" {
+				return mf.GetMetric()[0].GetCounter().GetValue()
+			}
+		}
+		return 0
+	}
+
+	// The counter keeps increasing while the heartbeat runs
+	var last float64
+	for i := 0; i < 3; i++ {
+		deadline := time.Now().Add(time.Second)
+		for heartbeats() <= last && time.Now().Before(deadline) {
+			time.Sleep(5 * time.Millisecond)
+		}
+		got := heartbeats()
+		if got <= last {
+			t.Fatalf("Expected heartbeat counter to increase past %v, got %v", last, got)
+		}
+		last = got
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("runHeartbeat() error = %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// defaultPreDeleteHookTimeout bounds how long a hook may run
+	defaultPreDeleteHookTimeout = 30 * time.Second
+	// preDeleteHookRequeueAfter is how long to wait before retrying a pod
+	// whose deletion was vetoed by the hook
+	preDeleteHookRequeueAfter = time.Minute
+	// preDeleteHookWaitDelay bounds how long a timed-out hook's output is
+	// still read, as child processes it started may hold the pipe open
+	preDeleteHookWaitDelay = time.Second
+)
+
+// PreDeleteHook runs an external command before an evicted pod is deleted.
+// The pod details are passed as environment variables, and a non-zero exit
+// status vetoes the deletion.
+type PreDeleteHook struct {
+	Command string
+	Timeout time.Duration
+
+	// Env lists variables of the manager's environment passed on to the
+	// hook besides PATH. Nothing else is, so credentials stay with the
+	// manager.
+	Env []string
+}
+
+// Run executes the hook for a pod and returns its combined output. A non-nil
+// error means the hook failed, timed out or vetoed the deletion.
+func (h *PreDeleteHook) Run(ctx context.Context, pod *corev1.Pod) (string, error) {
+	timeout := h.Timeout
+	if timeout <= 0 {
+		timeout = defaultPreDeleteHookTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, h.Command)
+	cmd.WaitDelay = preDeleteHookWaitDelay
+	cmd.Env = append(h.environ(),
+		"REAPER_POD_NAME="+pod.Name,
+		"REAPER_POD_NAMESPACE="+pod.Namespace,
+		"REAPER_POD_UID="+string(pod.UID),
+		"REAPER_POD_NODE="+pod.Spec.NodeName,
+		"REAPER_POD_REASON="+pod.Status.Reason,
+		"REAPER_POD_MESSAGE="+pod.Status.Message,
+	)
+
+	out, err := cmd.CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("pre-delete hook timed out after %s", timeout)
+	}
+	if err != nil {
+		return output, fmt.Errorf("pre-delete hook failed: %w", err)
+	}
+	return output, nil
+}
+
+// environ returns PATH and the variables listed in Env from the manager's
+// environment, skipping unset ones
+func (h *PreDeleteHook) environ() []string {
+	var env []string
+	for _, name := range append([]string{"PATH"}, h.Env...) {
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	return env
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// writeHook writes an executable stub hook script and returns its path
+func writeHook(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hook.sh")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o755); err != nil {
+		t.Fatalf("Failed to write hook: %v", err)
+	}
+	return path
+}
+
+func TestPreDeleteHook_Run(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Reason: "Evicted",
+		},
+	}
+
+	t.Run("passes pod details as environment", func(t *testing.T) {
+		hook := &PreDeleteHook{Command: writeHook(t, `echo "$REAPER_POD_NAMESPACE/$REAPER_POD_NAME $REAPER_POD_REASON"`)}
+
+		output, err := hook.Run(context.Background(), pod)
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if output != "default/test-pod Evicted" {
+			t.Errorf("Run() output = %q, want %q", output, "default/test-pod Evicted")
+		}
+	})
+
+	t.Run("passes only PATH and allowed variables from the environment", func(t *testing.T) {
+		t.Setenv("REAPER_TEST_SECRET", "hunter2")
+		t.Setenv("REAPER_TEST_ALLOWED", "yes")
+		hook := &PreDeleteHook{
+			Command: writeHook(t, `echo "secret=$REAPER_TEST_SECRET allowed=$REAPER_TEST_ALLOWED"; command -v sleep >/dev/null`),
+			Env:     []string{"REAPER_TEST_ALLOWED"},
+		}
+
+		output, err := hook.Run(context.Background(), pod)
+		if err != nil {
+			t.Fatalf("Run() error = %v, want PATH to be passed, output %q", err, output)
+		}
+		if output != "secret= allowed=yes" {
+			t.Errorf("Run() output = %q, want %q", output, "secret= allowed=yes")
+		}
+	})
+
+	t.Run("non-zero exit vetoes", func(t *testing.T) {
+		hook := &PreDeleteHook{Command: writeHook(t, "echo keep; exit 3")}
+
+		output, err := hook.Run(context.Background(), pod)
+		if err == nil {
+			t.Fatal("Run() expected an error for non-zero exit")
+		}
+		if output != "keep" {
+			t.Errorf("Run() output = %q, want %q", output, "keep")
+		}
+	})
+
+	t.Run("timeout vetoes", func(t *testing.T) {
+		hook := &PreDeleteHook{
+			Command: writeHook(t, "exec sleep 5"),
+			Timeout: 100 * time.Millisecond,
+		}
+
+		if _, err := hook.Run(context.Background(), pod); err == nil {
+			t.Fatal("Run() expected an error when the hook times out")
+		}
+	})
+
+	t.Run("timeout with a child process holding the output", func(t *testing.T) {
+		hook := &PreDeleteHook{
+			Command: writeHook(t, "sleep 5; true"),
+			Timeout: 100 * time.Millisecond,
+		}
+
+		start := time.Now()
+		if _, err := hook.Run(context.Background(), pod); err == nil {
+			t.Fatal("Run() expected an error when the hook times out")
+		}
+		if elapsed := time.Since(start); elapsed > 3*time.Second {
+			t.Errorf("Run() returned after %v, want it bounded by the timeout", elapsed)
+		}
+	})
+}
+
+func TestPodReconciler_PreDeleteHook(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		script        string
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "allowing hook lets deletion proceed",
+			script:        "exit 0",
+			expectDeleted: true,
+			expectRequeue: false,
+		},
+		{
+			name:          "vetoing hook requeues the pod",
+			script:        "exit 1",
+			expectDeleted: false,
+			expectRequeue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Metrics:       metrics.NewPodMetrics(),
+				TTLToDelete:   300,
+				PreDeleteHook: &PreDeleteHook{Command: writeHook(t, tt.script)},
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
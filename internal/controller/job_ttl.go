@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;patch
+
+// owningJob returns the Job controlling the pod, or nil if the pod is not
+// owned by a Job or the Job no longer exists.
+func (r *PodReconciler) owningJob(ctx context.Context, pod *corev1.Pod) (*batchv1.Job, error) {
+	ref := ownerRef(pod.OwnerReferences)
+	if ref == nil || ref.Kind != "Job" {
+		return nil, nil
+	}
+
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, job); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+// delegateToJobTTL hands cleanup of a Job-owned pod over to the Job's
+// ttlSecondsAfterFinished. It returns true if the pod is Job-owned and its
+// cleanup is delegated, and whether the Job had to be patched.
+func (r *PodReconciler) delegateToJobTTL(ctx context.Context, pod *corev1.Pod) (delegated, patched bool, err error) {
+	job, err := r.owningJob(ctx, pod)
+	if err != nil || job == nil {
+		return false, false, err
+	}
+
+	ttl := r.JobTTLSecondsAfterFinished
+	err = r.patchWithRetry(ctx, job, func() bool {
+		if job.Spec.TTLSecondsAfterFinished != nil && *job.Spec.TTLSecondsAfterFinished <= ttl {
+			// Already cleaned up at least as eagerly as we would
+			patched = false
+			return false
+		}
+		job.Spec.TTLSecondsAfterFinished = &ttl
+		patched = true
+		return true
+	})
+	if err != nil {
+		return false, false, err
+	}
+	return true, patched, nil
+}
@@ -0,0 +1,153 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_JobTTL(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	existingTTL := int32(0)
+
+	tests := []struct {
+		name          string
+		useJobTTL     bool
+		owners        []metav1.OwnerReference
+		jobTTL        *int32
+		expectDeleted bool
+		expectJobTTL  bool
+		expectPatched float64
+	}{
+		{
+			name:          "job-owned pod patches the job instead of deleting",
+			useJobTTL:     true,
+			owners:        []metav1.OwnerReference{controllerRef("batch/v1", "Job", "batch", "job-uid")},
+			expectDeleted: false,
+			expectJobTTL:  true,
+			expectPatched: 1,
+		},
+		{
+			name:          "job with ttl already set is not patched again",
+			useJobTTL:     true,
+			owners:        []metav1.OwnerReference{controllerRef("batch/v1", "Job", "batch", "job-uid")},
+			jobTTL:        &existingTTL,
+			expectDeleted: false,
+			expectJobTTL:  true,
+			expectPatched: 0,
+		},
+		{
+			name:          "pod owned by missing job is deleted directly",
+			useJobTTL:     true,
+			owners:        []metav1.OwnerReference{controllerRef("batch/v1", "Job", "gone", "gone-uid")},
+			expectDeleted: true,
+			expectJobTTL:  false,
+			expectPatched: 0,
+		},
+		{
+			name:          "non-job pod is deleted directly",
+			useJobTTL:     true,
+			owners:        []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")},
+			expectDeleted: true,
+			expectJobTTL:  false,
+			expectPatched: 0,
+		},
+		{
+			name:          "job-owned pod is deleted directly when disabled",
+			useJobTTL:     false,
+			owners:        []metav1.OwnerReference{controllerRef("batch/v1", "Job", "batch", "job-uid")},
+			expectDeleted: true,
+			expectJobTTL:  false,
+			expectPatched: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "batch",
+					Namespace: "default",
+					UID:       "job-uid",
+				},
+				Spec: batchv1.JobSpec{
+					TTLSecondsAfterFinished: tt.jobTTL,
+				},
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "batch-xyz",
+					Namespace:       "default",
+					OwnerReferences: tt.owners,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod, job).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+				UseJobTTL:   tt.useJobTTL,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+
+			updatedJob := &batchv1.Job{}
+			if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "batch", Namespace: "default"}, updatedJob); err != nil {
+				t.Fatalf("Failed to get job: %v", err)
+			}
+			hasTTL := updatedJob.Spec.TTLSecondsAfterFinished != nil
+			if hasTTL != tt.expectJobTTL {
+				t.Errorf("Job has ttlSecondsAfterFinished = %v, want %v", hasTTL, tt.expectJobTTL)
+			}
+
+			patched := gatherCounter(t, registry, "evicted_pods_job_ttl_patched_total", "namespace", "default")
+			if patched != tt.expectPatched {
+				t.Errorf("evicted_pods_job_ttl_patched_total = %v, want %v", patched, tt.expectPatched)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LabelTTL gives finished pods carrying a label a TTL of their own, for
+// workloads such as Spark drivers or Argo steps that leave their pods behind
+type LabelTTL struct {
+	Key   string
+	Value string
+	// TTL in seconds
+	TTL int
+}
+
+// labelTTL returns the TTL of the first LabelTTLs rule matching the pod's
+// labels. It returns false if none matches.
+func (r *PodReconciler) labelTTL(pod *corev1.Pod) (int, bool) {
+	for _, rule := range r.LabelTTLs {
+		if value, ok := pod.Labels[rule.Key]; ok && value == rule.Value {
+			return rule.TTL, true
+		}
+	}
+	return 0, false
+}
+
+// isFinishedLabeled checks if a pod has Succeeded or Failed and matches a
+// LabelTTLs rule, which makes it a candidate whatever its reason
+func (r *PodReconciler) isFinishedLabeled(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	_, ok := r.labelTTL(pod)
+	return ok
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_LabelTTLs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	driver := map[string]string{"spark-role": "driver"}
+
+	tests := []struct {
+		name          string
+		labels        map[string]string
+		phase         corev1.PodPhase
+		reason        string
+		age           time.Duration
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "labeled succeeded pod past its rule TTL is deleted",
+			labels:        driver,
+			phase:         corev1.PodSucceeded,
+			age:           20 * time.Minute,
+			expectDeleted: true,
+		},
+		{
+			name:          "labeled failed pod past its rule TTL is deleted",
+			labels:        driver,
+			phase:         corev1.PodFailed,
+			reason:        "Error",
+			age:           20 * time.Minute,
+			expectDeleted: true,
+		},
+		{
+			name:          "labeled evicted pod follows its rule TTL over the default",
+			labels:        driver,
+			phase:         corev1.PodFailed,
+			reason:        "Evicted",
+			age:           5 * time.Minute,
+			expectRequeue: true,
+		},
+		{
+			name:   "labeled running pod is left alone",
+			labels: driver,
+			phase:  corev1.PodRunning,
+			age:    20 * time.Minute,
+		},
+		{
+			name:  "unlabeled succeeded pod is left alone",
+			phase: corev1.PodSucceeded,
+			age:   20 * time.Minute,
+		},
+		{
+			name:          "unlabeled evicted pod follows the default TTL",
+			labels:        map[string]string{"spark-role": "executor"},
+			phase:         corev1.PodFailed,
+			reason:        "Evicted",
+			age:           5 * time.Minute,
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+					Labels:    tt.labels,
+				},
+				Status: corev1.PodStatus{
+					Phase:     tt.phase,
+					Reason:    tt.reason,
+					StartTime: &metav1.Time{Time: time.Now().Add(-tt.age)},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     metrics.NewPodMetrics(),
+				TTLToDelete: 60,
+				LabelTTLs:   []LabelTTL{{Key: "spark-role", Value: "driver", TTL: 600}},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted = %v, got %v", tt.expectDeleted, deleted)
+			}
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+		})
+	}
+}
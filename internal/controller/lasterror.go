@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// LastErrorPath is where the last reconcile error is served
+const LastErrorPath = "/debug/last-error"
+
+// lastError holds the most recent reconcile error
+type lastError struct {
+	mu      sync.Mutex
+	message string
+	pod     types.NamespacedName
+	at      time.Time
+}
+
+// lastErrorResponse is the JSON served by the last error endpoint. It is
+// empty until a reconcile fails.
+type lastErrorResponse struct {
+	Message string     `json:"message,omitempty"`
+	Pod     string     `json:"pod,omitempty"`
+	Time    *time.Time `json:"time,omitempty"`
+}
+
+func (l *lastError) record(pod types.NamespacedName, err error, at time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.message = err.Error()
+	l.pod = pod
+	l.at = at
+}
+
+func (l *lastError) response() lastErrorResponse {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.at.IsZero() {
+		return lastErrorResponse{}
+	}
+	at := l.at
+	return lastErrorResponse{Message: l.message, Pod: l.pod.String(), Time: &at}
+}
+
+// LastErrorHandler serves the most recent reconcile error as JSON
+func (r *PodReconciler) LastErrorHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(r.lastErr.response())
+	})
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_LastErrorHandler(t *testing.T) {
+	client := &errorClient{}
+	r := &PodReconciler{
+		Client:      client,
+		Metrics:     metrics.NewPodMetrics(),
+		TTLToDelete: 300,
+	}
+
+	get := func() lastErrorResponse {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		r.LastErrorHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LastErrorPath, nil))
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		var resp lastErrorResponse
+		if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+
+	if resp := get(); resp.Message != "" || resp.Time != nil {
+		t.Errorf("Expected empty response before any error, got %+v", resp)
+	}
+
+	reconcileWith := func(name string, err error) {
+		client.deleteError = err
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+		_, _ = r.Reconcile(context.Background(), req)
+	}
+
+	reconcileWith("first-pod", errors.New("first failure"))
+	reconcileWith("second-pod", errors.New("second failure"))
+	reconcileWith("third-pod", nil)
+
+	resp := get()
+	if want := "deleting pod default/second-pod: second failure"; resp.Message != want {
+		t.Errorf("last error message = %q, want %q", resp.Message, want)
+	}
+	if resp.Pod != "default/second-pod" {
+		t.Errorf("last error pod = %q, want %q", resp.Pod, "default/second-pod")
+	}
+	if resp.Time == nil || resp.Time.IsZero() {
+		t.Error("Expected last error time to be set")
+	}
+}
@@ -0,0 +1,83 @@
+package controller
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	// latencyWindow is how far back delete calls count towards the p99
+	latencyWindow = time.Minute
+	// latencyMinSamples is the minimum number of delete calls in the window
+	// before the p99 is considered meaningful
+	latencyMinSamples = 10
+	// latencyBackoffRequeueAfter is how long deletions are held back while
+	// the API server is slow
+	latencyBackoffRequeueAfter = 30 * time.Second
+)
+
+// LatencyGuard tracks the duration of recent delete calls and backs off new
+// deletions while their p99 exceeds a threshold, so a struggling API server
+// isn't piled onto. No deletes are made while backing off, so the window
+// drains and deletions resume once it has passed.
+type LatencyGuard struct {
+	threshold time.Duration
+
+	mu      sync.Mutex
+	samples []latencySample
+}
+
+type latencySample struct {
+	at       time.Time
+	duration time.Duration
+}
+
+// NewLatencyGuard creates a LatencyGuard that engages when the p99 of the
+// delete calls made within the last minute exceeds threshold
+func NewLatencyGuard(threshold time.Duration) *LatencyGuard {
+	return &LatencyGuard{threshold: threshold}
+}
+
+// Record records the duration of a delete call
+func (g *LatencyGuard) Record(now time.Time, d time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.samples = append(g.samples, latencySample{at: now, duration: d})
+	g.prune(now)
+}
+
+// Check returns the p99 delete latency and reports whether new deletions
+// should back off
+func (g *LatencyGuard) Check(now time.Time) (time.Duration, bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prune(now)
+
+	if len(g.samples) < latencyMinSamples {
+		return 0, false
+	}
+	p99 := g.percentile(0.99)
+	return p99, p99 > g.threshold
+}
+
+func (g *LatencyGuard) prune(now time.Time) {
+	cutoff := now.Add(-latencyWindow)
+	i := 0
+	for i < len(g.samples) && g.samples[i].at.Before(cutoff) {
+		i++
+	}
+	g.samples = g.samples[i:]
+}
+
+// percentile returns the nearest-rank percentile q (0-1) of the samples
+func (g *LatencyGuard) percentile(q float64) time.Duration {
+	durations := make([]time.Duration, len(g.samples))
+	for i, sample := range g.samples {
+		durations[i] = sample.duration
+	}
+	slices.Sort(durations)
+	rank := int(math.Ceil(q*float64(len(durations)))) - 1
+	return durations[max(rank, 0)]
+}
@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestLatencyGuard_EngagesAndDisengages(t *testing.T) {
+	g := NewLatencyGuard(100 * time.Millisecond)
+	now := time.Now()
+
+	// Too few samples to judge
+	for i := 0; i < latencyMinSamples-1; i++ {
+		g.Record(now, time.Second)
+	}
+	if _, backoff := g.Check(now); backoff {
+		t.Fatal("Check() backed off before reaching the minimum sample count")
+	}
+
+	// A slow p99 engages the backoff
+	g.Record(now, time.Second)
+	p99, backoff := g.Check(now)
+	if !backoff || p99 != time.Second {
+		t.Fatalf("Check() = (%v, %v), want (%v, true)", p99, backoff, time.Second)
+	}
+
+	// Once the slow calls leave the window and calls are fast, it disengages
+	later := now.Add(2 * latencyWindow)
+	for i := 0; i < latencyMinSamples; i++ {
+		g.Record(later, 10*time.Millisecond)
+	}
+	if p99, backoff := g.Check(later); backoff {
+		t.Fatalf("Check() = (%v, true), want the backoff to disengage", p99)
+	}
+}
+
+func TestLatencyGuard_P99(t *testing.T) {
+	g := NewLatencyGuard(100 * time.Millisecond)
+	now := time.Now()
+
+	// A single slow call among a hundred fast ones stays under the p99
+	for i := 0; i < 99; i++ {
+		g.Record(now, 10*time.Millisecond)
+	}
+	g.Record(now, time.Second)
+	if p99, backoff := g.Check(now); backoff || p99 != 10*time.Millisecond {
+		t.Errorf("Check() = (%v, %v), want (%v, false)", p99, backoff, 10*time.Millisecond)
+	}
+
+	// A second one reaches it
+	g.Record(now, time.Second)
+	if p99, backoff := g.Check(now); !backoff || p99 != time.Second {
+		t.Errorf("Check() = (%v, %v), want (%v, true)", p99, backoff, time.Second)
+	}
+}
+
+func TestPodReconciler_LatencyBackoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			},
+		}
+	}
+	var objs []runtime.Object
+	for i := 0; i <= latencyMinSamples; i++ {
+		objs = append(objs, newPod(fmt.Sprintf("pod-%d", i)))
+	}
+
+	// Every delete is slower than the threshold
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(objs...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				time.Sleep(5 * time.Millisecond)
+				return c.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:       fakeClient,
+		Scheme:       scheme,
+		Metrics:      podMetrics,
+		TTLToDelete:  300,
+		LatencyGuard: NewLatencyGuard(time.Millisecond),
+	}
+
+	reconcilePod := func(i int) (reconcile.Result, types.NamespacedName) {
+		t.Helper()
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("pod-%d", i), Namespace: "default"}}
+		result, err := r.Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		return result, req.NamespacedName
+	}
+
+	// The first deletes go through and fill the window
+	for i := 0; i < latencyMinSamples; i++ {
+		if result, _ := reconcilePod(i); result.RequeueAfter != 0 {
+			t.Fatalf("Reconcile() of pod %d RequeueAfter = %v, want the pod deleted", i, result.RequeueAfter)
+		}
+	}
+
+	// The next one backs off
+	result, key := reconcilePod(latencyMinSamples)
+	if result.RequeueAfter != latencyBackoffRequeueAfter {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, latencyBackoffRequeueAfter)
+	}
+	if err := fakeClient.Get(context.Background(), key, &corev1.Pod{}); err != nil {
+		t.Errorf("Expected pod to exist while backing off, but got error: %v", err)
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	var backoff float64
+	for _, mf := range mfs {
+		if mf.GetName() == "reaper_latency_backoff" {
+			backoff = mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	if backoff != 1 {
+		t.Errorf("reaper_latency_backoff = %v, want 1", backoff)
+	}
+	if deferred := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferLatencyBackoff); deferred != 1 {
+		t.Errorf("reaper_deferred_total{reason=%q} = %v, want 1", metrics.DeferLatencyBackoff, deferred)
+	}
+}
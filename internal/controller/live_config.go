@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+)
+
+// reaperDisabledRequeueAfter is how often pods are checked again while
+// reaping is disabled, so they are picked up soon after it is enabled
+const reaperDisabledRequeueAfter = time.Minute
+
+// liveConfigRequeueAfter is how long reconciles arriving before the
+// ReaperConfig has been loaded are deferred
+const liveConfigRequeueAfter = 2 * time.Second
+
+// LiveConfig holds settings applied to a running reconciler, overriding the
+// ones it was started with. Unset fields keep the startup value.
+type LiveConfig struct {
+	// Disabled pauses reaping
+	Disabled bool
+
+	// TTLToDelete replaces the default TTL
+	TTLToDelete *int
+
+	// Namespaces limits reaping to these namespaces, within the watched ones
+	Namespaces []string
+
+	// Reasons replaces Evicted as the status reasons eligible for reaping
+	Reasons []string
+}
+
+// SetLiveConfig applies settings to the running reconciler. Nil restores
+// the settings it was started with.
+func (r *PodReconciler) SetLiveConfig(cfg *LiveConfig) {
+	r.liveConfig.Store(cfg)
+	r.liveConfigLoaded.Store(true)
+}
+
+// requeueCandidates queues every candidate pod again, so pods that settings
+// applied at runtime newly make eligible are not left waiting for an event
+func (r *PodReconciler) requeueCandidates(ctx context.Context) error {
+	if r.liveReloads == nil {
+		return nil
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods); err != nil {
+		return fmt.Errorf("listing pods: %w", err)
+	}
+	for i := range pods.Items {
+		if !r.isCandidatePodPredicate(&pods.Items[i]) {
+			continue
+		}
+		// A new TTL makes the fast-path requeue times stale
+		r.notBefore.Delete(client.ObjectKeyFromObject(&pods.Items[i]))
+		select {
+		case r.liveReloads <- event.GenericEvent{Object: &pods.Items[i]}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// live returns the settings applied at runtime, never nil
+func (r *PodReconciler) live() *LiveConfig {
+	if cfg := r.liveConfig.Load(); cfg != nil {
+		return cfg
+	}
+	return &LiveConfig{}
+}
+
+// defaultTTL returns the TTL of pods without a more specific one
+func (r *PodReconciler) defaultTTL() int {
+	if ttl := r.live().TTLToDelete; ttl != nil {
+		return *ttl
+	}
+	return r.TTLToDelete
+}
+
+// isLiveNamespace checks if the namespace is one reaping is limited to at
+// runtime, if any
+func (r *PodReconciler) isLiveNamespace(namespace string) bool {
+	namespaces := r.live().Namespaces
+	return len(namespaces) == 0 || slices.Contains(namespaces, namespace)
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_LiveConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	liveTTL := 3600
+
+	tests := []struct {
+		name          string
+		live          *LiveConfig
+		namespace     string
+		reason        string
+		expectDeleted bool
+		expectRequeue time.Duration
+	}{
+		{name: "no live config", expectDeleted: true},
+		{name: "disabled", live: &LiveConfig{Disabled: true}, expectRequeue: reaperDisabledRequeueAfter},
+		{name: "live TTL not exceeded", live: &LiveConfig{TTLToDelete: &liveTTL}, expectRequeue: 45 * time.Minute},
+		{name: "namespace outside live namespaces", live: &LiveConfig{Namespaces: []string{"team-a"}}},
+		{name: "namespace in live namespaces", live: &LiveConfig{Namespaces: []string{"team-a"}}, namespace: "team-a", expectDeleted: true},
+		{name: "live reasons include Shutdown", live: &LiveConfig{Reasons: []string{"Shutdown"}}, reason: "Shutdown", expectDeleted: true},
+		{name: "live reasons exclude Evicted", live: &LiveConfig{Reasons: []string{"Shutdown"}}},
+		{name: "Shutdown is not eligible by default", reason: "Shutdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := tt.namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			reason := tt.reason
+			if reason == "" {
+				reason = "Evicted"
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: namespace},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    reason,
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     metrics.NewPodMetrics(),
+				TTLToDelete: 300,
+			}
+			r.SetLiveConfig(tt.live)
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			if result.RequeueAfter < tt.expectRequeue || (tt.expectRequeue == 0 && result.RequeueAfter != 0) {
+				t.Errorf("Expected a requeue of at least %v, got %v", tt.expectRequeue, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_WaitsForReaperConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:           fakeClient,
+		Scheme:           scheme,
+		Metrics:          podMetrics,
+		TTLToDelete:      300,
+		ReaperConfigured: true,
+	}
+
+	ctx := context.Background()
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+	reconcilePod := func() time.Duration {
+		t.Helper()
+		result, err := r.Reconcile(ctx, req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if err := fakeClient.Get(ctx, req.NamespacedName, &corev1.Pod{}); err != nil {
+			t.Fatalf("Expected the pod to be kept, got %v", err)
+		}
+		return result.RequeueAfter
+	}
+
+	// A ReaperConfig disabling reaping may not be loaded yet
+	if got := reconcilePod(); got != liveConfigRequeueAfter {
+		t.Errorf("RequeueAfter before the ReaperConfig is loaded = %v, want %v", got, liveConfigRequeueAfter)
+	}
+
+	r.SetLiveConfig(&LiveConfig{Disabled: true})
+	if got := reconcilePod(); got != reaperDisabledRequeueAfter {
+		t.Errorf("RequeueAfter once disabled = %v, want %v", got, reaperDisabledRequeueAfter)
+	}
+
+	for _, reason := range []string{metrics.DeferReaperConfig, metrics.DeferReaperDisabled} {
+		if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", reason); got != 1 {
+			t.Errorf("reaper_deferred_total{reason=%q} = %v, want 1", reason, got)
+		}
+	}
+}
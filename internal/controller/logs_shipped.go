@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// logsShippedAnnotation is set by a log shipper once a pod's logs have been
+// shipped. Any value counts.
+const logsShippedAnnotation = "pod-reaper.kyos.com/logs-shipped"
+
+// logsShippedWait returns whether a pod has to wait for its logs to be
+// shipped before it is deleted, and how long until LogsShippedTimeout
+// expires. Without a timeout the pod waits until the annotation is set, and
+// the reconcile it triggers deletes it.
+func (r *PodReconciler) logsShippedWait(pod *corev1.Pod) (time.Duration, bool) {
+	if !r.WaitForLogsShipped {
+		return 0, false
+	}
+	if _, ok := pod.Annotations[logsShippedAnnotation]; ok {
+		return 0, false
+	}
+	if r.LogsShippedTimeout <= 0 {
+		return 0, true
+	}
+	if _, ok := r.ageStart(pod); !ok {
+		// Nothing to measure the wait from
+		return 0, false
+	}
+
+	// The wait starts once the TTL is exceeded
+	waited := r.podAge(pod) - time.Duration(r.ttlFor(pod))*time.Second
+	if waited < 0 {
+		waited = 0
+	}
+	if remaining := r.LogsShippedTimeout - waited; remaining > 0 {
+		return remaining, true
+	}
+	return 0, false
+}
+
+// logsShippedCheck checks if a pod's logs were shipped, or the wait for them
+// timed out
+func (r *PodReconciler) logsShippedCheck(pod *corev1.Pod) podCheck {
+	wait, waiting := r.logsShippedWait(pod)
+	_, shipped := pod.Annotations[logsShippedAnnotation]
+	detail := fmt.Sprintf("annotation %q", logsShippedAnnotation)
+	if !shipped && !waiting {
+		detail += fmt.Sprintf(", timed out after %s", r.LogsShippedTimeout)
+	}
+	outcome := "wait for the logs to be shipped"
+	if wait > 0 {
+		outcome = fmt.Sprintf("requeue in %s", wait.Round(time.Second))
+	}
+	return podCheck{
+		name:         "logs shipped",
+		pass:         !waiting,
+		detail:       detail,
+		outcome:      outcome,
+		requeueAfter: wait,
+		deferReason:  metrics.DeferLogsShipped,
+	}
+}
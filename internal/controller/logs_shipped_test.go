@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_WaitForLogsShipped(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// Pods are 5 minutes past their TTL
+	tests := []struct {
+		name          string
+		shipped       bool
+		timeout       time.Duration
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "shipped is deleted",
+			shipped:       true,
+			timeout:       time.Hour,
+			expectDeleted: true,
+		},
+		{
+			name:          "not shipped under timeout waits",
+			timeout:       time.Hour,
+			expectRequeue: true,
+		},
+		{
+			name:          "not shipped over timeout is deleted",
+			timeout:       time.Minute,
+			expectDeleted: true,
+		},
+		{
+			name: "not shipped without timeout waits for the annotation",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			if tt.shipped {
+				pod.Annotations = map[string]string{logsShippedAnnotation: "true"}
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:             fakeClient,
+				Scheme:             scheme,
+				Metrics:            podMetrics,
+				TTLToDelete:        300,
+				AllowedNamespaces:  []string{"default"},
+				WaitForLogsShipped: true,
+				LogsShippedTimeout: tt.timeout,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			if tt.expectRequeue {
+				// The timeout runs from when the TTL expired
+				if result.RequeueAfter <= 50*time.Minute || result.RequeueAfter > 55*time.Minute {
+					t.Errorf("Expected a requeue when the timeout expires, got %v", result.RequeueAfter)
+				}
+			} else if result.RequeueAfter != 0 {
+				t.Errorf("Expected no requeue, got %v", result.RequeueAfter)
+			}
+			wantDeferred := 0.0
+			if !tt.expectDeleted {
+				wantDeferred = 1
+			}
+			if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferLogsShipped); got != wantDeferred {
+				t.Errorf("reaper_deferred_total{reason=%q} = %v, want %v", metrics.DeferLogsShipped, got, wantDeferred)
+			}
+		})
+	}
+}
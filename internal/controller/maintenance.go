@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// maintenanceWindow is a period during which deletions are paused
+type maintenanceWindow struct {
+	Start time.Time
+	End   time.Time
+}
+
+// parseMaintenanceWindows parses the maintenance windows listed in a
+// ConfigMap. Every line of every value is a `<start>/<end>` range of RFC3339
+// timestamps; blank lines and lines starting with # are ignored.
+func parseMaintenanceWindows(data map[string]string) ([]maintenanceWindow, []error) {
+	var windows []maintenanceWindow
+	var errs []error
+	for key, value := range data {
+		for _, line := range strings.Split(value, "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			window, err := parseMaintenanceWindow(line)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", key, err))
+				continue
+			}
+			windows = append(windows, window)
+		}
+	}
+	return windows, errs
+}
+
+func parseMaintenanceWindow(line string) (maintenanceWindow, error) {
+	startStr, endStr, ok := strings.Cut(line, "/")
+	if !ok {
+		return maintenanceWindow{}, fmt.Errorf("invalid maintenance window %q, expected <start>/<end>", line)
+	}
+	start, err := time.Parse(time.RFC3339, strings.TrimSpace(startStr))
+	if err != nil {
+		return maintenanceWindow{}, fmt.Errorf("invalid maintenance window start %q: %w", startStr, err)
+	}
+	end, err := time.Parse(time.RFC3339, strings.TrimSpace(endStr))
+	if err != nil {
+		return maintenanceWindow{}, fmt.Errorf("invalid maintenance window end %q: %w", endStr, err)
+	}
+	if !end.After(start) {
+		return maintenanceWindow{}, fmt.Errorf("maintenance window %q ends before it starts", line)
+	}
+	return maintenanceWindow{Start: start, End: end}, nil
+}
+
+// activeMaintenanceWindow reports whether a maintenance window listed in the
+// MaintenanceConfigMap is active at now, and when the latest active one ends.
+// A missing ConfigMap means no window is active.
+func (r *PodReconciler) activeMaintenanceWindow(ctx context.Context, now time.Time) (time.Time, bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, *r.MaintenanceConfigMap, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return time.Time{}, false, nil
+		}
+		return time.Time{}, false, err
+	}
+
+	windows, errs := parseMaintenanceWindows(cm.Data)
+	for _, err := range errs {
+		log.FromContext(ctx).Error(err, "ignoring invalid maintenance window", "configMap", *r.MaintenanceConfigMap)
+	}
+
+	var end time.Time
+	active := false
+	for _, w := range windows {
+		if !now.Before(w.Start) && now.Before(w.End) {
+			active = true
+			if w.End.After(end) {
+				end = w.End
+			}
+		}
+	}
+	return end, active, nil
+}
+
+// pauseForMaintenance defers a deletion while a maintenance window is
+// active. It reports whether it did, with the reconcile result to report;
+// every path that deletes pods checks it first.
+func (r *PodReconciler) pauseForMaintenance(ctx context.Context, pod *corev1.Pod) (ctrl.Result, string, bool, error) {
+	if r.MaintenanceConfigMap == nil {
+		return ctrl.Result{}, "", false, nil
+	}
+	logger := log.FromContext(ctx)
+	end, active, err := r.activeMaintenanceWindow(ctx, time.Now())
+	if err != nil {
+		logger.Error(err, "unable to read maintenance windows", "configMap", *r.MaintenanceConfigMap)
+		return ctrl.Result{}, metrics.ReconcileError, true,
+			fmt.Errorf("reading maintenance windows from %s: %w", *r.MaintenanceConfigMap, err)
+	}
+	r.Metrics.SetMaintenanceActive(active)
+	if !active {
+		return ctrl.Result{}, "", false, nil
+	}
+	requeueAfter := time.Until(end)
+	logger.Info("maintenance window active, requeuing", "pod", client.ObjectKeyFromObject(pod), "requeueAfter", requeueAfter)
+	r.Metrics.IncDeferred(metrics.DeferMaintenance)
+	return ctrl.Result{RequeueAfter: requeueAfter}, metrics.ReconcileRequeued, true, nil
+}
@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestParseMaintenanceWindows(t *testing.T) {
+	windows, errs := parseMaintenanceWindows(map[string]string{
+		"windows": `
+# weekly upgrade
+2026-01-10T02:00:00Z/2026-01-10T04:00:00Z
+2026-01-17T02:00:00+01:00 / 2026-01-17T04:00:00+01:00
+not-a-window
+2026-01-24T04:00:00Z/2026-01-24T02:00:00Z
+`,
+	})
+
+	if len(windows) != 2 {
+		t.Fatalf("parseMaintenanceWindows() returned %d windows, want 2: %v", len(windows), windows)
+	}
+	if len(errs) != 2 {
+		t.Errorf("parseMaintenanceWindows() returned %d errors, want 2: %v", len(errs), errs)
+	}
+	want := time.Date(2026, 1, 17, 1, 0, 0, 0, time.UTC)
+	if !windows[1].Start.Equal(want) {
+		t.Errorf("windows[1].Start = %v, want %v", windows[1].Start, want)
+	}
+}
+
+func TestPodReconciler_MaintenanceWindow(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	now := time.Now()
+	configMapKey := types.NamespacedName{Name: "reaper-maintenance", Namespace: "kube-system"}
+
+	inWindow := now.Add(-time.Hour).Format(time.RFC3339) + "/" + now.Add(time.Hour).Format(time.RFC3339)
+	pastWindow := now.Add(-2*time.Hour).Format(time.RFC3339) + "/" + now.Add(-time.Hour).Format(time.RFC3339)
+	evictedPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: now.Add(-10 * time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		window        string
+		pod           *corev1.Pod
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "in-window pod is requeued",
+			window:        inWindow,
+			pod:           evictedPod,
+			expectDeleted: false,
+			expectRequeue: true,
+		},
+		{
+			name:          "out-of-window pod is deleted",
+			window:        pastWindow,
+			pod:           evictedPod,
+			expectDeleted: true,
+			expectRequeue: false,
+		},
+		{
+			name:          "in-window unschedulable pod is requeued",
+			window:        inWindow,
+			pod:           unschedulablePod(2 * time.Hour),
+			expectDeleted: false,
+			expectRequeue: true,
+		},
+		{
+			name:          "out-of-window unschedulable pod is deleted",
+			window:        pastWindow,
+			pod:           unschedulablePod(2 * time.Hour),
+			expectDeleted: true,
+			expectRequeue: false,
+		},
+		{
+			name:          "in-window crashlooping pod is requeued",
+			window:        inWindow,
+			pod:           runningPod(crashLoopBackOffReason, 2*time.Hour),
+			expectDeleted: false,
+			expectRequeue: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := tt.pod.DeepCopy()
+			configMap := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      configMapKey.Name,
+					Namespace: configMapKey.Namespace,
+				},
+				Data: map[string]string{"windows": tt.window},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod, configMap).
+				Build()
+
+			registry := prometheus.NewRegistry()
+			podMetrics := metrics.NewPodMetrics()
+			podMetrics.Register(registry)
+			r := &PodReconciler{
+				Client:               fakeClient,
+				Scheme:               scheme,
+				Metrics:              podMetrics,
+				TTLToDelete:          300,
+				MaintenanceConfigMap: &configMapKey,
+				ReapUnschedulable:    true,
+				UnschedulableTTL:     3600,
+				ReapCrashLoop:        true,
+				CrashLoopDuration:    time.Hour,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+			deferred := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferMaintenance)
+			if (deferred == 1) != tt.expectRequeue {
+				t.Errorf("Expected maintenance deferrals = %v with expectRequeue %v", deferred, tt.expectRequeue)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
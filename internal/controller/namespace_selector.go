@@ -0,0 +1,111 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
+// namespaceSet tracks the namespaces currently matching NamespaceSelector
+type namespaceSet struct {
+	mu    sync.RWMutex
+	names map[string]bool
+}
+
+func (s *namespaceSet) has(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.names[name]
+}
+
+// set records whether a namespace matches, returning true if it didn't
+// before
+func (s *namespaceSet) set(name string, matches bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !matches {
+		delete(s.names, name)
+		return false
+	}
+	if s.names[name] {
+		return false
+	}
+	if s.names == nil {
+		s.names = make(map[string]bool)
+	}
+	s.names[name] = true
+	return true
+}
+
+// observeNamespace updates the selected namespaces from a namespace's
+// labels, returning true if it newly matches NamespaceSelector
+func (r *PodReconciler) observeNamespace(ctx context.Context, ns *corev1.Namespace) bool {
+	matches := ns.DeletionTimestamp.IsZero() && r.NamespaceSelector.Matches(labels.Set(ns.Labels))
+	if !r.selectedNamespaces.set(ns.Name, matches) {
+		return false
+	}
+	logger := log.FromContext(ctx)
+	if len(r.CachedNamespaces) > 0 && !slices.Contains(r.CachedNamespaces, ns.Name) {
+		// The cache can't grow at runtime
+		logger.Info("namespace matches the namespace selector but is not cached, "+
+			"add it to REAPER_WATCH_NAMESPACES and restart to reap its pods", "namespace", ns.Name)
+		return false
+	}
+	logger.Info("watching namespace matching the namespace selector", "namespace", ns.Name)
+	return true
+}
+
+// enqueueNamespacePods queues the candidate pods of a newly selected
+// namespace, which were ignored while it didn't match
+func (r *PodReconciler) enqueueNamespacePods(ctx context.Context, namespace string, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list pods of newly selected namespace", "namespace", namespace)
+		return
+	}
+	for i := range pods.Items {
+		if r.isCandidatePodPredicate(&pods.Items[i]) {
+			q.Add(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: pods.Items[i].Name}})
+		}
+	}
+}
+
+// namespaceHandler applies NamespaceSelector to namespaces created or
+// relabeled after startup, queuing the pods of newly selected ones
+func (r *PodReconciler) namespaceHandler() handler.TypedEventHandler[*corev1.Namespace, reconcile.Request] {
+	observe := func(ctx context.Context, ns *corev1.Namespace, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		if r.observeNamespace(ctx, ns) {
+			r.enqueueNamespacePods(ctx, ns.Name, q)
+		}
+	}
+	return handler.TypedFuncs[*corev1.Namespace, reconcile.Request]{
+		CreateFunc: func(ctx context.Context, e event.TypedCreateEvent[*corev1.Namespace], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			observe(ctx, e.Object, q)
+		},
+		UpdateFunc: func(ctx context.Context, e event.TypedUpdateEvent[*corev1.Namespace], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			observe(ctx, e.ObjectNew, q)
+		},
+		DeleteFunc: func(ctx context.Context, e event.TypedDeleteEvent[*corev1.Namespace], q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+			r.selectedNamespaces.set(e.Object.Name, false)
+		},
+	}
+}
+
+// namespaceSource watches namespaces for NamespaceSelector
+func (r *PodReconciler) namespaceSource(mgr ctrl.Manager) source.Source {
+	return source.Kind(mgr.GetCache(), &corev1.Namespace{}, r.namespaceHandler())
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNamespaceSelector_DynamicUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	evicted := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "evicted", Namespace: "payments"},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+	}
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "payments"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(evicted, running).
+		Build()
+
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           metrics.NewPodMetrics(),
+		NamespaceSelector: labels.SelectorFromSet(labels.Set{"reap": "true"}),
+	}
+	h := r.namespaceHandler()
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+	ctx := context.Background()
+
+	unlabeled := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments"}}
+	labeled := unlabeled.DeepCopy()
+	labeled.Labels = map[string]string{"reap": "true"}
+
+	// A namespace created without the label is not watched
+	h.Create(ctx, event.TypedCreateEvent[*corev1.Namespace]{Object: unlabeled}, q)
+	if r.isNamespaceWatched("payments") {
+		t.Error("Expected namespace without the label not to be watched")
+	}
+	if q.Len() != 0 {
+		t.Errorf("Expected no pods queued, got %d", q.Len())
+	}
+
+	// Labeling it later starts watching it and queues its evicted pods
+	h.Update(ctx, event.TypedUpdateEvent[*corev1.Namespace]{ObjectOld: unlabeled, ObjectNew: labeled}, q)
+	if !r.isNamespaceWatched("payments") {
+		t.Error("Expected labeled namespace to be watched")
+	}
+	if q.Len() != 1 {
+		t.Fatalf("Expected 1 pod queued, got %d", q.Len())
+	}
+	req, _ := q.Get()
+	if want := (types.NamespacedName{Namespace: "payments", Name: "evicted"}); req.NamespacedName != want {
+		t.Errorf("Expected %v queued, got %v", want, req.NamespacedName)
+	}
+	q.Done(req)
+
+	// Unrelated updates don't queue the pods again
+	h.Update(ctx, event.TypedUpdateEvent[*corev1.Namespace]{ObjectOld: labeled, ObjectNew: labeled}, q)
+	if q.Len() != 0 {
+		t.Errorf("Expected no pods queued, got %d", q.Len())
+	}
+
+	// Removing the label stops watching it
+	h.Update(ctx, event.TypedUpdateEvent[*corev1.Namespace]{ObjectOld: labeled, ObjectNew: unlabeled}, q)
+	if r.isNamespaceWatched("payments") {
+		t.Error("Expected namespace to stop being watched once unlabeled")
+	}
+
+	h.Create(ctx, event.TypedCreateEvent[*corev1.Namespace]{Object: labeled}, q)
+	h.Delete(ctx, event.TypedDeleteEvent[*corev1.Namespace]{Object: labeled}, q)
+	if r.isNamespaceWatched("payments") {
+		t.Error("Expected deleted namespace not to be watched")
+	}
+}
+
+func TestNamespaceSelector_ScopedCache(t *testing.T) {
+	r := &PodReconciler{
+		NamespaceSelector: labels.SelectorFromSet(labels.Set{"reap": "true"}),
+		CachedNamespaces:  []string{"default", "payments"},
+	}
+
+	tests := []struct {
+		name         string
+		namespace    string
+		expectQueued bool
+	}{
+		{
+			name:         "cached namespace",
+			namespace:    "payments",
+			expectQueued: true,
+		},
+		{
+			name:      "namespace outside the cache needs a restart",
+			namespace: "billing",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:   tt.namespace,
+				Labels: map[string]string{"reap": "true"},
+			}}
+			if queued := r.observeNamespace(context.Background(), ns); queued != tt.expectQueued {
+				t.Errorf("observeNamespace() = %v, want %v", queued, tt.expectQueued)
+			}
+			// Matching namespaces are selected either way, the cache alone
+			// decides whether their pods are seen
+			if !r.isNamespaceWatched(tt.namespace) {
+				t.Error("Expected matching namespace to be watched")
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+// ValidateNamespaces checks that each configured namespace exists, logging a
+// warning and flagging the missing-namespace gauge for any that don't. It
+// returns the namespaces that could not be found.
+func ValidateNamespaces(ctx context.Context, reader client.Reader, namespaces []string, m *metrics.PodMetrics) ([]string, error) {
+	logger := log.FromContext(ctx)
+
+	var missing []string
+	for _, name := range namespaces {
+		err := reader.Get(ctx, client.ObjectKey{Name: name}, &corev1.Namespace{})
+		switch {
+		case errors.IsNotFound(err):
+			logger.Info("configured namespace does not exist", "namespace", name)
+			m.SetNamespaceMissing(name, true)
+			missing = append(missing, name)
+		case err != nil:
+			return missing, err
+		default:
+			m.SetNamespaceMissing(name, false)
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestValidateNamespaces(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "monitoring"}},
+		).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	missing, err := ValidateNamespaces(context.Background(), fakeClient,
+		[]string{"default", "monitroing", "monitoring", "kube-sytem"}, podMetrics)
+	if err != nil {
+		t.Fatalf("ValidateNamespaces() error = %v", err)
+	}
+
+	wantMissing := []string{"monitroing", "kube-sytem"}
+	if len(missing) != len(wantMissing) {
+		t.Fatalf("ValidateNamespaces() missing = %v, want %v", missing, wantMissing)
+	}
+	for i, ns := range wantMissing {
+		if missing[i] != ns {
+			t.Errorf("ValidateNamespaces() missing[%d] = %q, want %q", i, missing[i], ns)
+		}
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	gauges := map[string]float64{}
+	for _, mf := range mfs {
+		if mf.GetName() != "reaper_configured_namespace_missing" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			gauges[m.GetLabel()[0].GetValue()] = m.GetGauge().GetValue()
+		}
+	}
+
+	want := map[string]float64{
+		"default":    0,
+		"monitoring": 0,
+		"monitroing": 1,
+		"kube-sytem": 1,
+	}
+	for ns, value := range want {
+		got, ok := gauges[ns]
+		if !ok {
+			t.Errorf("reaper_configured_namespace_missing{namespace=%q} not reported", ns)
+			continue
+		}
+		if got != value {
+			t.Errorf("reaper_configured_namespace_missing{namespace=%q} = %v, want %v", ns, got, value)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=nodes,verbs=get
+
+// nodeNotReadyRequeueAfter is how long to wait before checking again
+// whether a pod's node has recovered
+const nodeNotReadyRequeueAfter = time.Minute
+
+// isNodeReady reports whether the node a pod ran on is Ready. Pods that were
+// never scheduled, or whose node is gone, count as ready as there is nothing
+// left to wait for.
+func (r *PodReconciler) isNodeReady(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	if pod.Spec.NodeName == "" {
+		return true, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_WaitForNodeReady(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	node := func(status corev1.ConditionStatus) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-a"},
+			Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse},
+				{Type: corev1.NodeReady, Status: status},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		nodeName      string
+		node          *corev1.Node
+		getErr        error
+		disabled      bool
+		expectDeleted bool
+		expectRequeue bool
+		expectError   bool
+	}{
+		{name: "ready node", nodeName: "node-a", node: node(corev1.ConditionTrue), expectDeleted: true},
+		{name: "not ready node", nodeName: "node-a", node: node(corev1.ConditionFalse), expectRequeue: true},
+		{name: "unknown node status", nodeName: "node-a", node: node(corev1.ConditionUnknown), expectRequeue: true},
+		{
+			name:          "node without a Ready condition",
+			nodeName:      "node-a",
+			node:          &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+			expectRequeue: true,
+		},
+		{name: "deleted node", nodeName: "node-a", expectDeleted: true},
+		{name: "pod never scheduled", expectDeleted: true},
+		{name: "node lookup fails", nodeName: "node-a", getErr: errors.New("boom"), expectError: true},
+		{name: "disabled", nodeName: "node-a", node: node(corev1.ConditionFalse), disabled: true, expectDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+				Spec:       corev1.PodSpec{NodeName: tt.nodeName, Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			objs := []runtime.Object{pod}
+			if tt.node != nil {
+				objs = append(objs, tt.node)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(objs...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if _, ok := obj.(*corev1.Node); ok && tt.getErr != nil {
+							return tt.getErr
+						}
+						return c.Get(ctx, key, obj, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Metrics:          podMetrics,
+				TTLToDelete:      300,
+				WaitForNodeReady: !tt.disabled,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Reconcile() error = %v, expectError %v", err, tt.expectError)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			if requeued := result.RequeueAfter == nodeNotReadyRequeueAfter; requeued != tt.expectRequeue {
+				t.Errorf("Expected requeue=%v, got RequeueAfter %v", tt.expectRequeue, result.RequeueAfter)
+			}
+			want := 0.0
+			if tt.expectRequeue {
+				want = 1
+			}
+			if got := gatherCounter(t, registry, "reaper_node_not_ready_requeues_total", "node", "node-a"); got != want {
+				t.Errorf("reaper_node_not_ready_requeues_total = %v, want %v", got, want)
+			}
+		})
+	}
+}
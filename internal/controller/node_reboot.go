@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// postRebootRequeueAfter is how long to wait before checking again whether
+// a pod's node has rebooted since the pod was created
+const postRebootRequeueAfter = 10 * time.Minute
+
+// nodeBootTime returns when a node last came up: the later of its creation
+// and its Ready condition last turning true, which the kubelet resets on
+// every restart
+func nodeBootTime(node *corev1.Node) time.Time {
+	boot := node.CreationTimestamp.Time
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady && cond.Status == corev1.ConditionTrue && cond.LastTransitionTime.After(boot) {
+			boot = cond.LastTransitionTime.Time
+		}
+	}
+	return boot
+}
+
+// predatesNodeBoot reports whether a pod was created before the node it ran
+// on last came up. Pods that were never scheduled, or whose node is gone,
+// count as predating it as no fresh eviction can come from them.
+func (r *PodReconciler) predatesNodeBoot(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	if pod.Spec.NodeName == "" {
+		return true, nil
+	}
+
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return pod.CreationTimestamp.Time.Before(nodeBootTime(node)), nil
+}
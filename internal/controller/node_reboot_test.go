@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNodeBootTime(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rebooted := created.Add(48 * time.Hour)
+
+	tests := []struct {
+		name       string
+		conditions []corev1.NodeCondition
+		want       time.Time
+	}{
+		{name: "no conditions", want: created},
+		{
+			name: "ready since a reboot",
+			conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeMemoryPressure, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(rebooted.Add(time.Hour))},
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(rebooted)},
+			},
+			want: rebooted,
+		},
+		{
+			name: "not ready",
+			conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionFalse, LastTransitionTime: metav1.NewTime(rebooted)},
+			},
+			want: created,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node := &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: "node-a", CreationTimestamp: metav1.NewTime(created)},
+				Status:     corev1.NodeStatus{Conditions: tt.conditions},
+			}
+			if got := nodeBootTime(node); !got.Equal(tt.want) {
+				t.Errorf("nodeBootTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_ReapOnlyPreReboot(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	rebooted := time.Now().Add(-time.Hour)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-a", CreationTimestamp: metav1.NewTime(rebooted.Add(-30 * 24 * time.Hour))},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastTransitionTime: metav1.NewTime(rebooted)},
+		}},
+	}
+
+	tests := []struct {
+		name          string
+		podCreated    time.Time
+		nodeName      string
+		node          *corev1.Node
+		disabled      bool
+		expectDeleted bool
+	}{
+		{name: "pod predates the reboot", podCreated: rebooted.Add(-time.Hour), nodeName: "node-a", node: node, expectDeleted: true},
+		{name: "pod created after the reboot", podCreated: rebooted.Add(10 * time.Minute), nodeName: "node-a", node: node},
+		{name: "deleted node", podCreated: rebooted.Add(10 * time.Minute), nodeName: "node-a", expectDeleted: true},
+		{name: "pod never scheduled", podCreated: rebooted.Add(10 * time.Minute), expectDeleted: true},
+		{name: "disabled", podCreated: rebooted.Add(10 * time.Minute), nodeName: "node-a", node: node, disabled: true, expectDeleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-pod",
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(tt.podCreated),
+				},
+				Spec: corev1.PodSpec{NodeName: tt.nodeName, Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: tt.podCreated},
+				},
+			}
+			objs := []runtime.Object{pod}
+			if tt.node != nil {
+				objs = append(objs, tt.node)
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				TTLToDelete:       300,
+				ReapOnlyPreReboot: !tt.disabled,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			// Newer pods are checked again, the node may reboot meanwhile
+			want, wantRequeue := 1.0, postRebootRequeueAfter
+			if tt.expectDeleted {
+				want, wantRequeue = 0, 0
+			}
+			if result.RequeueAfter != wantRequeue {
+				t.Errorf("RequeueAfter = %v, want %v", result.RequeueAfter, wantRequeue)
+			}
+			if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferPostReboot); got != want {
+				t.Errorf("reaper_deferred_total{reason=%q} = %v, want %v", metrics.DeferPostReboot, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=configmaps;secrets,verbs=list;delete
+
+// podObjectRefs returns the names of the ConfigMaps and Secrets a pod
+// references through its volumes, environment and image pull secrets
+func podObjectRefs(pod *corev1.Pod) (configMaps, secrets sets.Set[string]) {
+	configMaps, secrets = sets.New[string](), sets.New[string]()
+	for _, volume := range pod.Spec.Volumes {
+		if volume.ConfigMap != nil {
+			configMaps.Insert(volume.ConfigMap.Name)
+		}
+		if volume.Secret != nil {
+			secrets.Insert(volume.Secret.SecretName)
+		}
+		if volume.Projected != nil {
+			for _, source := range volume.Projected.Sources {
+				if source.ConfigMap != nil {
+					configMaps.Insert(source.ConfigMap.Name)
+				}
+				if source.Secret != nil {
+					secrets.Insert(source.Secret.Name)
+				}
+			}
+		}
+	}
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		for _, from := range container.EnvFrom {
+			if from.ConfigMapRef != nil {
+				configMaps.Insert(from.ConfigMapRef.Name)
+			}
+			if from.SecretRef != nil {
+				secrets.Insert(from.SecretRef.Name)
+			}
+		}
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				configMaps.Insert(env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+			if env.ValueFrom.SecretKeyRef != nil {
+				secrets.Insert(env.ValueFrom.SecretKeyRef.Name)
+			}
+		}
+	}
+	for _, ref := range pod.Spec.ImagePullSecrets {
+		secrets.Insert(ref.Name)
+	}
+	return configMaps, secrets
+}
+
+// isOwnedByPod checks if an object has an owner reference to a pod
+func isOwnedByPod(obj client.Object, pod *corev1.Pod) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == pod.UID {
+			return true
+		}
+	}
+	return false
+}
+
+// deleteOrphanedPodObjects deletes the ConfigMaps and Secrets a reaped pod
+// referenced or owned, that match OrphanedObjectSelector and that no other
+// pod references anymore, returning how many it deleted
+func (r *PodReconciler) deleteOrphanedPodObjects(ctx context.Context, reaped *corev1.Pod) (int, error) {
+	reapedConfigMaps, reapedSecrets := podObjectRefs(reaped)
+
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(reaped.Namespace)); err != nil {
+		return 0, fmt.Errorf("listing pods in namespace %s: %w", reaped.Namespace, err)
+	}
+	referencedConfigMaps, referencedSecrets := sets.New[string](), sets.New[string]()
+	for i := range pods.Items {
+		// The reaped pod may linger in the cache or behind a finalizer
+		if pods.Items[i].UID == reaped.UID {
+			continue
+		}
+		configMaps, secrets := podObjectRefs(&pods.Items[i])
+		referencedConfigMaps = referencedConfigMaps.Union(configMaps)
+		referencedSecrets = referencedSecrets.Union(secrets)
+	}
+
+	reader := r.apiReader()
+	listOpts := []client.ListOption{
+		client.InNamespace(reaped.Namespace),
+		client.MatchingLabelsSelector{Selector: r.OrphanedObjectSelector},
+	}
+	configMaps := &corev1.ConfigMapList{}
+	if err := reader.List(ctx, configMaps, listOpts...); err != nil {
+		return 0, fmt.Errorf("listing ConfigMaps in namespace %s: %w", reaped.Namespace, err)
+	}
+	secrets := &corev1.SecretList{}
+	if err := reader.List(ctx, secrets, listOpts...); err != nil {
+		return 0, fmt.Errorf("listing Secrets in namespace %s: %w", reaped.Namespace, err)
+	}
+
+	// One failed deletion doesn't stop the others
+	var deleted int
+	var errs []error
+	deleteOrphan := func(kind string, obj client.Object) {
+		if err := r.Delete(ctx, obj); err != nil && !errors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("deleting %s %s/%s: %w", kind, obj.GetNamespace(), obj.GetName(), err))
+			return
+		}
+		deleted++
+	}
+	for i := range configMaps.Items {
+		cm := &configMaps.Items[i]
+		if (reapedConfigMaps.Has(cm.Name) || isOwnedByPod(cm, reaped)) && !referencedConfigMaps.Has(cm.Name) {
+			deleteOrphan("ConfigMap", cm)
+		}
+	}
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if (reapedSecrets.Has(secret.Name) || isOwnedByPod(secret, reaped)) && !referencedSecrets.Has(secret.Name) {
+			deleteOrphan("Secret", secret)
+		}
+	}
+	return deleted, utilerrors.NewAggregate(errs)
+}
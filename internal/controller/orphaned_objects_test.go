@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_DeleteOrphanedPodObjects(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	perPod := map[string]string{"per-pod": "true"}
+	objects := func() []runtime.Object {
+		return []runtime.Object{
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "evicted", Namespace: "default", UID: "evicted"},
+				Spec: corev1.PodSpec{
+					Volumes: []corev1.Volume{{
+						Name:         "config",
+						VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: "evicted-config"}}},
+					}},
+					// Shared with the running pod
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "running-secret"}},
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				},
+			},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default", UID: "running"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    "app",
+						EnvFrom: []corev1.EnvFromSource{{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "running-config"}}}},
+						Env: []corev1.EnvVar{{
+							Name:      "TOKEN",
+							ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "running-secret"}, Key: "token"}},
+						}},
+					}},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "evicted-config", Namespace: "default", Labels: perPod}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "running-config", Namespace: "default", Labels: perPod}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unlabeled", Namespace: "default"}},
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "elsewhere", Namespace: "other", Labels: perPod}},
+			// Owned by the evicted pod without it referencing it
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+				Name:            "evicted-secret",
+				Namespace:       "default",
+				Labels:          perPod,
+				OwnerReferences: []metav1.OwnerReference{{APIVersion: "v1", Kind: "Pod", Name: "evicted", UID: "evicted"}},
+			}},
+			// Matches the selector, but the evicted pod never used it
+			&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unreferenced-config", Namespace: "default", Labels: perPod}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unreferenced-secret", Namespace: "default", Labels: perPod}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "running-secret", Namespace: "default", Labels: perPod}},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		selector        labels.Selector
+		failConfigMaps  bool
+		expectRemaining []string
+	}{
+		{
+			name:            "orphaned objects are deleted, referenced ones kept",
+			selector:        labels.SelectorFromSet(perPod),
+			expectRemaining: []string{"running-config", "unlabeled", "elsewhere", "unreferenced-config", "running-secret", "unreferenced-secret"},
+		},
+		{
+			name:            "a failed deletion doesn't stop the others",
+			selector:        labels.SelectorFromSet(perPod),
+			failConfigMaps:  true,
+			expectRemaining: []string{"evicted-config", "running-config", "unlabeled", "elsewhere", "unreferenced-config", "running-secret", "unreferenced-secret"},
+		},
+		{
+			name:            "nothing is deleted without a selector",
+			expectRemaining: []string{"evicted-config", "running-config", "unlabeled", "elsewhere", "unreferenced-config", "evicted-secret", "running-secret", "unreferenced-secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(objects()...).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if _, ok := obj.(*corev1.ConfigMap); ok && tt.failConfigMaps {
+							return errors.New("boom")
+						}
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			r := &PodReconciler{
+				Client:                 fakeClient,
+				Scheme:                 scheme,
+				Metrics:                metrics.NewPodMetrics(),
+				TTLToDelete:            300,
+				OrphanedObjectSelector: tt.selector,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "evicted", Namespace: "default"}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+				t.Error("Expected the evicted pod to be deleted")
+			}
+
+			remaining := map[string]bool{}
+			configMaps := &corev1.ConfigMapList{}
+			secrets := &corev1.SecretList{}
+			if err := fakeClient.List(context.Background(), configMaps); err != nil {
+				t.Fatalf("Failed to list ConfigMaps: %v", err)
+			}
+			if err := fakeClient.List(context.Background(), secrets); err != nil {
+				t.Fatalf("Failed to list Secrets: %v", err)
+			}
+			for _, cm := range configMaps.Items {
+				remaining[cm.Name] = true
+			}
+			for _, secret := range secrets.Items {
+				remaining[secret.Name] = true
+			}
+			if len(remaining) != len(tt.expectRemaining) {
+				t.Errorf("Expected %v to remain, got %v", tt.expectRemaining, remaining)
+			}
+			for _, name := range tt.expectRemaining {
+				if !remaining[name] {
+					t.Errorf("Expected %s to remain, got %v", name, remaining)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// defaultOwnerResolutionDepth is enough for Pod → ReplicaSet → Deployment
+	// and Pod → Job → CronJob chains, with room to spare.
+	defaultOwnerResolutionDepth = 5
+)
+
+//+kubebuilder:rbac:groups=apps,resources=replicasets;deployments;statefulsets;daemonsets,verbs=get
+//+kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get
+
+// resolveTopOwner walks the owner chain of a pod and returns the kind and name
+// of the top-most owner that could be resolved. Ownerless pods return empty
+// strings. If an owner in the chain cannot be fetched, the last known
+// reference is returned.
+func (r *PodReconciler) resolveTopOwner(ctx context.Context, pod *corev1.Pod) (kind, name string) {
+	logger := log.FromContext(ctx)
+
+	ref := ownerRef(pod.OwnerReferences)
+	if ref == nil {
+		return "", ""
+	}
+
+	maxDepth := r.OwnerResolutionDepth
+	if maxDepth <= 0 {
+		maxDepth = defaultOwnerResolutionDepth
+	}
+
+	visited := map[types.UID]bool{pod.UID: true}
+	for depth := 1; ; depth++ {
+		kind, name = ref.Kind, ref.Name
+		if depth >= maxDepth || visited[ref.UID] {
+			return kind, name
+		}
+		visited[ref.UID] = true
+
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion(ref.APIVersion)
+		owner.SetKind(ref.Kind)
+		key := client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}
+		if err := r.Get(ctx, key, owner); err != nil {
+			logger.V(1).Info("unable to resolve owner, stopping owner chain resolution",
+				"kind", ref.Kind, "name", ref.Name, "error", err.Error())
+			return kind, name
+		}
+
+		ref = ownerRef(owner.GetOwnerReferences())
+		if ref == nil {
+			return kind, name
+		}
+	}
+}
+
+// ownerKindLabel returns the owner kind label of a reaped pod, None for
+// ownerless pods
+func ownerKindLabel(kind string) string {
+	if kind == "" {
+		return "None"
+	}
+	return kind
+}
+
+// ownerRef returns the controlling owner reference if present, otherwise the
+// first owner reference, or nil if there are none.
+func ownerRef(refs []metav1.OwnerReference) *metav1.OwnerReference {
+	if len(refs) == 0 {
+		return nil
+	}
+	for i := range refs {
+		if refs[i].Controller != nil && *refs[i].Controller {
+			return &refs[i]
+		}
+	}
+	return &refs[0]
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ownerObservedRequeueAfter is how long to wait before checking again
+// whether a pod's owner has observed its failure
+const ownerObservedRequeueAfter = 15 * time.Second
+
+// hasOwnerObserved reports whether the ReplicaSet controlling a pod has
+// observed it failing: its status is up to date with its spec, and it has
+// replaced the pod so it runs as many active pods as it wants. Pods of
+// Deployments are checked against their ReplicaSet. Pods with any other
+// owner, or whose ReplicaSet is gone, count as observed.
+func (r *PodReconciler) hasOwnerObserved(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	ref := ownerRef(pod.OwnerReferences)
+	if ref == nil || ref.Kind != "ReplicaSet" {
+		return true, nil
+	}
+
+	rs := &appsv1.ReplicaSet{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: pod.Namespace, Name: ref.Name}, rs); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if rs.UID != ref.UID {
+		// Replaced by a ReplicaSet of the same name
+		return true, nil
+	}
+	if rs.Status.ObservedGeneration < rs.Generation {
+		return false, nil
+	}
+
+	desired := int32(1)
+	if rs.Spec.Replicas != nil {
+		desired = *rs.Spec.Replicas
+	}
+	if desired == 0 {
+		return true, nil
+	}
+	active, err := r.activeReplicas(ctx, rs)
+	if err != nil {
+		return false, err
+	}
+	return active >= desired, nil
+}
+
+// activeReplicas counts the pods controlled by a ReplicaSet that are neither
+// finished nor being deleted
+func (r *PodReconciler) activeReplicas(ctx context.Context, rs *appsv1.ReplicaSet) (int32, error) {
+	selector, err := metav1.LabelSelectorAsSelector(rs.Spec.Selector)
+	if err != nil {
+		return 0, err
+	}
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods, client.InNamespace(rs.Namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return 0, err
+	}
+
+	var active int32
+	for i := range pods.Items {
+		p := &pods.Items[i]
+		if ref := metav1.GetControllerOf(p); ref == nil || ref.UID != rs.UID {
+			continue
+		}
+		if p.DeletionTimestamp != nil || p.Status.Phase == corev1.PodFailed || p.Status.Phase == corev1.PodSucceeded {
+			continue
+		}
+		active++
+	}
+	return active, nil
+}
@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_WaitForOwnerObserved(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	labels := map[string]string{"app": "web"}
+	replicaSet := func(generation, observedGeneration int64) *appsv1.ReplicaSet {
+		replicas := int32(2)
+		return &appsv1.ReplicaSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "web-abc",
+				Namespace:  "default",
+				UID:        "rs-uid",
+				Generation: generation,
+			},
+			Spec: appsv1.ReplicaSetSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: labels},
+			},
+			Status: appsv1.ReplicaSetStatus{ObservedGeneration: observedGeneration},
+		}
+	}
+	running := func(n int) []runtime.Object {
+		var pods []runtime.Object
+		for i := 0; i < n; i++ {
+			pods = append(pods, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            fmt.Sprintf("web-abc-%d", i),
+					Namespace:       "default",
+					Labels:          labels,
+					OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web-abc", "rs-uid")},
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodRunning},
+			})
+		}
+		return pods
+	}
+
+	tests := []struct {
+		name          string
+		objects       []runtime.Object
+		expectDeleted bool
+	}{
+		{
+			name:          "owner replaced the pod",
+			objects:       append(running(2), replicaSet(1, 1)),
+			expectDeleted: true,
+		},
+		{
+			name:    "owner has not replaced the pod",
+			objects: append(running(1), replicaSet(1, 1)),
+		},
+		{
+			name:    "owner status is out of date",
+			objects: append(running(2), replicaSet(2, 1)),
+		},
+		{
+			name:          "owner is gone",
+			objects:       running(1),
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "web-abc-evicted",
+					Namespace:       "default",
+					Labels:          labels,
+					OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web-abc", "rs-uid")},
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(append(tt.objects, pod)...).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:               fakeClient,
+				Scheme:               scheme,
+				Metrics:              podMetrics,
+				TTLToDelete:          300,
+				AllowedNamespaces:    []string{"default"},
+				WaitForOwnerObserved: true,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			if !tt.expectDeleted && result.RequeueAfter != ownerObservedRequeueAfter {
+				t.Errorf("Expected requeue after %v, got %v", ownerObservedRequeueAfter, result.RequeueAfter)
+			}
+			wantDeferred := 0.0
+			if !tt.expectDeleted {
+				wantDeferred = 1
+			}
+			if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferOwnerObserved); got != wantDeferred {
+				t.Errorf("reaper_deferred_total{reason=%q} = %v, want %v", metrics.DeferOwnerObserved, got, wantDeferred)
+			}
+		})
+	}
+}
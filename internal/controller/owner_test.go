@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func controllerRef(apiVersion, kind, name string, uid types.UID) metav1.OwnerReference {
+	isController := true
+	return metav1.OwnerReference{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+		UID:        uid,
+		Controller: &isController,
+	}
+}
+
+func TestPodReconciler_resolveTopOwner(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			UID:       "deployment-uid",
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "web-abc123",
+			Namespace:       "default",
+			UID:             "replicaset-uid",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "Deployment", "web", "deployment-uid")},
+		},
+	}
+	// cyclic-a and cyclic-b own each other
+	cyclicA := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cyclic-a",
+			Namespace:       "default",
+			UID:             "cyclic-a-uid",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "cyclic-b", "cyclic-b-uid")},
+		},
+	}
+	cyclicB := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "cyclic-b",
+			Namespace:       "default",
+			UID:             "cyclic-b-uid",
+			OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "cyclic-a", "cyclic-a-uid")},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		owners   []metav1.OwnerReference
+		maxDepth int
+		wantKind string
+		wantName string
+	}{
+		{
+			name:     "ownerless pod",
+			owners:   nil,
+			wantKind: "",
+			wantName: "",
+		},
+		{
+			name:     "two-level chain resolves to deployment",
+			owners:   []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web-abc123", "replicaset-uid")},
+			wantKind: "Deployment",
+			wantName: "web",
+		},
+		{
+			name:     "max depth stops at immediate owner",
+			owners:   []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web-abc123", "replicaset-uid")},
+			maxDepth: 1,
+			wantKind: "ReplicaSet",
+			wantName: "web-abc123",
+		},
+		{
+			name:     "missing owner returns last known reference",
+			owners:   []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "gone", "gone-uid")},
+			wantKind: "ReplicaSet",
+			wantName: "gone",
+		},
+		{
+			name:     "owner cycle terminates",
+			owners:   []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "cyclic-a", "cyclic-a-uid")},
+			wantKind: "ReplicaSet",
+			wantName: "cyclic-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(deployment, replicaSet, cyclicA, cyclicB).
+				Build()
+
+			r := &PodReconciler{
+				Client:               fakeClient,
+				Scheme:               scheme,
+				OwnerResolutionDepth: tt.maxDepth,
+			}
+
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-pod",
+					Namespace:       "default",
+					UID:             "pod-uid",
+					OwnerReferences: tt.owners,
+				},
+			}
+
+			kind, name := r.resolveTopOwner(context.Background(), pod)
+			if kind != tt.wantKind || name != tt.wantName {
+				t.Errorf("resolveTopOwner() = (%q, %q), want (%q, %q)", kind, name, tt.wantKind, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_DeletedByOwnerKind(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newPod := func(name string, owners ...metav1.OwnerReference) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       "default",
+				OwnerReferences: owners,
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			},
+		}
+	}
+	// The ReplicaSet is gone, so its pod stops at it
+	pods := []*corev1.Pod{
+		newPod("web-abc123-xyz", controllerRef("apps/v1", "ReplicaSet", "web-abc123", "rs-uid")),
+		newPod("batch-1", controllerRef("batch/v1", "Job", "batch", "job-uid")),
+		newPod("batch-2", controllerRef("batch/v1", "Job", "batch", "job-uid")),
+		newPod("standalone"),
+	}
+
+	for _, enabled := range []bool{true, false} {
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithRuntimeObjects(pods[0].DeepCopy(), pods[1].DeepCopy(), pods[2].DeepCopy(), pods[3].DeepCopy()).
+			Build()
+
+		registry := prometheus.NewRegistry()
+		podMetrics := metrics.NewPodMetrics()
+		podMetrics.Register(registry)
+
+		r := &PodReconciler{
+			Client:          fakeClient,
+			Scheme:          scheme,
+			Metrics:         podMetrics,
+			TTLToDelete:     300,
+			CountOwnerKinds: enabled,
+		}
+
+		for _, pod := range pods {
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+		}
+
+		want := map[string]float64{"ReplicaSet": 1, "Job": 2, "None": 1}
+		for kind, count := range want {
+			if !enabled {
+				count = 0
+			}
+			if got := gatherCounter(t, registry, "reaper_deleted_by_owner_kind_total", "kind", kind); got != count {
+				t.Errorf("enabled %v: expected %v deletions for kind %s, got %v", enabled, count, kind, got)
+			}
+		}
+	}
+}
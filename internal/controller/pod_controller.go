@@ -2,20 +2,90 @@ package controller
 
 import (
 	"context"
+	stderrors "errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/archive"
 	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/notify"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Modes deciding what happens to pods eligible for reaping
+const (
+	// ModeDelete deletes eligible pods
+	ModeDelete = "delete"
+	// ModeQuarantine labels eligible pods for manual review and never
+	// deletes them
+	ModeQuarantine = "quarantine"
+)
+
+// Policies for evicted pods without an owner
+const (
+	// StandalonePolicyTTL deletes ownerless pods after the TTL, like any other pod
+	StandalonePolicyTTL = "ttl"
+	// StandalonePolicyReap deletes ownerless pods without waiting for the TTL
+	StandalonePolicyReap = "reap"
+	// StandalonePolicyPreserve never deletes ownerless pods
+	StandalonePolicyPreserve = "preserve"
+)
+
+// Policies deciding eviction from container statuses instead of the pod phase
+const (
+	// EvictionContainerPolicyAll requires every container to have terminated
+	EvictionContainerPolicyAll = "all"
+	// EvictionContainerPolicyAny requires at least one terminated container
+	EvictionContainerPolicyAny = "any"
+)
+
+// Policies for pods whose StartTime is in the future
+const (
+	// FutureStartTimePolicyZero treats the pod as just started, requeuing it
+	// for the full TTL
+	FutureStartTimePolicyZero = "zero"
+	// FutureStartTimePolicyCreation measures the age from the pod's
+	// creationTimestamp instead
+	FutureStartTimePolicyCreation = "creation"
 )
 
 const (
 	preserveAnnotation = "pod-reaper.kyos.com/preserve"
+	// reapedAnnotation marks a pod the reaper has started deleting, so a pod
+	// seen again while terminating isn't counted twice
+	reapedAnnotation = "pod-reaper.kyos.com/reaped"
+	// reasonMatchAnnotation lists the failure reasons that make a pod
+	// eligible, replacing the default of Evicted for that pod
+	reasonMatchAnnotation = "pod-reaper.kyos.com/reason-match"
+
+	evictedReason = "Evicted"
+
+	// maxPodAge clamps pod ages computed from nonsensical StartTimes
+	maxPodAge = 10 * 365 * 24 * time.Hour
+
+	// futureStartTimeTolerance is how far in the future a StartTime may be,
+	// e.g. from clock skew, before it is handled by FutureStartTimePolicy
+	futureStartTimeTolerance = 30 * time.Second
+
+	// observationConfirmDelay is how long to wait before confirming a first
+	// eligible observation
+	observationConfirmDelay = 10 * time.Second
 )
 
 // PodReconciler reconciles a Pod object
@@ -24,63 +94,823 @@ type PodReconciler struct {
 	Scheme      *runtime.Scheme
 	Metrics     *metrics.PodMetrics
 	TTLToDelete int // seconds to wait before deletion
+
+	// NamespaceTTLs overrides TTLToDelete for individual namespaces
+	NamespaceTTLs map[string]int
+
+	// ReasonTTLs overrides TTLToDelete and NamespaceTTLs for pods failed
+	// with a given status reason
+	ReasonTTLs map[string]int
+
+	// LabelTTLs makes Succeeded and Failed pods matching one of these label
+	// rules candidates, with the rule's TTL. The first matching rule wins,
+	// and overrides ReasonTTLs.
+	LabelTTLs []LabelTTL
+
+	// OwnedTTL and OrphanTTL, if set, override TTLToDelete for pods with
+	// and without owner references. Reason and namespace TTLs still take
+	// precedence.
+	OwnedTTL  *int
+	OrphanTTL *int
+
+	// SafeMode restricts deletions to AllowedNamespaces, regardless of
+	// which namespaces are being watched.
+	SafeMode          bool
+	AllowedNamespaces []string
+
+	// NamespacePrefix, if set, limits reconciles to namespaces starting with
+	// it or listed in WatchNamespaces, for use with a watch-all cache
+	NamespacePrefix string
+	WatchNamespaces []string
+
+	// NamespaceSelector, if set, further limits reconciles to namespaces
+	// whose labels match it. Namespaces are watched, so ones created or
+	// relabeled later are picked up without a restart as long as the cache
+	// covers them.
+	NamespaceSelector  labels.Selector
+	selectedNamespaces namespaceSet
+	// OrphanedObjectSelector, if set, identifies the per-pod ConfigMaps and
+	// Secrets some controllers create. After reaping a pod, the ones in its
+	// namespace that no remaining pod references are deleted too.
+	OrphanedObjectSelector labels.Selector
+	// APIReader reads objects the manager doesn't cache. Nil falls back to
+	// Client.
+	APIReader client.Reader
+	// CachedNamespaces lists the namespaces the cache is scoped to, empty
+	// when it covers all namespaces
+	CachedNamespaces []string
+
+	// OwnerResolutionDepth bounds how many owner references are followed
+	// when resolving a pod's top-level owner.
+	OwnerResolutionDepth int
+
+	// TransitionUpdatesOnly limits update events to pods transitioning
+	// into the evicted state.
+	TransitionUpdatesOnly bool
+
+	// UseJobTTL delegates cleanup of Job-owned pods to the owning Job by
+	// setting its ttlSecondsAfterFinished instead of deleting the pod.
+	UseJobTTL                  bool
+	JobTTLSecondsAfterFinished int32
+
+	// PriorityClassFilter limits reaping to pods with one of these priority
+	// class names. MaxPriority limits reaping to pods with a lower priority.
+	PriorityClassFilter []string
+	MaxPriority         *int32
+
+	// ContainerTerminationReasons limits reaping to pods with a container
+	// that terminated with one of these reasons, such as Error
+	ContainerTerminationReasons []string
+
+	// PreDeleteHook, if set, runs before each deletion and can veto it
+	PreDeleteHook *PreDeleteHook
+
+	// Notifier, if set, is told about every reaped pod
+	Notifier *notify.Notifier
+
+	// Archiver, if set, uploads the manifest of each evicted pod before it
+	// is deleted. Pods whose manifest can't be uploaded are not deleted.
+	Archiver *archive.Archiver
+
+	// CountOwnerKinds counts reaped pods per kind of their top-level owner
+	CountOwnerKinds bool
+
+	// FieldManager, if set, is the field manager the reaper's writes are
+	// recorded under in managed fields, instead of the client's default
+	FieldManager string
+
+	// AuditAnnotations lists pod annotations echoed into the deletion log
+	// line and notification of every reaped pod
+	AuditAnnotations []string
+
+	// WaitForOwnerObserved only deletes pods once their owning ReplicaSet
+	// has observed their failure and replaced them
+	WaitForOwnerObserved bool
+
+	// WaitForNodeReady only deletes pods once the node they ran on is Ready
+	// again, so pods evicted under node pressure are kept during the incident
+	WaitForNodeReady bool
+
+	// ReapOnlyPreReboot only deletes pods created before the node they ran
+	// on last came up, keeping fresh evictions around for inspection
+	ReapOnlyPreReboot bool
+
+	// WaitForLogsShipped only deletes pods annotated as having their logs
+	// shipped, or once LogsShippedTimeout has passed since their TTL
+	// expired. A zero timeout waits for the annotation indefinitely.
+	WaitForLogsShipped bool
+	LogsShippedTimeout time.Duration
+
+	// RequireConsecutiveObservations requires a pod to be seen eligible on
+	// two separate reconciles before it is deleted
+	RequireConsecutiveObservations bool
+
+	// ReapUnschedulable also reaps Pending pods that have been unschedulable
+	// for longer than UnschedulableTTL seconds
+	ReapUnschedulable bool
+	UnschedulableTTL  int
+
+	// ReapCrashLoop also reaps Running pods whose owner is gone and that have
+	// been in CrashLoopBackOff for longer than CrashLoopDuration
+	ReapCrashLoop     bool
+	CrashLoopDuration time.Duration
+
+	// UseEvictionAPI removes pods through the eviction API instead of
+	// deleting them directly
+	UseEvictionAPI bool
+
+	// Mode decides what happens to eligible pods. Empty means ModeDelete.
+	Mode string
+
+	// StandalonePolicy controls how evicted pods without an owner are
+	// handled. Empty means StandalonePolicyTTL.
+	StandalonePolicy string
+
+	// EvictionContainerPolicy, if set, decides whether a pod with an eligible
+	// reason is evicted from its container statuses rather than its phase
+	EvictionContainerPolicy string
+
+	// RequireAllTerminated waits for every container of an evicted pod to
+	// stop running before deleting it, so sidecars can finish cleaning up
+	RequireAllTerminated bool
+
+	// StampFirstSeen annotates evicted pods with when the reaper first saw
+	// them and measures the TTL from that instead of the StartTime
+	StampFirstSeen bool
+
+	// AnnotateSchedule annotates evicted pods waiting out their TTL with
+	// when they are due to be deleted
+	AnnotateSchedule bool
+
+	// VerifyDelete polls for a deleted pod to be gone before counting it, so
+	// deletions stuck on a finalizer are reported as unconfirmed instead
+	VerifyDelete bool
+
+	// TTLAnchor controls which timestamp the TTL is measured from. Empty
+	// means TTLAnchorStart.
+	TTLAnchor string
+
+	// FutureStartTimePolicy controls how the age of pods whose StartTime is
+	// in the future is measured. Empty means FutureStartTimePolicyZero.
+	FutureStartTimePolicy string
+
+	// SkipEmptySpec leaves malformed evicted pods without containers in place
+	// instead of reaping them
+	SkipEmptySpec bool
+
+	// MaintenanceConfigMap, if set, names a ConfigMap listing maintenance
+	// windows during which deletions are paused
+	MaintenanceConfigMap *types.NamespacedName
+
+	// Shedder, if set, requeues deletions while the API server is throttling
+	Shedder *LoadShedder
+
+	// LatencyGuard, if set, requeues deletions while recent delete calls are
+	// slow
+	LatencyGuard *LatencyGuard
+
+	// TeamLabelKey, if set, names the namespace label whose value is
+	// reported as the team on deletion metrics
+	TeamLabelKey string
+	teams        teamCache
+
+	// ControllerName overrides the controller name, which has to be unique
+	// when running several managers in one process
+	ControllerName string
+
+	// Self, if set, identifies the reaper's own pods, which are never reaped
+	Self *Identity
+
+	// MaxTrackedPods bounds each in-memory per-pod map, evicting the least
+	// recently used pods beyond it. 0 means unbounded.
+	MaxTrackedPods int
+
+	// ReconcileDebounce coalesces reconciles of the same pod within this
+	// window, requeuing them to the window end. 0 disables it.
+	ReconcileDebounce time.Duration
+
+	// StartupJitter spreads the reconciles of the pods the cache delivers
+	// at startup over this window, so they don't all hit the API server at
+	// once. 0 disables it.
+	StartupJitter time.Duration
+	startedAt     time.Time
+
+	// Reasons replaces Evicted as the status reasons eligible for reaping
+	Reasons []string
+	// ExceptReasons makes every status reason of a failed pod eligible for
+	// reaping except these. It is mutually exclusive with Reasons.
+	ExceptReasons []string
+
+	// DryRunNamespaces lists namespaces whose pods are only logged as they
+	// would be deleted, while the rest are deleted as usual
+	DryRunNamespaces []string
+
+	// FirstPassDryRun only logs the pods the first pass after startup
+	// would delete, with a count per namespace, and requeues them to be
+	// deleted once it is over
+	FirstPassDryRun bool
+	firstPass       firstPass
+
+	// DeferUntilCacheSynced requeues reconciles arriving before the cache
+	// has synced instead of acting on partial data
+	DeferUntilCacheSynced bool
+	cacheSynced           atomic.Bool
+
+	// HeartbeatInterval is how often the heartbeat counter is incremented
+	// while the reaper runs. Zero disables the heartbeat.
+	HeartbeatInterval time.Duration
+
+	// DeleteConcurrency caps simultaneous delete calls across reconcile
+	// workers. 0 means unbounded.
+	DeleteConcurrency int
+	deleteSlots       chan struct{}
+
+	// ReaperConfigured is set when a ReaperConfigReconciler applies settings
+	// at runtime. Reconciles are deferred until it has loaded them, and the
+	// candidate pods are queued again whenever they change.
+	ReaperConfigured bool
+	liveConfigLoaded atomic.Bool
+	liveReloads      chan event.GenericEvent
+
+	// liveConfig holds the settings applied at runtime, see SetLiveConfig
+	liveConfig atomic.Pointer[LiveConfig]
+
+	// initOnce sets up the state derived from the settings above
+	initOnce sync.Once
+
+	// observations records when a pod was first seen eligible for deletion
+	observations podTracker[types.UID, time.Time]
+
+	// scheduledRequeues records when a pod requeued before its TTL was
+	// intended to be reconciled again, to measure requeue drift
+	scheduledRequeues podTracker[types.UID, time.Time]
+
+	// lastErr holds the most recent reconcile error, for the debug endpoint
+	lastErr lastError
+
+	// notBefore records when a pod requeued before its TTL next becomes
+	// eligible, so requeues firing early can skip the Get
+	notBefore podTracker[types.NamespacedName, time.Time]
+
+	// lastReconciled records when a pod was last reconciled past the
+	// debounce window
+	lastReconciled podTracker[types.UID, time.Time]
+
+	// backoff counts failed deletions per pod until it is deleted or swept
+	backoff podTracker[types.NamespacedName, backoffEntry]
 }
 
-//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch;delete
 //+kubebuilder:rbac:groups="",resources=pods/status,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop
-func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *PodReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reconcileErr error) {
 	logger := log.FromContext(ctx)
 
+	// Record exactly one result per reconcile, and the last error
+	result := metrics.ReconcileNoop
+	reap := apiReapFrom(ctx)
+	defer func() {
+		r.Metrics.IncReconcile(result)
+		if reconcileErr != nil {
+			r.lastErr.record(req.NamespacedName, reconcileErr, time.Now())
+		}
+		if reap != nil {
+			reap.result = result
+		}
+	}()
+
+	r.initOnce.Do(r.init)
+
+	if r.DeferUntilCacheSynced && !r.cacheSynced.Load() {
+		logger.V(1).Info("cache not synced yet, deferring", "pod", req.NamespacedName,
+			"requeueAfter", cacheSyncRequeueAfter)
+		r.Metrics.IncDeferred(metrics.DeferCacheSync)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: cacheSyncRequeueAfter}, nil
+	}
+
+	// Hold off until the ReaperConfig is loaded, it may disable reaping
+	if r.ReaperConfigured && !r.liveConfigLoaded.Load() {
+		logger.V(1).Info("ReaperConfig not loaded yet, deferring", "pod", req.NamespacedName,
+			"requeueAfter", liveConfigRequeueAfter)
+		r.Metrics.IncDeferred(metrics.DeferReaperConfig)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: liveConfigRequeueAfter}, nil
+	}
+
+	// Ignore namespaces outside the watched prefix
+	if !r.isNamespaceWatched(req.Namespace) {
+		logger.V(1).Info("namespace does not match watched prefix, skipping", "pod", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	// Pause reaping while disabled at runtime, checking again later
+	if r.live().Disabled {
+		logger.V(1).Info("reaping is disabled, requeuing", "pod", req.NamespacedName,
+			"requeueAfter", reaperDisabledRequeueAfter)
+		r.Metrics.IncDeferred(metrics.DeferReaperDisabled)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: reaperDisabledRequeueAfter}, nil
+	}
+
+	// Skip pods that are known not to be eligible yet, unless asked to reap
+	// them now
+	if requeueAfter, ok := r.fastPathRequeue(req.NamespacedName); ok && reap == nil {
+		logger.V(1).Info("pod not yet eligible, requeuing without fetching", "pod", req.NamespacedName,
+			"requeueAfter", requeueAfter)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
 	// Fetch the Pod instance
 	pod := &corev1.Pod{}
 	err := r.Get(ctx, req.NamespacedName, pod)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// Object not found, return without error
+			r.Metrics.DeletePodInfo(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		logger.Error(err, "unable to fetch Pod")
-		return ctrl.Result{}, err
+		result = metrics.ReconcileError
+		return ctrl.Result{}, fmt.Errorf("getting pod %s: %w", req.NamespacedName, err)
 	}
 
-	// Check if pod is evicted
-	if !r.isPodEvicted(pod) {
-		logger.V(1).Info("pod is not evicted, skipping", "phase", pod.Status.Phase, "reason", pod.Status.Reason)
+	r.observeRequeueDrift(pod.UID, time.Now())
+
+	// Coalesce bursts of updates to the same pod
+	if requeueAfter, ok := r.debounce(pod.UID, time.Now()); ok && reap == nil {
+		logger.V(1).Info("pod reconciled recently, debouncing", "pod", req.NamespacedName, "requeueAfter", requeueAfter)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Smooth out the burst of reconciles right after startup
+	if requeueAfter, ok := r.startupDelay(pod.UID, time.Now()); ok && reap == nil {
+		logger.V(1).Info("spreading reconciles after startup", "pod", req.NamespacedName, "requeueAfter", requeueAfter)
+		r.Metrics.IncDeferred(metrics.DeferStartupGrace)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Never reap the reaper's own pods
+	if r.isSelf(pod) {
+		logger.Info("pod belongs to the reaper itself, never deleting", "pod", req.NamespacedName)
+		result = r.skip(pod, metrics.SkipSelf)
 		return ctrl.Result{}, nil
 	}
 
-	// Check preservation annotation
-	if r.shouldPreservePod(pod) {
-		logger.Info("pod has preserve annotation, skipping deletion", "pod", req.NamespacedName)
-		r.Metrics.IncSkipped(pod.Namespace)
+	// Check why the pod is a candidate, if it is one
+	switch r.classify(pod) {
+	case kindUnschedulable:
+		var res ctrl.Result
+		res, result, err = r.reconcileUnschedulable(ctx, pod, unschedulableCondition(pod))
+		return res, err
+	case kindCrashLoop:
+		var res ctrl.Result
+		res, result, err = r.reconcileCrashLoop(ctx, pod)
+		return res, err
+	case kindNone:
+		logger.V(1).Info("pod is not evicted, skipping", "phase", pod.Status.Phase, "reason", pod.Status.Reason)
+		r.observations.Delete(pod.UID)
+		r.Metrics.DeletePodInfo(req.Namespace, req.Name)
 		return ctrl.Result{}, nil
 	}
+	if !hasNoMetrics(pod) {
+		r.Metrics.SetPodInfo(pod.Namespace, pod.Name, pod.Spec.NodeName, pod.Status.Reason)
+	}
 
-	// Check TTL
-	if !r.hasExceededTTL(pod) {
+	// Flag StartTimes too old to be real, the age used below is clamped
+	if hasSuspiciousStartTime(pod) {
+		logger.Info("pod has a suspicious StartTime", "pod", req.NamespacedName,
+			"startTime", pod.Status.StartTime.Time)
+		if !hasNoMetrics(pod) {
+			r.Metrics.IncSuspiciousStartTime(pod.Namespace)
+		}
+	}
+	if hasFutureStartTime(pod) {
+		logger.Info("pod has a StartTime in the future", "pod", req.NamespacedName,
+			"startTime", pod.Status.StartTime.Time, "policy", r.FutureStartTimePolicy)
+	}
+	if value, ok := pod.Annotations[anchorTimeAnnotation]; ok {
+		if _, valid := pinnedAnchor(pod); !valid {
+			logger.Info("ignoring invalid anchor-time annotation", "pod", req.NamespacedName, "value", value)
+		}
+	}
+
+	// Pods without containers are malformed, flag them
+	if len(pod.Spec.Containers) == 0 {
+		logger.Info("evicted pod has no containers", "pod", req.NamespacedName, "reap", !r.SkipEmptySpec)
+	}
+
+	// Apply the checks that need only the pod, from the safe-mode allow-list
+	// to the TTL
+	if c := firstFailed(r.evictedChecks(pod, reap != nil)); c != nil {
+		if c.name != checkTTL {
+			var res ctrl.Result
+			res, result = r.handleFailedCheck(logger, pod, c)
+			return res, nil
+		}
+		// Record when the pod was first seen evicted, the TTL is measured
+		// from it
+		if r.StampFirstSeen {
+			if err := r.stampFirstSeen(ctx, pod, time.Now()); err != nil {
+				logger.Error(err, "unable to stamp pod as first seen", "pod", req.NamespacedName)
+				result = metrics.ReconcileError
+				return ctrl.Result{}, fmt.Errorf("stamping pod %s as first seen: %w", req.NamespacedName, err)
+			}
+		}
 		requeueAfter := r.calculateRequeueTime(pod)
 		logger.Info("pod has not exceeded TTL, requeuing", "pod", req.NamespacedName, "requeueAfter", requeueAfter)
+		if r.AnnotateSchedule {
+			if err := r.annotateSchedule(ctx, pod, time.Now().Add(requeueAfter)); err != nil {
+				logger.Error(err, "unable to annotate pod with its scheduled deletion", "pod", req.NamespacedName)
+			}
+		}
+		r.notBefore.Set(req.NamespacedName, time.Now().Add(requeueAfter))
+		r.scheduledRequeues.Set(pod.UID, time.Now().Add(requeueAfter))
+		result = metrics.ReconcileRequeued
 		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	}
 
+	// Confirm eligibility on a second observation
+	if r.RequireConsecutiveObservations && !r.confirmObservation(pod) {
+		logger.Info("pod eligible for deletion on first observation, requeuing to confirm", "pod", req.NamespacedName,
+			"requeueAfter", observationConfirmDelay)
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: observationConfirmDelay}, nil
+	}
+
+	// Wait for the owner to react to the failure
+	if r.WaitForOwnerObserved {
+		observed, err := r.hasOwnerObserved(ctx, pod)
+		if err != nil {
+			logger.Error(err, "unable to check whether the owner observed the pod failing", "pod", req.NamespacedName)
+			result = metrics.ReconcileError
+			return ctrl.Result{}, fmt.Errorf("checking owner of pod %s: %w", req.NamespacedName, err)
+		}
+		if !observed {
+			logger.Info("owner has not observed the pod failing yet, requeuing", "pod", req.NamespacedName,
+				"requeueAfter", ownerObservedRequeueAfter)
+			r.Metrics.IncDeferred(metrics.DeferOwnerObserved)
+			result = metrics.ReconcileRequeued
+			return ctrl.Result{RequeueAfter: ownerObservedRequeueAfter}, nil
+		}
+	}
+
+	// Wait for the node to recover from the pressure that evicted the pod
+	if r.WaitForNodeReady {
+		ready, err := r.isNodeReady(ctx, pod)
+		if err != nil {
+			logger.Error(err, "unable to check whether the node is ready", "pod", req.NamespacedName, "node", pod.Spec.NodeName)
+			result = metrics.ReconcileError
+			return ctrl.Result{}, fmt.Errorf("checking node %s of pod %s: %w", pod.Spec.NodeName, req.NamespacedName, err)
+		}
+		if !ready {
+			logger.Info("node is not ready, requeuing", "pod", req.NamespacedName, "node", pod.Spec.NodeName,
+				"requeueAfter", nodeNotReadyRequeueAfter)
+			if !hasNoMetrics(pod) {
+				r.Metrics.IncNodeNotReady(pod.Spec.NodeName)
+			}
+			result = metrics.ReconcileRequeued
+			return ctrl.Result{RequeueAfter: nodeNotReadyRequeueAfter}, nil
+		}
+	}
+
+	// Keep evictions from the node's current boot
+	if r.ReapOnlyPreReboot {
+		preReboot, err := r.predatesNodeBoot(ctx, pod)
+		if err != nil {
+			logger.Error(err, "unable to check when the node last came up", "pod", req.NamespacedName, "node", pod.Spec.NodeName)
+			result = metrics.ReconcileError
+			return ctrl.Result{}, fmt.Errorf("checking node %s of pod %s: %w", pod.Spec.NodeName, req.NamespacedName, err)
+		}
+		if !preReboot {
+			logger.V(1).Info("pod is newer than its node's last reboot, requeuing", "pod", req.NamespacedName,
+				"node", pod.Spec.NodeName, "requeueAfter", postRebootRequeueAfter)
+			r.Metrics.IncDeferred(metrics.DeferPostReboot)
+			result = metrics.ReconcileRequeued
+			return ctrl.Result{RequeueAfter: postRebootRequeueAfter}, nil
+		}
+	}
+
+	// Pause deletions during maintenance windows
+	if res, reconcileResult, paused, err := r.pauseForMaintenance(ctx, pod); paused {
+		result = reconcileResult
+		return res, err
+	}
+
+	// Shed deletion work while the API server is throttling
+	if r.Shedder != nil {
+		delay, shed := r.Shedder.Check(time.Now())
+		r.Metrics.SetShedding(shed)
+		if shed {
+			logger.Info("API error rate too high, shedding deletion", "pod", req.NamespacedName, "requeueAfter", delay)
+			r.Metrics.IncDeferred(metrics.DeferShedding)
+			result = metrics.ReconcileRequeued
+			return ctrl.Result{RequeueAfter: delay}, nil
+		}
+	}
+
+	// Back off while the API server is slow to delete
+	if r.LatencyGuard != nil {
+		p99, backoff := r.LatencyGuard.Check(time.Now())
+		r.Metrics.SetLatencyBackoff(backoff)
+		if backoff {
+			logger.Info("delete latency too high, backing off deletion", "pod", req.NamespacedName,
+				"p99", p99, "requeueAfter", latencyBackoffRequeueAfter)
+			r.Metrics.IncDeferred(metrics.DeferLatencyBackoff)
+			result = metrics.ReconcileRequeued
+			return ctrl.Result{RequeueAfter: latencyBackoffRequeueAfter}, nil
+		}
+	}
+
+	// Only log what would be deleted in dry-run namespaces, ahead of the
+	// first pass so it doesn't count pods it will never delete
+	if r.dryRun(ctx, pod) {
+		result = r.skip(pod, metrics.SkipDryRun)
+		return ctrl.Result{}, nil
+	}
+
+	// Only log what the first pass after startup would delete
+	if wait, dryRun := r.firstPassDryRun(ctx, pod, time.Now()); dryRun {
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: wait}, nil
+	}
+
+	// Delegate Job-owned pods to the Job's TTL-after-finished
+	if r.UseJobTTL {
+		delegated, patched, err := r.delegateToJobTTL(ctx, pod)
+		if err != nil {
+			logger.Error(err, "unable to set ttlSecondsAfterFinished on owning Job", "pod", req.NamespacedName)
+			result = metrics.ReconcileError
+			return ctrl.Result{}, fmt.Errorf("delegating pod %s to its Job's TTL: %w", req.NamespacedName, err)
+		}
+		if delegated {
+			if patched {
+				if !hasNoMetrics(pod) {
+					r.Metrics.IncJobTTLPatched(pod.Namespace)
+				}
+				logger.Info("set ttlSecondsAfterFinished on owning Job", "pod", req.NamespacedName,
+					"ttlSecondsAfterFinished", r.JobTTLSecondsAfterFinished)
+			}
+			result = r.skip(pod, metrics.SkipJobTTL)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Label the pod for manual review instead of deleting it
+	if r.Mode == ModeQuarantine {
+		res, reconcileResult, err := r.quarantine(ctx, pod)
+		result = reconcileResult
+		return res, err
+	}
+
+	// Run the pre-delete hook, which can veto the deletion
+	if r.PreDeleteHook != nil {
+		output, err := r.PreDeleteHook.Run(ctx, pod)
+		if err != nil {
+			logger.Info("pre-delete hook vetoed deletion, requeuing", "pod", req.NamespacedName,
+				"error", err.Error(), "output", output, "requeueAfter", preDeleteHookRequeueAfter)
+			result = metrics.ReconcileRequeued
+			return ctrl.Result{RequeueAfter: preDeleteHookRequeueAfter}, nil
+		}
+		logger.V(1).Info("pre-delete hook allowed deletion", "pod", req.NamespacedName, "output", output)
+	}
+
+	// Keep a copy of the manifest before it is gone
+	if r.Archiver != nil {
+		if err := r.Archiver.Archive(ctx, pod); err != nil {
+			logger.Error(err, "unable to archive pod manifest", "pod", req.NamespacedName)
+			result = metrics.ReconcileError
+			return ctrl.Result{}, fmt.Errorf("archiving pod %s: %w", req.NamespacedName, err)
+		}
+		logger.V(1).Info("archived pod manifest", "pod", req.NamespacedName, "key", r.Archiver.Key(pod))
+	}
+
+	// Mark the pod before deleting it so the deletion is only counted once
+	uid := pod.UID
+	alreadyReaped := isReaped(pod)
+	if !alreadyReaped {
+		if err := r.markReaped(ctx, pod); err != nil {
+			if errors.IsNotFound(err) {
+				logger.V(1).Info("pod already deleted, likely by another replica", "pod", req.NamespacedName)
+				r.Metrics.DeletePodInfo(req.Namespace, req.Name)
+				result = metrics.ReconcileNoop
+				return ctrl.Result{}, nil
+			}
+			// A new pod was created under the same name, which is a fresh
+			// candidate of its own
+			if stderrors.Is(err, errReplaced) {
+				result = metrics.ReconcileNoop
+				return r.podReplaced(ctx, req, uid)
+			}
+			logger.Error(err, "unable to mark pod as reaped", "pod", req.NamespacedName)
+			result = metrics.ReconcileError
+			return ctrl.Result{}, fmt.Errorf("marking pod %s as reaped: %w", req.NamespacedName, err)
+		}
+	}
+
 	// Delete the pod
-	logger.Info("deleting evicted pod", "pod", req.NamespacedName)
-	if err := r.Delete(ctx, pod); err != nil {
-		logger.Error(err, "unable to delete pod", "pod", req.NamespacedName)
-		return ctrl.Result{}, err
+	ownerKind, ownerName := r.resolveTopOwner(ctx, pod)
+	logger.Info("deleting evicted pod", "pod", req.NamespacedName, "ownerKind", ownerKind, "ownerName", ownerName)
+	err = r.removePod(ctx, pod)
+	if r.UseEvictionAPI && isEvictionBlocked(err) {
+		logger.Info("eviction blocked by a PodDisruptionBudget, requeuing", "pod", req.NamespacedName,
+			"requeueAfter", evictionBlockedRequeueAfter)
+		if !hasNoMetrics(pod) {
+			r.Metrics.IncEvictionBlocked(pod.Namespace)
+		}
+		result = metrics.ReconcileRequeued
+		return ctrl.Result{RequeueAfter: evictionBlockedRequeueAfter}, nil
+	}
+	// The pod is gone and a new one has its name, leave it to its own reconcile
+	if isPodReplaced(err) {
+		result = metrics.ReconcileNoop
+		return r.podReplaced(ctx, req, uid)
+	}
+	// Another replica deleted the pod first, it counted and reported it
+	if errors.IsNotFound(err) {
+		r.clearDeleteFailures(req.NamespacedName)
+		r.observations.Delete(pod.UID)
+		r.Metrics.DeletePodInfo(req.Namespace, req.Name)
+		logger.V(1).Info("pod already deleted, likely by another replica", "pod", req.NamespacedName)
+		result = metrics.ReconcileNoop
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		attempts := r.recordDeleteFailure(req.NamespacedName, pod.UID)
+		logger.Error(err, "unable to delete pod", "pod", req.NamespacedName, "attempts", attempts)
+		result = metrics.ReconcileError
+		return ctrl.Result{}, fmt.Errorf("deleting pod %s: %w", req.NamespacedName, err)
+	}
+
+	// Make sure the pod is actually gone before counting it
+	if r.VerifyDelete {
+		confirmed, err := r.confirmDeleted(ctx, pod)
+		if err != nil {
+			logger.Error(err, "unable to confirm pod deletion", "pod", req.NamespacedName)
+		}
+		if !confirmed {
+			logger.Info("pod still exists after deleting it, it may be held by a finalizer", "pod", req.NamespacedName,
+				"finalizers", pod.Finalizers)
+			r.clearDeleteFailures(req.NamespacedName)
+			if !hasNoMetrics(pod) {
+				r.Metrics.IncDeleteUnconfirmed(pod.Namespace)
+			}
+			result = metrics.ReconcileNoop
+			return ctrl.Result{}, nil
+		}
+	}
+
+	r.clearDeleteFailures(req.NamespacedName)
+	r.observations.Delete(pod.UID)
+	r.Metrics.DeletePodInfo(req.Namespace, req.Name)
+	result = metrics.ReconcileDeleted
+	silent := isSilent(pod)
+	var annotations map[string]string
+	if silent {
+		logger.V(1).Info("successfully deleted silent evicted pod", "pod", req.NamespacedName)
+	} else {
+		annotations = r.auditAnnotations(pod)
+		logValues := []any{"pod", req.NamespacedName}
+		if len(annotations) > 0 {
+			logValues = append(logValues, "annotations", annotations)
+		}
+		logger.Info("successfully deleted evicted pod", logValues...)
 	}
 
-	r.Metrics.IncDeleted(pod.Namespace)
-	logger.Info("successfully deleted evicted pod", "pod", req.NamespacedName)
+	// Clean up the per-pod objects left behind
+	if r.OrphanedObjectSelector != nil {
+		deleted, err := r.deleteOrphanedPodObjects(ctx, pod)
+		if err != nil {
+			logger.Error(err, "unable to delete orphaned pod objects", "pod", req.NamespacedName)
+		}
+		if deleted > 0 {
+			logger.Info("deleted orphaned pod objects", "pod", req.NamespacedName, "count", deleted)
+		}
+	}
+
+	// A previous run already counted and reported this pod
+	if alreadyReaped {
+		logger.V(1).Info("pod was already reaped, not counting again", "pod", req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+	if !hasNoMetrics(pod) {
+		r.Metrics.IncDeleted(pod.Namespace, qosClass(pod))
+		r.Metrics.SetLastDeletion(pod.Namespace, pod.Status.Reason, time.Now())
+		if r.TeamLabelKey != "" {
+			r.Metrics.IncTeamDeleted(r.namespaceTeam(ctx, pod.Namespace))
+		}
+		if r.CountOwnerKinds {
+			r.Metrics.IncDeletedByOwnerKind(ownerKindLabel(ownerKind))
+		}
+	}
+
+	if r.Notifier != nil && !silent {
+		r.Notifier.PodReaped(notify.Event{
+			Namespace:   pod.Namespace,
+			Name:        pod.Name,
+			Reason:      pod.Status.Reason,
+			OwnerKind:   ownerKind,
+			OwnerName:   ownerName,
+			Annotations: annotations,
+		})
+	}
 
 	return ctrl.Result{}, nil
 }
 
-// isPodEvicted checks if a pod is in evicted state
+// podReplaced forgets a pod that was replaced by a new pod of the same name
+// before it could be removed, without counting it as deleted
+func (r *PodReconciler) podReplaced(ctx context.Context, req ctrl.Request, uid types.UID) (ctrl.Result, error) {
+	r.clearDeleteFailures(req.NamespacedName)
+	r.observations.Delete(uid)
+	r.Metrics.DeletePodInfo(req.Namespace, req.Name)
+	log.FromContext(ctx).Info("pod was replaced by a new pod of the same name, not deleting it",
+		"pod", req.NamespacedName, "uid", uid)
+	return ctrl.Result{}, nil
+}
+
+// init sets up the state derived from the reconciler settings
+func (r *PodReconciler) init() {
+	r.limitTrackers()
+	if r.DeleteConcurrency > 0 {
+		r.deleteSlots = make(chan struct{}, r.DeleteConcurrency)
+	}
+	r.startedAt = time.Now()
+	r.firstPass.ends = r.startedAt.Add(firstPassWindow)
+}
+
+// limitTrackers applies MaxTrackedPods to the in-memory per-pod maps
+func (r *PodReconciler) limitTrackers() {
+	r.observations.setLimit(r.MaxTrackedPods, func() { r.Metrics.IncTrackerEviction("observations") })
+	r.scheduledRequeues.setLimit(r.MaxTrackedPods, func() { r.Metrics.IncTrackerEviction("scheduled_requeues") })
+	r.notBefore.setLimit(r.MaxTrackedPods, func() { r.Metrics.IncTrackerEviction("not_before") })
+	r.lastReconciled.setLimit(r.MaxTrackedPods, func() { r.Metrics.IncTrackerEviction("last_reconciled") })
+	r.backoff.setLimit(r.MaxTrackedPods, func() { r.Metrics.IncTrackerEviction("backoff") })
+}
+
+// isPodEvicted checks if a pod is in evicted state, according to the
+// EvictionContainerPolicy, or is a finished pod matching a LabelTTLs rule
 func (r *PodReconciler) isPodEvicted(pod *corev1.Pod) bool {
-	return pod.Status.Phase == corev1.PodFailed && pod.Status.Reason == "Evicted"
+	if r.isFinishedLabeled(pod) {
+		return true
+	}
+	reasons, exceptReasons := r.eligibleReasons()
+	if r.EvictionContainerPolicy == "" || len(pod.Status.ContainerStatuses) == 0 {
+		return isEvicted(pod, reasons, exceptReasons)
+	}
+	if !hasEligibleReason(pod, reasons, exceptReasons) {
+		return false
+	}
+	terminated := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil {
+			terminated++
+		}
+	}
+	if r.EvictionContainerPolicy == EvictionContainerPolicyAny {
+		return terminated > 0
+	}
+	return terminated == len(pod.Status.ContainerStatuses)
+}
+
+// eligibleReasons returns the status reasons eligible for reaping and the
+// ones excluded from it. The ReaperConfig reasons replace both.
+func (r *PodReconciler) eligibleReasons() ([]string, []string) {
+	if reasons := r.live().Reasons; len(reasons) > 0 {
+		return reasons, nil
+	}
+	return r.Reasons, r.ExceptReasons
+}
+
+// isEvicted checks if a pod failed for a reason that makes it eligible
+func isEvicted(pod *corev1.Pod, defaultReasons, exceptReasons []string) bool {
+	return pod.Status.Phase == corev1.PodFailed && hasEligibleReason(pod, defaultReasons, exceptReasons)
+}
+
+// hasEligibleReason checks if a pod's status reason makes it eligible: the
+// reasons listed in its reason-match annotation, else the default reasons,
+// else any reason but the except reasons, Evicted if none are given
+func hasEligibleReason(pod *corev1.Pod, defaultReasons, exceptReasons []string) bool {
+	reasons, ok := pod.Annotations[reasonMatchAnnotation]
+	if !ok {
+		if len(defaultReasons) == 0 && len(exceptReasons) > 0 {
+			return pod.Status.Reason != "" && !slices.Contains(exceptReasons, pod.Status.Reason)
+		}
+		if len(defaultReasons) == 0 {
+			return pod.Status.Reason == evictedReason
+		}
+		return pod.Status.Reason != "" && slices.Contains(defaultReasons, pod.Status.Reason)
+	}
+	for _, reason := range strings.Split(reasons, ",") {
+		if strings.TrimSpace(reason) == pod.Status.Reason && pod.Status.Reason != "" {
+			return true
+		}
+	}
+	return false
 }
 
 // shouldPreservePod checks if pod has preserve annotation set to "true"
@@ -91,31 +921,229 @@ func (r *PodReconciler) shouldPreservePod(pod *corev1.Pod) bool {
 	return pod.Annotations[preserveAnnotation] == "true"
 }
 
+// skip counts a pod skipped for a reason, unless it is left out of the
+// metrics, returning the skipped reconcile result
+func (r *PodReconciler) skip(pod *corev1.Pod, reason string) string {
+	if !hasNoMetrics(pod) {
+		r.Metrics.IncSkipped(pod.Namespace, reason)
+	}
+	return metrics.ReconcileSkipped
+}
+
+// qosClass returns the pod's QoS class, or unknown if it isn't set
+func qosClass(pod *corev1.Pod) string {
+	if pod.Status.QOSClass == "" {
+		return "unknown"
+	}
+	return string(pod.Status.QOSClass)
+}
+
+// auditAnnotations returns the pod's values of the AuditAnnotations it has
+func (r *PodReconciler) auditAnnotations(pod *corev1.Pod) map[string]string {
+	var annotations map[string]string
+	for _, key := range r.AuditAnnotations {
+		if value, ok := pod.Annotations[key]; ok {
+			if annotations == nil {
+				annotations = make(map[string]string)
+			}
+			annotations[key] = value
+		}
+	}
+	return annotations
+}
+
+// apiReader returns the APIReader, or the Client if it isn't set
+func (r *PodReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
+// isReaped checks if pod has already been marked for deletion by the reaper
+func isReaped(pod *corev1.Pod) bool {
+	_, ok := pod.Annotations[reapedAnnotation]
+	return ok
+}
+
+// markReaped sets the reaped annotation on a pod
+func (r *PodReconciler) markReaped(ctx context.Context, pod *corev1.Pod) error {
+	return r.patchWithRetry(ctx, pod, func() bool {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[reapedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		return true
+	})
+}
+
+// isNamespaceWatched checks if a namespace matches the watched prefix or is
+// listed explicitly, and matches the namespace selector if one is set.
+// Without a prefix every namespace is watched.
+func (r *PodReconciler) isNamespaceWatched(namespace string) bool {
+	if !r.isLiveNamespace(namespace) {
+		return false
+	}
+	if r.NamespaceSelector != nil && !r.selectedNamespaces.has(namespace) {
+		return false
+	}
+	if r.NamespacePrefix == "" {
+		return true
+	}
+	if strings.HasPrefix(namespace, r.NamespacePrefix) {
+		return true
+	}
+	for _, ns := range r.WatchNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// isNamespaceAllowed checks if deletions are permitted in the namespace.
+// Outside of safe mode every namespace is allowed.
+func (r *PodReconciler) isNamespaceAllowed(namespace string) bool {
+	if !r.SafeMode {
+		return true
+	}
+	for _, ns := range r.AllowedNamespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesPriorityFilter checks if the pod's priority class and priority
+// fall within the configured filters. Unset filters match every pod.
+func (r *PodReconciler) matchesPriorityFilter(pod *corev1.Pod) bool {
+	if len(r.PriorityClassFilter) > 0 {
+		matched := false
+		for _, class := range r.PriorityClassFilter {
+			if pod.Spec.PriorityClassName == class {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if r.MaxPriority != nil {
+		var priority int32
+		if pod.Spec.Priority != nil {
+			priority = *pod.Spec.Priority
+		}
+		if priority >= *r.MaxPriority {
+			return false
+		}
+	}
+
+	return true
+}
+
+// confirmObservation reports whether the pod was already seen eligible on an
+// earlier reconcile, recording this observation if not
+func (r *PodReconciler) confirmObservation(pod *corev1.Pod) bool {
+	if _, seen := r.observations.Get(pod.UID); seen {
+		return true
+	}
+	r.observations.Set(pod.UID, time.Now())
+	return false
+}
+
+// ttlFor returns the TTL in seconds for a pod: the TTL its reap-after
+// annotation asks for, else the TTL of its label rule, else the TTL for its
+// reason, else for its namespace, else for whether it has an owner, else the
+// default
+func (r *PodReconciler) ttlFor(pod *corev1.Pod) int {
+	if action, after := resolveAnnotations(pod); action == actionReapAfter {
+		return int(after / time.Second)
+	}
+	if ttl, ok := r.labelTTL(pod); ok {
+		return ttl
+	}
+	if ttl, ok := r.ReasonTTLs[pod.Status.Reason]; ok {
+		return ttl
+	}
+	if ttl, ok := r.NamespaceTTLs[pod.Namespace]; ok {
+		return ttl
+	}
+	if len(pod.OwnerReferences) > 0 && r.OwnedTTL != nil {
+		return *r.OwnedTTL
+	}
+	if len(pod.OwnerReferences) == 0 && r.OrphanTTL != nil {
+		return *r.OrphanTTL
+	}
+	return r.defaultTTL()
+}
+
 // hasExceededTTL checks if the pod has exceeded the TTL
 func (r *PodReconciler) hasExceededTTL(pod *corev1.Pod) bool {
-	if pod.Status.StartTime == nil {
-		// If no start time, consider it exceeded
+	if _, ok := r.ageStart(pod); !ok {
+		// Without anything to measure from, consider it exceeded
 		return true
 	}
 
-	podAge := time.Since(pod.Status.StartTime.Time)
-	return podAge > time.Duration(r.TTLToDelete)*time.Second
+	return r.podAge(pod) > time.Duration(r.ttlFor(pod))*time.Second
+}
+
+// podAge returns how long ago a pod's age starts according to ageStart,
+// clamped between zero and maxPodAge
+func (r *PodReconciler) podAge(pod *corev1.Pod) time.Duration {
+	start, _ := r.ageStart(pod)
+	age := time.Since(start)
+	if age < 0 {
+		return 0
+	}
+	if age > maxPodAge {
+		return maxPodAge
+	}
+	return age
+}
+
+// hasFutureStartTime checks if a pod claims to start further in the future
+// than clock skew explains
+func hasFutureStartTime(pod *corev1.Pod) bool {
+	return pod.Status.StartTime != nil && time.Until(pod.Status.StartTime.Time) > futureStartTimeTolerance
+}
+
+// hasSuspiciousStartTime checks if a pod claims to have started longer ago
+// than any real pod could have, e.g. an epoch-zero StartTime
+func hasSuspiciousStartTime(pod *corev1.Pod) bool {
+	return pod.Status.StartTime != nil && time.Since(pod.Status.StartTime.Time) > maxPodAge
+}
+
+// observeRequeueDrift records how late a reconcile fired relative to the
+// requeue scheduled for the pod. Reconciles triggered early by watch events
+// aren't drift and only clear the schedule.
+func (r *PodReconciler) observeRequeueDrift(uid types.UID, now time.Time) {
+	scheduled, ok := r.scheduledRequeues.Get(uid)
+	if !ok {
+		return
+	}
+	r.scheduledRequeues.Delete(uid)
+	if drift := now.Sub(scheduled); drift >= 0 {
+		r.Metrics.ObserveRequeueDrift(drift)
+	}
 }
 
 // calculateRequeueTime calculates when to requeue the pod for deletion
 func (r *PodReconciler) calculateRequeueTime(pod *corev1.Pod) time.Duration {
-	if pod.Status.StartTime == nil {
+	if _, ok := r.ageStart(pod); !ok {
 		return 0
 	}
 
-	podAge := time.Since(pod.Status.StartTime.Time)
-	ttlDuration := time.Duration(r.TTLToDelete) * time.Second
+	age := r.podAge(pod)
+	ttlDuration := time.Duration(r.ttlFor(pod)) * time.Second
 
-	if podAge >= ttlDuration {
+	if age >= ttlDuration {
 		return 0
 	}
 
-	return ttlDuration - podAge
+	return ttlDuration - age
 }
 
 // isEvictedPodPredicate returns true if the object is an evicted pod
@@ -124,16 +1152,73 @@ func isEvictedPodPredicate(obj client.Object) bool {
 	if !ok {
 		return false
 	}
-	return pod.Status.Phase == corev1.PodFailed && pod.Status.Reason == "Evicted"
+	return isEvicted(pod, nil, nil)
 }
 
-// SetupWithManager sets up the controller with the Manager.
+// isCandidatePodPredicate returns true if the object is a pod the reconciler
+// may act on
+func (r *PodReconciler) isCandidatePodPredicate(obj client.Object) bool {
+	return r.isEvictedCandidate(obj) ||
+		(r.ReapUnschedulable && isUnschedulablePodPredicate(obj)) ||
+		(r.ReapCrashLoop && isCrashLoopingPodPredicate(obj))
+}
+
+// isEvictedCandidate returns true if the object is an evicted pod under the
+// EvictionContainerPolicy
+func (r *PodReconciler) isEvictedCandidate(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	return ok && r.isPodEvicted(pod)
+}
+
+// podPredicate returns the event filter for the controller. When
+// transitionUpdatesOnly is set, update events only pass when the pod
+// transitions into a candidate state, ignoring unrelated status updates.
+func podPredicate(isCandidate func(client.Object) bool, transitionUpdatesOnly bool) predicate.Funcs {
+	candidatePredicate := predicate.NewPredicateFuncs(isCandidate)
+	if transitionUpdatesOnly {
+		candidatePredicate.UpdateFunc = func(e event.UpdateEvent) bool {
+			return !isCandidate(e.ObjectOld) && isCandidate(e.ObjectNew)
+		}
+	}
+	return candidatePredicate
+}
+
+// SetupWithManager sets up the controller with the Manager. Requeues don't
+// survive a restart, but the informer replays every existing pod as a create
+// event on startup, so each is re-evaluated and requeued against its TTL.
 func (r *PodReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	// Only watch pods that are evicted (Failed phase with Evicted reason)
-	evictedPredicate := predicate.NewPredicateFuncs(isEvictedPodPredicate)
+	if err := mgr.Add(manager.RunnableFunc(r.runBackoffSweep)); err != nil {
+		return err
+	}
+	if err := mgr.Add(manager.RunnableFunc(r.runPreservedOverdueSweep)); err != nil {
+		return err
+	}
+	if r.DeferUntilCacheSynced {
+		if err := mgr.Add(manager.RunnableFunc(r.cacheSyncGate(mgr.GetCache()))); err != nil {
+			return err
+		}
+	}
+	if r.HeartbeatInterval > 0 {
+		if err := mgr.Add(manager.RunnableFunc(r.runHeartbeat)); err != nil {
+			return err
+		}
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Only watch pods that are evicted (Failed phase with Evicted reason),
+	// or unschedulable or crashlooping when reaping those is enabled
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Pod{}).
-		WithEventFilter(evictedPredicate).
-		Complete(r)
+		WithEventFilter(r.invalidateFastPathPredicate()).
+		WithEventFilter(podPredicate(r.isCandidatePodPredicate, r.TransitionUpdatesOnly))
+	if r.NamespaceSelector != nil {
+		b = b.WatchesRawSource(r.namespaceSource(mgr))
+	}
+	if r.ReaperConfigured {
+		r.liveReloads = make(chan event.GenericEvent)
+		b = b.WatchesRawSource(source.Channel(r.liveReloads, &handler.EnqueueRequestForObject{}))
+	}
+	if r.ControllerName != "" {
+		b = b.Named(r.ControllerName)
+	}
+	return b.Complete(r)
 }
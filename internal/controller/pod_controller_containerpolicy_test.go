@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_EvictionContainerPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	terminated := corev1.ContainerStatus{
+		Name:  "terminated",
+		State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "Error"}},
+	}
+	running := corev1.ContainerStatus{
+		Name:  "running",
+		State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+	}
+
+	tests := []struct {
+		name          string
+		policy        string
+		phase         corev1.PodPhase
+		statuses      []corev1.ContainerStatus
+		expectDeleted bool
+	}{
+		{
+			name:          "default uses the phase with mixed containers",
+			phase:         corev1.PodFailed,
+			statuses:      []corev1.ContainerStatus{terminated, running},
+			expectDeleted: true,
+		},
+		{
+			name:     "default ignores running pods with a terminated container",
+			phase:    corev1.PodRunning,
+			statuses: []corev1.ContainerStatus{terminated, running},
+		},
+		{
+			name:     "all keeps pods with a running container",
+			policy:   EvictionContainerPolicyAll,
+			phase:    corev1.PodFailed,
+			statuses: []corev1.ContainerStatus{terminated, running},
+		},
+		{
+			name:          "all deletes pods whose containers all terminated",
+			policy:        EvictionContainerPolicyAll,
+			phase:         corev1.PodFailed,
+			statuses:      []corev1.ContainerStatus{terminated, terminated},
+			expectDeleted: true,
+		},
+		{
+			name:          "any deletes pods with a terminated container",
+			policy:        EvictionContainerPolicyAny,
+			phase:         corev1.PodRunning,
+			statuses:      []corev1.ContainerStatus{terminated, running},
+			expectDeleted: true,
+		},
+		{
+			name:     "any keeps pods with every container running",
+			policy:   EvictionContainerPolicyAny,
+			phase:    corev1.PodRunning,
+			statuses: []corev1.ContainerStatus{running, running},
+		},
+		{
+			name:          "policy falls back to the phase without container statuses",
+			policy:        EvictionContainerPolicyAll,
+			phase:         corev1.PodFailed,
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:             tt.phase,
+					Reason:            "Evicted",
+					StartTime:         &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+					ContainerStatuses: tt.statuses,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:                  fakeClient,
+				Scheme:                  scheme,
+				Metrics:                 metrics.NewPodMetrics(),
+				TTLToDelete:             300,
+				AllowedNamespaces:       []string{"default"},
+				EvictionContainerPolicy: tt.policy,
+			}
+
+			if got := r.isCandidatePodPredicate(pod); got != tt.expectDeleted {
+				t.Errorf("isCandidatePodPredicate() = %v, want %v", got, tt.expectDeleted)
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
@@ -8,8 +8,10 @@ import (
 
 	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -171,6 +173,10 @@ func (c *errorClient) Get(ctx context.Context, key client.ObjectKey, obj client.
 	return nil
 }
 
+func (c *errorClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	return nil
+}
+
 func (c *errorClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
 	return c.deleteError
 }
@@ -180,8 +186,9 @@ func TestPodReconciler_ClientErrors(t *testing.T) {
 	_ = clientgoscheme.AddToScheme(scheme)
 
 	t.Run("get error", func(t *testing.T) {
+		getErr := errors.New("get failed")
 		r := &PodReconciler{
-			Client:      &errorClient{getError: errors.New("get failed")},
+			Client:      &errorClient{getError: getErr},
 			Scheme:      scheme,
 			Metrics:     metrics.NewPodMetrics(),
 			TTLToDelete: 300,
@@ -195,14 +202,18 @@ func TestPodReconciler_ClientErrors(t *testing.T) {
 		}
 		_, err := r.Reconcile(context.Background(), req)
 
-		if err == nil || err.Error() != "get failed" {
+		if !errors.Is(err, getErr) {
 			t.Errorf("Expected 'get failed' error, got: %v", err)
 		}
+		if err == nil || err.Error() != "getting pod default/test-pod: get failed" {
+			t.Errorf("Expected the error to name the operation and pod, got: %v", err)
+		}
 	})
 
 	t.Run("delete error", func(t *testing.T) {
+		deleteErr := errors.New("delete failed")
 		r := &PodReconciler{
-			Client:      &errorClient{deleteError: errors.New("delete failed")},
+			Client:      &errorClient{deleteError: deleteErr},
 			Scheme:      scheme,
 			Metrics:     metrics.NewPodMetrics(),
 			TTLToDelete: 300,
@@ -216,8 +227,34 @@ func TestPodReconciler_ClientErrors(t *testing.T) {
 		}
 		_, err := r.Reconcile(context.Background(), req)
 
-		if err == nil || err.Error() != "delete failed" {
+		if !errors.Is(err, deleteErr) {
 			t.Errorf("Expected 'delete failed' error, got: %v", err)
 		}
+		if err == nil || err.Error() != "deleting pod default/test-pod: delete failed" {
+			t.Errorf("Expected the error to name the operation and pod, got: %v", err)
+		}
 	})
 }
+
+func TestPodReconciler_WrappedErrorsAs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	r := &PodReconciler{
+		Client:      &errorClient{deleteError: apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "test-pod", errors.New("denied"))},
+		Scheme:      scheme,
+		Metrics:     metrics.NewPodMetrics(),
+		TTLToDelete: 300,
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "default"}}
+	_, err := r.Reconcile(context.Background(), req)
+
+	var statusErr *apierrors.StatusError
+	if !errors.As(err, &statusErr) {
+		t.Fatalf("Expected a wrapped API status error, got: %v", err)
+	}
+	if !apierrors.IsForbidden(err) {
+		t.Errorf("Expected the wrapped error to still be Forbidden, got: %v", err)
+	}
+}
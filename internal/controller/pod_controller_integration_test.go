@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TestPodReconciler_Integration runs the reconciler in a manager against a
+// real API server, so pods reach it through the cache, watch and predicates
+func TestPodReconciler_Integration(t *testing.T) {
+	cfg := startTestEnv(t)
+	c := startManager(t, cfg, &PodReconciler{TTLToDelete: 2})
+	ctx := context.Background()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "reaper-it"}}
+	if err := c.Create(ctx, ns); err != nil {
+		t.Fatalf("Failed to create namespace: %v", err)
+	}
+
+	// Pods are created Pending and move to their phase through a status
+	// update, as the kubelet would
+	newPod := func(name string, annotations map[string]string, status corev1.PodStatus) types.NamespacedName {
+		t.Helper()
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns.Name, Annotations: annotations},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "busybox"}}},
+		}
+		if err := c.Create(ctx, pod); err != nil {
+			t.Fatalf("Failed to create pod %s: %v", name, err)
+		}
+		pod.Status = status
+		if err := c.Status().Update(ctx, pod); err != nil {
+			t.Fatalf("Failed to update status of pod %s: %v", name, err)
+		}
+		return client.ObjectKeyFromObject(pod)
+	}
+	evicted := func() corev1.PodStatus {
+		return corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now()},
+		}
+	}
+	exists := func(key types.NamespacedName) bool {
+		t.Helper()
+		err := c.Get(ctx, key, &corev1.Pod{})
+		if err != nil && !errors.IsNotFound(err) {
+			t.Fatalf("Failed to get pod %s: %v", key, err)
+		}
+		return err == nil
+	}
+
+	start := time.Now()
+	evictedPod := newPod("evicted", nil, evicted())
+	preservedPod := newPod("preserved", map[string]string{preserveAnnotation: "true"}, evicted())
+	runningPod := newPod("running", nil, corev1.PodStatus{Phase: corev1.PodRunning})
+
+	err := wait.PollUntilContextTimeout(ctx, 100*time.Millisecond, 30*time.Second, true,
+		func(ctx context.Context) (bool, error) { return !exists(evictedPod), nil })
+	if err != nil {
+		t.Fatalf("Expected the evicted pod to be deleted after its TTL: %v", err)
+	}
+	// StartTime is stored with second precision, so allow a second of slack
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("Expected the evicted pod to wait for its TTL, deleted after %v", elapsed)
+	}
+
+	// Give the reconciler time to act on the other pods wrongly
+	time.Sleep(2 * time.Second)
+	if !exists(preservedPod) {
+		t.Error("Expected the preserved pod to remain")
+	}
+	if !exists(runningPod) {
+		t.Error("Expected the running pod to remain")
+	}
+}
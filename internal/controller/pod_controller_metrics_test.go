@@ -2,17 +2,20 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
 	"github.com/prometheus/client_golang/prometheus"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -349,3 +352,649 @@ func TestPodReconciler_NoMetricsForNonEvictedPods(t *testing.T) {
 		}
 	}
 }
+
+// gatherCounter returns the value of the counter series of a metric family
+// whose label matches the given name and value
+func gatherCounter(t *testing.T, registry *prometheus.Registry, metricName, labelName, labelValue string) float64 {
+	t.Helper()
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+
+	for _, mf := range mfs {
+		if mf.GetName() != metricName {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == labelName && label.GetValue() == labelValue {
+					return m.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	return 0
+}
+
+func TestPodReconciler_ReconcilesTotal(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	evictedStatus := func(age time.Duration) corev1.PodStatus {
+		return corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-age)},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		pod        *corev1.Pod
+		wantResult string
+	}{
+		{
+			name: "deleted",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "deleted", Namespace: "default"},
+				Status:     evictedStatus(10 * time.Minute),
+			},
+			wantResult: metrics.ReconcileDeleted,
+		},
+		{
+			name: "skipped",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "skipped",
+					Namespace:   "default",
+					Annotations: map[string]string{"pod-reaper.kyos.com/preserve": "true"},
+				},
+				Status: evictedStatus(10 * time.Minute),
+			},
+			wantResult: metrics.ReconcileSkipped,
+		},
+		{
+			name: "requeued",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "requeued", Namespace: "default"},
+				Status:     evictedStatus(time.Minute),
+			},
+			wantResult: metrics.ReconcileRequeued,
+		},
+		{
+			name: "noop",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "noop", Namespace: "default"},
+				Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+			},
+			wantResult: metrics.ReconcileNoop,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(tt.pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tt.pod.Name,
+					Namespace: tt.pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			for _, result := range []string{
+				metrics.ReconcileDeleted,
+				metrics.ReconcileSkipped,
+				metrics.ReconcileRequeued,
+				metrics.ReconcileNoop,
+				metrics.ReconcileError,
+			} {
+				want := 0.0
+				if result == tt.wantResult {
+					want = 1
+				}
+				got := gatherCounter(t, registry, "reaper_reconciles_total", "result", result)
+				if got != want {
+					t.Errorf("reaper_reconciles_total{result=%q} = %v, want %v", result, got, want)
+				}
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		podMetrics := metrics.NewPodMetrics()
+		registry := prometheus.NewRegistry()
+		podMetrics.Register(registry)
+
+		r := &PodReconciler{
+			Client:      &errorClient{deleteError: errors.New("delete failed")},
+			Scheme:      scheme,
+			Metrics:     podMetrics,
+			TTLToDelete: 300,
+		}
+
+		req := reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: "error", Namespace: "default"},
+		}
+		if _, err := r.Reconcile(context.Background(), req); err == nil {
+			t.Fatal("Expected Reconcile() to return an error")
+		}
+
+		if got := gatherCounter(t, registry, "reaper_reconciles_total", "result", metrics.ReconcileError); got != 1 {
+			t.Errorf("reaper_reconciles_total{result=\"error\"} = %v, want 1", got)
+		}
+		if got := gatherCounter(t, registry, "reaper_reconciles_total", "result", metrics.ReconcileDeleted); got != 0 {
+			t.Errorf("reaper_reconciles_total{result=\"deleted\"} = %v, want 0", got)
+		}
+	})
+}
+
+func TestPodReconciler_RequeueDrift(t *testing.T) {
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{Metrics: podMetrics}
+	scheduled := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	// Fired 2s late
+	r.scheduledRequeues.Set("late", scheduled)
+	r.observeRequeueDrift("late", scheduled.Add(2*time.Second))
+
+	// Triggered early by a watch event, not drift
+	r.scheduledRequeues.Set("early", scheduled)
+	r.observeRequeueDrift("early", scheduled.Add(-time.Minute))
+
+	// Never scheduled
+	r.observeRequeueDrift("unscheduled", scheduled)
+
+	if r.scheduledRequeues.Len() != 0 {
+		t.Errorf("Expected scheduled requeues to be cleared, %d remain", r.scheduledRequeues.Len())
+	}
+
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, family := range families {
+		if family.GetName() != "evicted_pod_requeue_drift_seconds" {
+			continue
+		}
+		histogram := family.GetMetric()[0].GetHistogram()
+		if histogram.GetSampleCount() != 1 {
+			t.Errorf("drift sample count = %d, want 1", histogram.GetSampleCount())
+		}
+		if histogram.GetSampleSum() != 2 {
+			t.Errorf("drift sample sum = %v, want 2", histogram.GetSampleSum())
+		}
+		return
+	}
+	t.Fatal("evicted_pod_requeue_drift_seconds not found")
+}
+
+func TestPodReconciler_ReapedAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		wantDeletedCount float64
+	}{
+		{
+			name:             "unmarked pod is counted",
+			wantDeletedCount: 1,
+		},
+		{
+			name:             "pod already bearing the reaped marker is not counted again",
+			annotations:      map[string]string{reapedAnnotation: "2026-01-01T00:00:00Z"},
+			wantDeletedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   "test-namespace",
+					Annotations: tt.annotations,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+
+			got := gatherCounter(t, registry, "evicted_pods_deleted_total", "namespace", "test-namespace")
+			if got != tt.wantDeletedCount {
+				t.Errorf("deleted count = %v, want %v", got, tt.wantDeletedCount)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_MarksPodBeforeDeleting(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-pod",
+			Namespace:  "default",
+			Finalizers: []string{"example.com/block"},
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		Build()
+
+	r := &PodReconciler{
+		Client:      fakeClient,
+		Scheme:      scheme,
+		Metrics:     metrics.NewPodMetrics(),
+		TTLToDelete: 300,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	// The finalizer keeps the pod around while terminating
+	terminating := &corev1.Pod{}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, terminating); err != nil {
+		t.Fatalf("Failed to get terminating pod: %v", err)
+	}
+	if !isReaped(terminating) {
+		t.Errorf("Expected terminating pod to carry the %s annotation", reapedAnnotation)
+	}
+}
+
+func TestPodReconciler_LastDeletionInfo(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "test-namespace",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:      fakeClient,
+		Scheme:      scheme,
+		Metrics:     podMetrics,
+		TTLToDelete: 300,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	before := time.Now().Unix()
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	for _, mf := range mfs {
+		if mf.GetName() != "reaper_last_deletion_info" {
+			continue
+		}
+		m := mf.GetMetric()[0]
+		labels := map[string]string{}
+		for _, label := range m.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		if labels["namespace"] != "test-namespace" || labels["reason"] != "Evicted" {
+			t.Errorf("reaper_last_deletion_info labels = %v, want namespace=test-namespace reason=Evicted", labels)
+		}
+		if got := m.GetGauge().GetValue(); got < float64(before) {
+			t.Errorf("reaper_last_deletion_info = %v, want at least %v", got, before)
+		}
+		return
+	}
+	t.Fatal("reaper_last_deletion_info not found after deletion")
+}
+
+func TestPodReconciler_DeletedQoSLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		qos     corev1.PodQOSClass
+		wantQoS string
+	}{
+		{name: "best effort", qos: corev1.PodQOSBestEffort, wantQoS: "BestEffort"},
+		{name: "burstable", qos: corev1.PodQOSBurstable, wantQoS: "Burstable"},
+		{name: "guaranteed", qos: corev1.PodQOSGuaranteed, wantQoS: "Guaranteed"},
+		{name: "not set", wantQoS: "unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					QOSClass:  tt.qos,
+					StartTime: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+				},
+			}
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:            fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build(),
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				TTLToDelete:       300,
+				AllowedNamespaces: []string{"default"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if got := gatherCounter(t, registry, "evicted_pods_deleted_total", "qos", tt.wantQoS); got != 1 {
+				t.Errorf("evicted_pods_deleted_total{qos=%q} = %v, want 1", tt.wantQoS, got)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_SkipReasons(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name      string
+		reason    string
+		configure func(r *PodReconciler, pod *corev1.Pod) []runtime.Object
+	}{
+		{
+			name:   "reaper's own pod",
+			reason: metrics.SkipSelf,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				r.Self = &Identity{Namespace: "default", Labels: map[string]string{selfNameLabel: "evicted-pod-reaper"}}
+				pod.Labels = map[string]string{selfNameLabel: "evicted-pod-reaper"}
+				return nil
+			},
+		},
+		{
+			name:   "namespace outside the safe-mode allow-list",
+			reason: metrics.SkipSafeMode,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				r.SafeMode = true
+				r.AllowedNamespaces = []string{"kube-system"}
+				return nil
+			},
+		},
+		{
+			name:   "priority class filtered out",
+			reason: metrics.SkipPriority,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				r.PriorityClassFilter = []string{"low"}
+				pod.Spec.PriorityClassName = "high"
+				return nil
+			},
+		},
+		{
+			name:   "pod without containers",
+			reason: metrics.SkipEmptySpec,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				r.SkipEmptySpec = true
+				pod.Spec.Containers = nil
+				return nil
+			},
+		},
+		{
+			name:   "preserve annotation",
+			reason: metrics.SkipPreserved,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				pod.Annotations = map[string]string{preserveAnnotation: "true"}
+				return nil
+			},
+		},
+		{
+			name:   "standalone policy preserve",
+			reason: metrics.SkipStandalone,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				r.StandalonePolicy = StandalonePolicyPreserve
+				pod.OwnerReferences = nil
+				return nil
+			},
+		},
+		{
+			name:   "delegated to the Job TTL",
+			reason: metrics.SkipJobTTL,
+			configure: func(r *PodReconciler, pod *corev1.Pod) []runtime.Object {
+				r.UseJobTTL = true
+				pod.OwnerReferences = []metav1.OwnerReference{controllerRef("batch/v1", "Job", "batch", "job-uid")}
+				return []runtime.Object{&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "batch", Namespace: "default", UID: "job-uid"}}}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-pod",
+					Namespace:       "default",
+					OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")},
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+			objs := append(tt.configure(r, pod), pod)
+			r.Client = fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if got := gatherCounter(t, registry, "evicted_pods_skipped_total", "reason", tt.reason); got != 1 {
+				t.Errorf("Expected 1 skip with reason %q, got %v", tt.reason, got)
+			}
+			if got := gatherCounter(t, registry, "reaper_reconciles_total", "result", metrics.ReconcileSkipped); got != 1 {
+				t.Errorf("Expected 1 skipped reconcile, got %v", got)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_PodInfo(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	evictedPod := func(name string, age time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: "node-a", Containers: []corev1.Container{{Name: "app"}}},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-age)},
+			},
+		}
+	}
+	young, old := evictedPod("young", time.Minute), evictedPod("old", 10*time.Minute)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(young, old).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:      fakeClient,
+		Scheme:      scheme,
+		Metrics:     podMetrics,
+		TTLToDelete: 300,
+	}
+	reconcilePod := func(pod *corev1.Pod) {
+		t.Helper()
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+		if _, err := r.Reconcile(context.Background(), req); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+	podInfo := func(name string) map[string]string {
+		t.Helper()
+		mfs, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("Failed to gather metrics: %v", err)
+		}
+		for _, mf := range mfs {
+			if mf.GetName() != "evicted_pod_info" {
+				continue
+			}
+			for _, m := range mf.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range m.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				if labels["pod"] == name {
+					return labels
+				}
+			}
+		}
+		return nil
+	}
+
+	// A pod waiting for its TTL is tracked
+	reconcilePod(young)
+	labels := podInfo("young")
+	if labels == nil {
+		t.Fatal("Expected an evicted_pod_info series for the pod waiting for its TTL")
+	}
+	if labels["namespace"] != "default" || labels["node"] != "node-a" || labels["reason"] != "Evicted" {
+		t.Errorf("evicted_pod_info labels = %v, want namespace=default node=node-a reason=Evicted", labels)
+	}
+
+	// Its series goes away once it is deleted by someone else
+	if err := fakeClient.Delete(context.Background(), young); err != nil {
+		t.Fatalf("Failed to delete pod: %v", err)
+	}
+	r.invalidateFastPathPredicate().Delete(event.DeleteEvent{Object: young})
+	reconcilePod(young)
+	if podInfo("young") != nil {
+		t.Error("Expected no evicted_pod_info series after the pod was deleted")
+	}
+
+	// And when the reaper deletes it
+	reconcilePod(old)
+	if podInfo("old") != nil {
+		t.Error("Expected no evicted_pod_info series after the reaper deleted the pod")
+	}
+}
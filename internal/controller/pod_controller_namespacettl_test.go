@@ -0,0 +1,238 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_NamespaceTTLs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		expectDeleted bool
+	}{
+		{
+			name:          "shorter override deletes early",
+			namespace:     "batch",
+			expectDeleted: true,
+		},
+		{
+			name:      "longer override keeps the pod",
+			namespace: "prod",
+		},
+		{
+			name:          "namespace without override uses the default",
+			namespace:     "default",
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: tt.namespace,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				NamespaceTTLs:     map[string]int{"batch": 60, "prod": 86400},
+				AllowedNamespaces: []string{"default", "batch", "prod"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			if !tt.expectDeleted && result.RequeueAfter < 23*time.Hour {
+				t.Errorf("Expected a requeue against the namespace TTL, got %v", result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_ReasonTTLs(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		namespace     string
+		reason        string
+		expectDeleted bool
+		minRequeue    time.Duration
+	}{
+		{
+			name:          "short reason TTL deletes early",
+			namespace:     "default",
+			reason:        "DeadlineExceeded",
+			expectDeleted: true,
+		},
+		{
+			name:       "long reason TTL requeues",
+			namespace:  "default",
+			reason:     "Evicted",
+			minRequeue: 45 * time.Minute,
+		},
+		{
+			name:          "shutdown pod uses its own reason TTL",
+			namespace:     "default",
+			reason:        "Shutdown",
+			expectDeleted: true,
+		},
+		{
+			name:          "reason TTL takes precedence over the namespace TTL",
+			namespace:     "prod",
+			reason:        "DeadlineExceeded",
+			expectDeleted: true,
+		},
+		{
+			name:       "reason without a TTL falls back to the namespace TTL",
+			namespace:  "prod",
+			reason:     "NodeAffinity",
+			minRequeue: 23 * time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   tt.namespace,
+					Annotations: map[string]string{reasonMatchAnnotation: "Evicted,Shutdown,DeadlineExceeded,NodeAffinity"},
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    tt.reason,
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				NamespaceTTLs:     map[string]int{"prod": 86400},
+				ReasonTTLs:        map[string]int{"Evicted": 3600, "Shutdown": 60, "DeadlineExceeded": 60},
+				AllowedNamespaces: []string{"default", "prod"},
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			if !tt.expectDeleted && result.RequeueAfter < tt.minRequeue {
+				t.Errorf("Expected a requeue of at least %v, got %v", tt.minRequeue, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_OwnerTTLs(t *testing.T) {
+	owned, orphan := 60, 86400
+	owner := []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web-abc123", "rs-uid")}
+
+	tests := []struct {
+		name        string
+		namespace   string
+		reason      string
+		owners      []metav1.OwnerReference
+		annotations map[string]string
+		ownedTTL    *int
+		orphanTTL   *int
+		want        int
+	}{
+		{name: "owned pod", owners: owner, ownedTTL: &owned, orphanTTL: &orphan, want: 60},
+		{name: "orphan pod", ownedTTL: &owned, orphanTTL: &orphan, want: 86400},
+		{name: "owned pod without owned TTL", owners: owner, orphanTTL: &orphan, want: 300},
+		{name: "orphan pod without orphan TTL", ownedTTL: &owned, want: 300},
+		{name: "namespace TTL takes precedence", namespace: "batch", owners: owner, ownedTTL: &owned, want: 30},
+		{name: "reason TTL takes precedence", reason: "DeadlineExceeded", orphanTTL: &orphan, want: 10},
+		{
+			name:        "reap-after takes precedence",
+			annotations: map[string]string{reapAfterAnnotation: "2m"},
+			orphanTTL:   &orphan,
+			want:        120,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace := tt.namespace
+			if namespace == "" {
+				namespace = "default"
+			}
+			reason := tt.reason
+			if reason == "" {
+				reason = "Evicted"
+			}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-pod",
+					Namespace:       namespace,
+					OwnerReferences: tt.owners,
+					Annotations:     tt.annotations,
+				},
+				Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: reason},
+			}
+
+			r := &PodReconciler{
+				TTLToDelete:   300,
+				NamespaceTTLs: map[string]int{"batch": 30},
+				ReasonTTLs:    map[string]int{"DeadlineExceeded": 10},
+				OwnedTTL:      tt.ownedTTL,
+				OrphanTTL:     tt.orphanTTL,
+			}
+			if got := r.ttlFor(pod); got != tt.want {
+				t.Errorf("ttlFor() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TestPodReconciler_ConsecutiveObservations verifies that a pod is only
+// deleted after being seen eligible on two separate reconciles
+func TestPodReconciler_ConsecutiveObservations(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+			UID:       "test-pod-uid",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		Build()
+
+	r := &PodReconciler{
+		Client:                         fakeClient,
+		Scheme:                         scheme,
+		Metrics:                        metrics.NewPodMetrics(),
+		TTLToDelete:                    300,
+		RequireConsecutiveObservations: true,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+
+	// First observation requeues
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != observationConfirmDelay {
+		t.Errorf("First Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, observationConfirmDelay)
+	}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err != nil {
+		t.Fatalf("Expected pod to exist after first observation, but got error: %v", err)
+	}
+	if _, seen := r.observations.Get(pod.UID); !seen {
+		t.Error("Expected first observation to be recorded")
+	}
+
+	// Second observation deletes
+	result, err = r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("Second Reconcile() RequeueAfter = %v, want 0", result.RequeueAfter)
+	}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+		t.Error("Expected pod to be deleted after second observation, but it still exists")
+	}
+	if r.observations.Len() != 0 {
+		t.Errorf("Expected observation to be cleared after deletion, %d remain", r.observations.Len())
+	}
+}
+
+func TestPodReconciler_ConsecutiveObservationsResetOnFlap(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "flapping-pod",
+			Namespace: "default",
+			UID:       "flapping-pod-uid",
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		Build()
+
+	r := &PodReconciler{
+		Client:                         fakeClient,
+		Scheme:                         scheme,
+		Metrics:                        metrics.NewPodMetrics(),
+		TTLToDelete:                    300,
+		RequireConsecutiveObservations: true,
+	}
+	r.observations.Set(pod.UID, time.Now())
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if _, seen := r.observations.Get(pod.UID); seen {
+		t.Error("Expected observation to be cleared once the pod is no longer evicted")
+	}
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_NamespacePrefix(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name            string
+		namespace       string
+		namespacePrefix string
+		watchNamespaces []string
+		expectDeleted   bool
+	}{
+		{
+			name:            "pod in matching namespace is deleted",
+			namespace:       "team-payments",
+			namespacePrefix: "team-",
+			expectDeleted:   true,
+		},
+		{
+			name:            "pod in non-matching namespace is ignored",
+			namespace:       "payments",
+			namespacePrefix: "team-",
+			expectDeleted:   false,
+		},
+		{
+			name:            "pod in explicitly listed namespace is deleted",
+			namespace:       "monitoring",
+			namespacePrefix: "team-",
+			watchNamespaces: []string{"monitoring"},
+			expectDeleted:   true,
+		},
+		{
+			name:          "every namespace is watched without a prefix",
+			namespace:     "payments",
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: tt.namespace,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:          fakeClient,
+				Scheme:          scheme,
+				Metrics:         metrics.NewPodMetrics(),
+				TTLToDelete:     300,
+				NamespacePrefix: tt.namespacePrefix,
+				WatchNamespaces: tt.watchNamespaces,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
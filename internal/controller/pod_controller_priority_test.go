@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+// TestPodReconciler_PriorityFilter verifies that only pods matching the
+// priority class filter and below the max priority are reaped
+func TestPodReconciler_PriorityFilter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name                string
+		priorityClassName   string
+		priority            *int32
+		priorityClassFilter []string
+		maxPriority         *int32
+		expectDeleted       bool
+	}{
+		{
+			name:                "matching priority class is reaped",
+			priorityClassName:   "batch-low",
+			priorityClassFilter: []string{"batch-low", "best-effort"},
+			expectDeleted:       true,
+		},
+		{
+			name:                "non-matching priority class is kept",
+			priorityClassName:   "system-cluster-critical",
+			priorityClassFilter: []string{"batch-low"},
+			expectDeleted:       false,
+		},
+		{
+			name:          "priority below max is reaped",
+			priority:      int32Ptr(100),
+			maxPriority:   int32Ptr(1000),
+			expectDeleted: true,
+		},
+		{
+			name:          "priority at max is kept",
+			priority:      int32Ptr(1000),
+			maxPriority:   int32Ptr(1000),
+			expectDeleted: false,
+		},
+		{
+			name:          "unset priority counts as zero",
+			priority:      nil,
+			maxPriority:   int32Ptr(1),
+			expectDeleted: true,
+		},
+		{
+			name:              "no filters reaps everything",
+			priorityClassName: "system-node-critical",
+			priority:          int32Ptr(2000001000),
+			expectDeleted:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Spec: corev1.PodSpec{
+					PriorityClassName: tt.priorityClassName,
+					Priority:          tt.priority,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:              fakeClient,
+				Scheme:              scheme,
+				Metrics:             metrics.NewPodMetrics(),
+				TTLToDelete:         300,
+				PriorityClassFilter: tt.priorityClassFilter,
+				MaxPriority:         tt.maxPriority,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_ReasonMatchAnnotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		reason        string
+		annotations   map[string]string
+		expectDeleted bool
+	}{
+		{
+			name:          "annotation expands eligibility to a custom reason",
+			reason:        "NodeShutdown",
+			annotations:   map[string]string{reasonMatchAnnotation: "Evicted, NodeShutdown"},
+			expectDeleted: true,
+		},
+		{
+			name:          "custom reason is ignored without the annotation",
+			reason:        "NodeShutdown",
+			expectDeleted: false,
+		},
+		{
+			name:          "annotation restricts eligibility",
+			reason:        "Evicted",
+			annotations:   map[string]string{reasonMatchAnnotation: "NodeShutdown"},
+			expectDeleted: false,
+		},
+		{
+			name:          "empty annotation matches nothing",
+			reason:        "Evicted",
+			annotations:   map[string]string{reasonMatchAnnotation: ""},
+			expectDeleted: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-pod",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    tt.reason,
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			if got := isEvictedPodPredicate(pod); got != tt.expectDeleted {
+				t.Errorf("isEvictedPodPredicate() = %v, want %v", got, tt.expectDeleted)
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     metrics.NewPodMetrics(),
+				TTLToDelete: 300,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
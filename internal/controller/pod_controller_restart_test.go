@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TestPodReconciler_Restart checks that a fresh reconciler picks up where a
+// previous one left off, without the requeues it had scheduled. On startup
+// the informer replays every cached pod as a create event.
+func TestPodReconciler_Restart(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	const ttl = 24 * time.Hour
+	evicted := func(name string, age time.Duration) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-age)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                  string
+		transitionUpdatesOnly bool
+	}{
+		{name: "all updates"},
+		{name: "transition updates only", transitionUpdatesOnly: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(
+					evicted("fresh", time.Hour),
+					evicted("halfway", 12*time.Hour),
+					evicted("expired", 25*time.Hour),
+					&corev1.Pod{
+						ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "default"},
+						Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+					},
+				).
+				Build()
+
+			newReconciler := func() *PodReconciler {
+				return &PodReconciler{
+					Client:                fakeClient,
+					Scheme:                scheme,
+					Metrics:               metrics.NewPodMetrics(),
+					TTLToDelete:           int(ttl.Seconds()),
+					AllowedNamespaces:     []string{"default"},
+					TransitionUpdatesOnly: tt.transitionUpdatesOnly,
+				}
+			}
+
+			// Replay the cache as the informer does on startup and reconcile
+			// what passes the event filters
+			replay := func(r *PodReconciler) map[string]time.Duration {
+				pods := &corev1.PodList{}
+				if err := fakeClient.List(context.Background(), pods); err != nil {
+					t.Fatalf("Failed to list pods: %v", err)
+				}
+				filters := []func(event.CreateEvent) bool{
+					r.invalidateFastPathPredicate().Create,
+					podPredicate(r.isCandidatePodPredicate, r.TransitionUpdatesOnly).Create,
+				}
+				requeues := map[string]time.Duration{}
+				for i := range pods.Items {
+					pod := &pods.Items[i]
+					accepted := true
+					for _, filter := range filters {
+						accepted = accepted && filter(event.CreateEvent{Object: pod})
+					}
+					if !accepted {
+						continue
+					}
+					req := reconcile.Request{NamespacedName: client.ObjectKeyFromObject(pod)}
+					result, err := r.Reconcile(context.Background(), req)
+					if err != nil {
+						t.Fatalf("Reconcile(%s) error = %v", pod.Name, err)
+					}
+					requeues[pod.Name] = result.RequeueAfter
+				}
+				return requeues
+			}
+
+			first := replay(newReconciler())
+			if _, ok := first["running"]; ok {
+				t.Error("Expected the running pod to be filtered out")
+			}
+
+			// The first reconciler's requeues are lost with it
+			time.Sleep(10 * time.Millisecond)
+			second := replay(newReconciler())
+
+			want := map[string]time.Duration{
+				"fresh":   23 * time.Hour,
+				"halfway": 12 * time.Hour,
+			}
+			if len(second) != len(want) {
+				t.Errorf("Expected %d pods reconciled after restart, got %v", len(want), second)
+			}
+			for name, expected := range want {
+				got, ok := second[name]
+				if !ok {
+					t.Errorf("Expected %s to be reconciled after restart", name)
+					continue
+				}
+				if diff := expected - got; diff < 0 || diff > time.Second {
+					t.Errorf("%s RequeueAfter = %v, want about %v", name, got, expected)
+				}
+			}
+
+			err := fakeClient.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "expired"}, &corev1.Pod{})
+			if err == nil {
+				t.Error("Expected the expired pod to be deleted")
+			}
+		})
+	}
+}
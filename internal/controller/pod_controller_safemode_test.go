@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// TestPodReconciler_SafeMode verifies that safe mode only allows deletions
+// in namespaces that are explicitly listed, regardless of what is watched
+func TestPodReconciler_SafeMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		namespace         string
+		safeMode          bool
+		allowedNamespaces []string
+		expectDeleted     bool
+	}{
+		{
+			name:              "safe mode allows deletion in listed namespace",
+			namespace:         "monitoring",
+			safeMode:          true,
+			allowedNamespaces: []string{"default", "monitoring"},
+			expectDeleted:     true,
+		},
+		{
+			name:              "safe mode blocks deletion outside allow-list",
+			namespace:         "kube-system",
+			safeMode:          true,
+			allowedNamespaces: []string{"default", "monitoring"},
+			expectDeleted:     false,
+		},
+		{
+			name:              "safe mode with empty allow-list blocks everything",
+			namespace:         "default",
+			safeMode:          true,
+			allowedNamespaces: nil,
+			expectDeleted:     false,
+		},
+		{
+			name:              "safe mode disabled ignores allow-list",
+			namespace:         "kube-system",
+			safeMode:          false,
+			allowedNamespaces: []string{"default"},
+			expectDeleted:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: tt.namespace,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				SafeMode:          tt.safeMode,
+				AllowedNamespaces: tt.allowedNamespaces,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
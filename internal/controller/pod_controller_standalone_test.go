@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_StandalonePolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		policy        string
+		age           time.Duration
+		owners        []metav1.OwnerReference
+		expectDeleted bool
+		expectRequeue bool
+	}{
+		{
+			name:          "ttl policy deletes ownerless pod past TTL",
+			policy:        StandalonePolicyTTL,
+			age:           10 * time.Minute,
+			expectDeleted: true,
+		},
+		{
+			name:          "ttl policy requeues ownerless pod within TTL",
+			policy:        StandalonePolicyTTL,
+			age:           time.Minute,
+			expectRequeue: true,
+		},
+		{
+			name:          "reap policy deletes ownerless pod within TTL",
+			policy:        StandalonePolicyReap,
+			age:           time.Minute,
+			expectDeleted: true,
+		},
+		{
+			name:   "preserve policy keeps ownerless pod past TTL",
+			policy: StandalonePolicyPreserve,
+			age:    10 * time.Minute,
+		},
+		{
+			name:          "reap policy does not apply to owned pods",
+			policy:        StandalonePolicyReap,
+			age:           time.Minute,
+			owners:        []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")},
+			expectRequeue: true,
+		},
+		{
+			name:          "preserve policy does not apply to owned pods",
+			policy:        StandalonePolicyPreserve,
+			age:           10 * time.Minute,
+			owners:        []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")},
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-pod",
+					Namespace:       "default",
+					OwnerReferences: tt.owners,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-tt.age)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Metrics:          metrics.NewPodMetrics(),
+				TTLToDelete:      300,
+				StandalonePolicy: tt.policy,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
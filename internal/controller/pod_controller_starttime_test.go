@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_SuspiciousStartTime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name           string
+		startTime      time.Time
+		wantSuspicious float64
+	}{
+		{
+			name:           "epoch-zero StartTime",
+			startTime:      time.Unix(0, 0),
+			wantSuspicious: 1,
+		},
+		{
+			// a zero StartTime doesn't survive serialisation, so the
+			// reconciler sees it unset rather than suspicious
+			name:           "zero StartTime",
+			startTime:      time.Time{},
+			wantSuspicious: 0,
+		},
+		{
+			name:           "recent StartTime",
+			startTime:      time.Now().Add(-10 * time.Minute),
+			wantSuspicious: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: tt.startTime},
+				},
+			}
+
+			if age := (&PodReconciler{}).podAge(pod); age <= 0 || age > maxPodAge {
+				t.Errorf("podAge() = %v, want within (0, %v]", age, maxPodAge)
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      pod.Name,
+					Namespace: pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if result.RequeueAfter != 0 {
+				t.Errorf("Reconcile() RequeueAfter = %v, want 0", result.RequeueAfter)
+			}
+
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+
+			got := gatherCounter(t, registry, "evicted_pods_suspicious_starttime_total", "namespace", "default")
+			if got != tt.wantSuspicious {
+				t.Errorf("suspicious StartTime count = %v, want %v", got, tt.wantSuspicious)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_FutureStartTime(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		policy        string
+		startIn       time.Duration
+		expectDeleted bool
+		expectRequeue time.Duration
+	}{
+		{
+			name:          "zero policy requeues for the full TTL",
+			policy:        FutureStartTimePolicyZero,
+			startIn:       time.Hour,
+			expectRequeue: 300 * time.Second,
+		},
+		{
+			name:          "empty policy behaves like zero",
+			startIn:       time.Hour,
+			expectRequeue: 300 * time.Second,
+		},
+		{
+			name:          "creation policy uses the creationTimestamp",
+			policy:        FutureStartTimePolicyCreation,
+			startIn:       time.Hour,
+			expectDeleted: true,
+		},
+		{
+			name:          "skew within tolerance is not a future StartTime",
+			policy:        FutureStartTimePolicyCreation,
+			startIn:       10 * time.Second,
+			expectRequeue: 300 * time.Second,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "test-pod",
+					Namespace:         "default",
+					CreationTimestamp: metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(tt.startIn)},
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:                fakeClient,
+				Scheme:                scheme,
+				Metrics:               metrics.NewPodMetrics(),
+				TTLToDelete:           300,
+				AllowedNamespaces:     []string{"default"},
+				FutureStartTimePolicy: tt.policy,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+			// Allow for the time spent reconciling
+			if diff := tt.expectRequeue - result.RequeueAfter; diff < 0 || diff > time.Second {
+				t.Errorf("Reconcile() RequeueAfter = %v, want about %v", result.RequeueAfter, tt.expectRequeue)
+			}
+		})
+	}
+}
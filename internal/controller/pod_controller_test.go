@@ -13,6 +13,7 @@ import (
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -399,3 +400,74 @@ func TestPodReconciler_EvictedPredicate(t *testing.T) {
 		})
 	}
 }
+
+// TestPodReconciler_TransitionUpdatePredicate tests update filtering on eviction transitions
+func TestPodReconciler_TransitionUpdatePredicate(t *testing.T) {
+	running := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	evicted := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: "Evicted"},
+	}
+	evictedRelabeled := evicted.DeepCopy()
+	evictedRelabeled.Labels = map[string]string{"touched": "true"}
+
+	tests := []struct {
+		name                  string
+		transitionUpdatesOnly bool
+		oldPod                *corev1.Pod
+		newPod                *corev1.Pod
+		want                  bool
+	}{
+		{
+			name:                  "transition into evicted passes",
+			transitionUpdatesOnly: true,
+			oldPod:                running,
+			newPod:                evicted,
+			want:                  true,
+		},
+		{
+			name:                  "update of already evicted pod is filtered",
+			transitionUpdatesOnly: true,
+			oldPod:                evicted,
+			newPod:                evictedRelabeled,
+			want:                  false,
+		},
+		{
+			name:                  "update of non-evicted pod is filtered",
+			transitionUpdatesOnly: true,
+			oldPod:                running,
+			newPod:                running,
+			want:                  false,
+		},
+		{
+			name:                  "update of already evicted pod passes without transition filtering",
+			transitionUpdatesOnly: false,
+			oldPod:                evicted,
+			newPod:                evictedRelabeled,
+			want:                  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := podPredicate(isEvictedPodPredicate, tt.transitionUpdatesOnly)
+			got := p.Update(event.UpdateEvent{ObjectOld: tt.oldPod, ObjectNew: tt.newPod})
+			if got != tt.want {
+				t.Errorf("Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("create events still use the evicted filter", func(t *testing.T) {
+		p := podPredicate(isEvictedPodPredicate, true)
+		if !p.Create(event.CreateEvent{Object: evicted}) {
+			t.Error("Create() for evicted pod = false, want true")
+		}
+		if p.Create(event.CreateEvent{Object: running}) {
+			t.Error("Create() for running pod = true, want false")
+		}
+	})
+}
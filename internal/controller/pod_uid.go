@@ -0,0 +1,24 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// uidPrecondition returns a precondition matching only the pod's UID, so a
+// new pod created under the same name is never removed in its place. It is
+// nil when the pod has no UID.
+func uidPrecondition(pod *corev1.Pod) *metav1.Preconditions {
+	if pod.UID == "" {
+		return nil
+	}
+	uid := pod.UID
+	return &metav1.Preconditions{UID: &uid}
+}
+
+// isPodReplaced checks if a delete was refused because the pod's name now
+// belongs to a pod with another UID
+func isPodReplaced(err error) bool {
+	return errors.IsConflict(err)
+}
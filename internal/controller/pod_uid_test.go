@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_ReplacedPod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name string
+		// replaceOn is the call before which the pod is replaced
+		replaceOn string
+	}{
+		{
+			name:      "replaced before marking",
+			replaceOn: "patch",
+		},
+		{
+			name:      "replaced before deleting",
+			replaceOn: "delete",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newPod := func(uid types.UID) *corev1.Pod {
+				return &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test-pod",
+						Namespace: "default",
+						UID:       uid,
+					},
+					Status: corev1.PodStatus{
+						Phase:     corev1.PodFailed,
+						Reason:    "Evicted",
+						StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+					},
+				}
+			}
+			key := types.NamespacedName{Name: "test-pod", Namespace: "default"}
+
+			replaced := false
+			replace := func(ctx context.Context, c client.Client) {
+				if replaced {
+					return
+				}
+				replaced = true
+				if err := c.Delete(ctx, newPod("old")); err != nil {
+					t.Fatalf("deleting old pod: %v", err)
+				}
+				if err := c.Create(ctx, newPod("new")); err != nil {
+					t.Fatalf("creating new pod: %v", err)
+				}
+			}
+
+			var deleted []types.UID
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(newPod("old")).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if tt.replaceOn == "patch" {
+							replace(ctx, c)
+						}
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if tt.replaceOn == "delete" {
+							replace(ctx, c)
+						}
+						// The fake client ignores UID preconditions, enforce them
+						// like the API server does
+						deleteOpts := &client.DeleteOptions{}
+						deleteOpts.ApplyOptions(opts)
+						current := &corev1.Pod{}
+						if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+							return err
+						}
+						if deleteOpts.Preconditions == nil || deleteOpts.Preconditions.UID == nil {
+							t.Error("Expected the delete to carry a UID precondition")
+						} else if *deleteOpts.Preconditions.UID != current.UID {
+							return apierrors.NewConflict(schema.GroupResource{Resource: "pods"}, obj.GetName(),
+								fmt.Errorf("precondition failed: UID in precondition: %s, UID in object meta: %s",
+									*deleteOpts.Preconditions.UID, current.UID))
+						}
+						deleted = append(deleted, current.UID)
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+			}
+
+			req := reconcile.Request{NamespacedName: key}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if len(deleted) != 0 {
+				t.Errorf("Expected the new pod to be left alone, deleted %v", deleted)
+			}
+			if got := gatherCounter(t, registry, "evicted_pods_deleted_total", "namespace", "default"); got != 0 {
+				t.Errorf("Expected no deletion to be counted, got %v", got)
+			}
+			current := &corev1.Pod{}
+			if err := fakeClient.Get(context.Background(), key, current); err != nil {
+				t.Fatalf("Expected the new pod to exist, got error: %v", err)
+			}
+			if current.UID != "new" {
+				t.Errorf("Expected the new pod, got UID %q", current.UID)
+			}
+			if isReaped(current) {
+				t.Error("Expected the new pod not to be marked as reaped")
+			}
+			// The mark carries the old pod's resourceVersion and is refused
+			if tt.replaceOn == "patch" {
+				if got := gatherUpdateConflicts(t, registry); got != 1 {
+					t.Errorf("Expected the mark to conflict once, got %v conflicts", got)
+				}
+			}
+
+			// The new pod is a candidate of its own
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+			if len(deleted) != 1 || deleted[0] != "new" {
+				t.Errorf("Expected the new pod to be deleted, deleted %v", deleted)
+			}
+			if got := gatherCounter(t, registry, "evicted_pods_deleted_total", "namespace", "default"); got != 1 {
+				t.Errorf("Expected 1 deletion to be counted, got %v", got)
+			}
+		})
+	}
+}
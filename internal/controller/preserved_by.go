@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"encoding/json"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// preservedBy returns the field manager that set a pod's preserve
+// annotation according to its managed fields, such as kubectl-annotate for
+// a human or a controller's name, or "" if it can't be told. When several
+// managers own the annotation, the most recent one is returned.
+func preservedBy(pod *corev1.Pod) string {
+	var manager string
+	var latest int64 = -1
+	for _, entry := range pod.ManagedFields {
+		if entry.FieldsV1 == nil || !ownsAnnotation(entry.FieldsV1.Raw, preserveAnnotation) {
+			continue
+		}
+		var at int64
+		if entry.Time != nil {
+			at = entry.Time.Unix()
+		}
+		if at > latest {
+			manager, latest = entry.Manager, at
+		}
+	}
+	return manager
+}
+
+// ownsAnnotation checks if a managed fields set includes an annotation
+func ownsAnnotation(fields []byte, key string) bool {
+	var set struct {
+		Metadata struct {
+			Annotations map[string]json.RawMessage `json:"f:annotations"`
+		} `json:"f:metadata"`
+	}
+	if err := json.Unmarshal(fields, &set); err != nil {
+		return false
+	}
+	_, ok := set.Metadata.Annotations["f:"+key]
+	return ok
+}
+
+// logPreserved logs that a pod is skipped for its preserve annotation,
+// attributing the annotation to whoever set it when known
+func logPreserved(logger logr.Logger, pod *corev1.Pod, key types.NamespacedName) {
+	values := []any{"pod", key}
+	if manager := preservedBy(pod); manager != "" {
+		values = append(values, "preservedBy", manager)
+	}
+	logger.Info("pod has preserve annotation, skipping deletion", values...)
+}
@@ -0,0 +1,233 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// managedFields returns a managed fields entry owning the given annotations
+func managedFields(manager string, operation metav1.ManagedFieldsOperationType, at time.Time, annotations ...string) metav1.ManagedFieldsEntry {
+	fields := make([]string, len(annotations))
+	for i, key := range annotations {
+		fields[i] = `"f:` + key + `":{}`
+	}
+	return metav1.ManagedFieldsEntry{
+		Manager:    manager,
+		Operation:  operation,
+		APIVersion: "v1",
+		Time:       &metav1.Time{Time: at},
+		FieldsType: "FieldsV1",
+		FieldsV1: &metav1.FieldsV1{
+			Raw: []byte(`{"f:metadata":{"f:annotations":{` + strings.Join(fields, ",") + `}}}`),
+		},
+	}
+}
+
+func TestPreservedBy(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		fields []metav1.ManagedFieldsEntry
+		want   string
+	}{
+		{
+			name: "annotated with kubectl",
+			fields: []metav1.ManagedFieldsEntry{
+				managedFields("kubelet", metav1.ManagedFieldsOperationUpdate, now),
+				managedFields("kubectl-annotate", metav1.ManagedFieldsOperationUpdate, now, preserveAnnotation),
+			},
+			want: "kubectl-annotate",
+		},
+		{
+			name: "applied by a controller",
+			fields: []metav1.ManagedFieldsEntry{
+				managedFields("argocd-controller", metav1.ManagedFieldsOperationApply, now, "team", preserveAnnotation),
+			},
+			want: "argocd-controller",
+		},
+		{
+			name: "most recent of several managers",
+			fields: []metav1.ManagedFieldsEntry{
+				managedFields("kubectl-client-side-apply", metav1.ManagedFieldsOperationUpdate, now, preserveAnnotation),
+				managedFields("kubectl-annotate", metav1.ManagedFieldsOperationUpdate, now.Add(-time.Hour), preserveAnnotation),
+			},
+			want: "kubectl-client-side-apply",
+		},
+		{
+			name: "other annotations only",
+			fields: []metav1.ManagedFieldsEntry{
+				managedFields("kubectl-annotate", metav1.ManagedFieldsOperationUpdate, now, "team"),
+			},
+			want: "",
+		},
+		{
+			name: "no managed fields",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{ManagedFields: tt.fields}}
+			if got := preservedBy(pod); got != tt.want {
+				t.Errorf("preservedBy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_PreservedByLog(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name    string
+		fields  []metav1.ManagedFieldsEntry
+		wantLog string
+	}{
+		{
+			name: "human",
+			fields: []metav1.ManagedFieldsEntry{
+				managedFields("kubectl-annotate", metav1.ManagedFieldsOperationUpdate, time.Now(), preserveAnnotation),
+			},
+			wantLog: `"preservedBy"="kubectl-annotate"`,
+		},
+		{
+			name: "controller",
+			fields: []metav1.ManagedFieldsEntry{
+				managedFields("debug-controller", metav1.ManagedFieldsOperationApply, time.Now(), preserveAnnotation),
+			},
+			wantLog: `"preservedBy"="debug-controller"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "test-pod",
+					Namespace:     "default",
+					Annotations:   map[string]string{preserveAnnotation: "true"},
+					ManagedFields: tt.fields,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			// The fake client manages its own managed fields, so serve the
+			// pod as the API server would have recorded it
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+						if err := c.Get(ctx, key, obj, opts...); err != nil {
+							return err
+						}
+						obj.SetManagedFields(tt.fields)
+						return nil
+					},
+				}).
+				Build()
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     metrics.NewPodMetrics(),
+				TTLToDelete: 300,
+			}
+
+			var logs []string
+			logger := funcr.New(func(prefix, args string) {
+				logs = append(logs, args)
+			}, funcr.Options{})
+			ctx := log.IntoContext(context.Background(), logger)
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(ctx, req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			var skip string
+			for _, line := range logs {
+				if strings.Contains(line, "pod has preserve annotation") {
+					skip = line
+				}
+			}
+			if !strings.Contains(skip, tt.wantLog) {
+				t.Errorf("Expected skip log to contain %s, got %q", tt.wantLog, skip)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_FieldManager(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	var managers []string
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				patchOpts := &client.PatchOptions{}
+				patchOpts.ApplyOptions(opts)
+				managers = append(managers, patchOpts.FieldManager)
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).
+		Build()
+
+	r := &PodReconciler{
+		Client:       fakeClient,
+		Scheme:       scheme,
+		Metrics:      metrics.NewPodMetrics(),
+		TTLToDelete:  300,
+		FieldManager: "evicted-pod-reaper",
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if len(managers) == 0 {
+		t.Fatal("Expected the pod to be patched before deletion")
+	}
+	for _, manager := range managers {
+		if manager != "evicted-pod-reaper" {
+			t.Errorf("Expected patches under field manager evicted-pod-reaper, got %q", manager)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// preservedOverdueSweepInterval is how often preserved pods past the global
+// TTL are counted
+const preservedOverdueSweepInterval = 5 * time.Minute
+
+// isPreservedOverdue returns true if an evicted pod is only kept by its
+// preserve annotation, having outlived the global TTL
+func (r *PodReconciler) isPreservedOverdue(pod *corev1.Pod) bool {
+	if !r.isNamespaceWatched(pod.Namespace) || !r.isPodEvicted(pod) {
+		return false
+	}
+	if action, _ := resolveAnnotations(pod); action != actionPreserve {
+		return false
+	}
+	if _, ok := r.ageStart(pod); !ok {
+		// Without anything to measure from the TTL counts as exceeded
+		return true
+	}
+	return r.podAge(pod) > time.Duration(r.defaultTTL())*time.Second
+}
+
+// sweepPreservedOverdue counts the preserved pods past the global TTL per
+// namespace, so a preserve annotation left on by accident shows up
+func (r *PodReconciler) sweepPreservedOverdue(ctx context.Context) error {
+	pods := &corev1.PodList{}
+	if err := r.List(ctx, pods); err != nil {
+		return err
+	}
+	counts := make(map[string]int)
+	for i := range pods.Items {
+		if r.isPreservedOverdue(&pods.Items[i]) {
+			counts[pods.Items[i].Namespace]++
+		}
+	}
+	r.Metrics.SetPreservedOverdue(counts)
+	return nil
+}
+
+// runPreservedOverdueSweep counts preserved overdue pods every
+// preservedOverdueSweepInterval until the context is cancelled
+func (r *PodReconciler) runPreservedOverdueSweep(ctx context.Context) error {
+	ticker := time.NewTicker(preservedOverdueSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.sweepPreservedOverdue(ctx); err != nil {
+				log.FromContext(ctx).Error(err, "unable to count preserved overdue pods")
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSweepPreservedOverdue(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newPod := func(name, namespace string, age time.Duration, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Annotations: annotations,
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-age)},
+			},
+		}
+	}
+	preserve := map[string]string{preserveAnnotation: "true"}
+
+	running := newPod("running", "team-a", 2*time.Hour, preserve)
+	running.Status = corev1.PodStatus{Phase: corev1.PodRunning, StartTime: running.Status.StartTime}
+	noStartTime := newPod("no-start-time", "team-b", 0, preserve)
+	noStartTime.Status.StartTime = nil
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(
+			newPod("overdue-1", "team-a", 2*time.Hour, preserve),
+			newPod("overdue-2", "team-a", 3*time.Hour, preserve),
+			newPod("young", "team-a", 10*time.Minute, preserve),
+			newPod("not-preserved", "team-a", 2*time.Hour, nil),
+			newPod("reap-now", "team-a", 2*time.Hour, map[string]string{preserveAnnotation: "true", reapNowAnnotation: "true"}),
+			running,
+			noStartTime,
+			newPod("unwatched", "other", 2*time.Hour, preserve),
+		).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:          fakeClient,
+		Scheme:          scheme,
+		Metrics:         podMetrics,
+		TTLToDelete:     3600,
+		NamespacePrefix: "team-",
+	}
+
+	if err := r.sweepPreservedOverdue(context.Background()); err != nil {
+		t.Fatalf("sweepPreservedOverdue() error = %v", err)
+	}
+
+	want := map[string]float64{"team-a": 2, "team-b": 1}
+	got := gatherPreservedOverdue(t, registry)
+	if len(got) != len(want) {
+		t.Errorf("Expected preserved overdue pods %v, got %v", want, got)
+	}
+	for namespace, n := range want {
+		if got[namespace] != n {
+			t.Errorf("Expected %v preserved overdue pods in %s, got %v", n, namespace, got[namespace])
+		}
+	}
+}
+
+func gatherPreservedOverdue(t *testing.T, registry *prometheus.Registry) map[string]float64 {
+	t.Helper()
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	counts := make(map[string]float64)
+	for _, mf := range mfs {
+		if mf.GetName() != "reaper_preserved_overdue_pods" {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "namespace" {
+					counts[label.GetValue()] = m.GetGauge().GetValue()
+				}
+			}
+		}
+	}
+	return counts
+}
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// quarantinedLabel marks a pod held for manual review by ModeQuarantine
+const quarantinedLabel = "pod-reaper.kyos.com/quarantined"
+
+// isQuarantined checks if a pod has already been quarantined
+func isQuarantined(pod *corev1.Pod) bool {
+	return pod.Labels[quarantinedLabel] == "true"
+}
+
+// quarantine labels a pod for manual review in place of deleting it. Pods
+// already labelled are left as they are and not counted again. It returns
+// the reconcile result to report.
+func (r *PodReconciler) quarantine(ctx context.Context, pod *corev1.Pod) (ctrl.Result, string, error) {
+	logger := log.FromContext(ctx)
+	key := client.ObjectKeyFromObject(pod)
+
+	labelled := false
+	err := r.patchWithRetry(ctx, pod, func() bool {
+		if isQuarantined(pod) {
+			return false
+		}
+		if pod.Labels == nil {
+			pod.Labels = make(map[string]string)
+		}
+		pod.Labels[quarantinedLabel] = "true"
+		labelled = true
+		return true
+	})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, metrics.ReconcileNoop, nil
+		}
+		logger.Error(err, "unable to quarantine pod", "pod", key)
+		return ctrl.Result{}, metrics.ReconcileError, fmt.Errorf("quarantining pod %s: %w", key, err)
+	}
+	if !labelled {
+		logger.V(1).Info("pod is already quarantined", "pod", key)
+		return ctrl.Result{}, metrics.ReconcileNoop, nil
+	}
+
+	if !hasNoMetrics(pod) {
+		r.Metrics.IncQuarantined(pod.Namespace)
+	}
+	logger.Info("quarantined pod for manual review", "pod", key)
+	return ctrl.Result{}, metrics.ReconcileQuarantined, nil
+}
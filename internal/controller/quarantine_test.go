@@ -0,0 +1,131 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_QuarantineMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		labels            map[string]string
+		startTime         time.Time
+		expectQuarantined float64
+		expectLabel       bool
+	}{
+		{
+			name:              "eligible pod is labelled",
+			startTime:         time.Now().Add(-10 * time.Minute),
+			expectQuarantined: 1,
+			expectLabel:       true,
+		},
+		{
+			name:        "already quarantined pod is not counted again",
+			labels:      map[string]string{quarantinedLabel: "true"},
+			startTime:   time.Now().Add(-10 * time.Minute),
+			expectLabel: true,
+		},
+		{
+			name:      "pod within its TTL is left alone",
+			startTime: time.Now().Add(-time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+					Labels:    tt.labels,
+				},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: tt.startTime},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						t.Error("Delete called in quarantine mode")
+						return c.Delete(ctx, obj, opts...)
+					},
+					SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+						t.Error("Eviction created in quarantine mode")
+						return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+					},
+				}).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:      fakeClient,
+				Scheme:      scheme,
+				Metrics:     podMetrics,
+				TTLToDelete: 300,
+				Mode:        ModeQuarantine,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			// A second pass over the labelled pod must not count it again
+			for range 2 {
+				if _, err := r.Reconcile(context.Background(), req); err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+			}
+
+			current := &corev1.Pod{}
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, current); err != nil {
+				t.Fatalf("Expected the pod to be kept, got error: %v", err)
+			}
+			if isQuarantined(current) != tt.expectLabel {
+				t.Errorf("Expected quarantined=%v, got labels %v", tt.expectLabel, current.Labels)
+			}
+			if got := gatherCounter(t, registry, "evicted_pods_quarantined_total", "namespace", "default"); got != tt.expectQuarantined {
+				t.Errorf("evicted_pods_quarantined_total = %v, want %v", got, tt.expectQuarantined)
+			}
+			if got := gatherCounter(t, registry, "evicted_pods_deleted_total", "namespace", "default"); got != 0 {
+				t.Errorf("Expected no deletions to be counted, got %v", got)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_QuarantineModeExplain(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app"}}},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+	r := &PodReconciler{TTLToDelete: 300, Mode: ModeQuarantine}
+	if got := r.Decide(pod); got != "would quarantine" {
+		t.Errorf("Decide() = %q, want %q", got, "would quarantine")
+	}
+}
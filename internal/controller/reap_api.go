@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// ReapPath is where the reap API accepts lists of pods to reap
+const ReapPath = "/reap"
+
+const (
+	// maxReapRequestBytes bounds the size of a reap API request body
+	maxReapRequestBytes = 1 << 20
+	// maxReapRequestPods bounds how many pods one reap API request can list
+	maxReapRequestPods = 500
+
+	// reapResultInvalid is reported for entries that aren't namespace/name
+	reapResultInvalid = "invalid"
+)
+
+// reapRequest is the JSON accepted by the reap API
+type reapRequest struct {
+	// Pods lists the pods to reap as namespace/name
+	Pods []string `json:"pods"`
+}
+
+// reapResponse is the JSON returned by the reap API, one result per pod in
+// the order they were listed
+type reapResponse struct {
+	Results []reapResult `json:"results"`
+}
+
+type reapResult struct {
+	Pod    string `json:"pod"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// apiReap marks a reconcile requested through the reap API, which ignores
+// the TTL like the reap-now annotation, and collects its result
+type apiReap struct {
+	result string
+}
+
+type apiReapKey struct{}
+
+func apiReapFrom(ctx context.Context) *apiReap {
+	reap, _ := ctx.Value(apiReapKey{}).(*apiReap)
+	return reap
+}
+
+// reapNow reconciles a pod ignoring its TTL, returning the reconcile result.
+// Every other check applies, so preserved pods are still skipped.
+func (r *PodReconciler) reapNow(ctx context.Context, key types.NamespacedName) (string, error) {
+	reap := &apiReap{result: metrics.ReconcileNoop}
+	_, err := r.Reconcile(context.WithValue(ctx, apiReapKey{}, reap), ctrl.Request{NamespacedName: key})
+	return reap.result, err
+}
+
+// ReapHandler serves the reap API, reaping the eligible pods of a list
+// right away. Requests must carry the token as a bearer token.
+func (r *PodReconciler) ReapHandler(token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		bearer, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var body reapRequest
+		if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, maxReapRequestBytes)).Decode(&body); err != nil {
+			http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body.Pods) > maxReapRequestPods {
+			http.Error(w, "too many pods in one request", http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		logger := ctrl.LoggerFrom(req.Context())
+		resp := reapResponse{Results: make([]reapResult, 0, len(body.Pods))}
+		for _, pod := range body.Pods {
+			namespace, name, ok := strings.Cut(pod, "/")
+			if !ok || namespace == "" || name == "" {
+				resp.Results = append(resp.Results, reapResult{Pod: pod, Result: reapResultInvalid})
+				continue
+			}
+			key := types.NamespacedName{Namespace: namespace, Name: name}
+			result, err := r.reapNow(req.Context(), key)
+			res := reapResult{Pod: key.String(), Result: result}
+			if err != nil {
+				res.Error = err.Error()
+			}
+			logger.Info("reap requested through the API", "pod", key, "result", result)
+			resp.Results = append(resp.Results, res)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
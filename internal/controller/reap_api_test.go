@@ -0,0 +1,112 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestPodReconciler_ReapHandler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	newPod := func(name string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			},
+		}
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(
+			newPod("young-pod", nil),
+			newPod("preserved-pod", map[string]string{preserveAnnotation: "true"}),
+		).
+		Build()
+
+	r := &PodReconciler{
+		Client:      fakeClient,
+		Scheme:      scheme,
+		Metrics:     metrics.NewPodMetrics(),
+		TTLToDelete: 3600,
+	}
+
+	post := func(token, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodPost, ReapPath, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		r.ReapHandler("secret").ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := post("", `{"pods":["default/young-pod"]}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 without a token, got %d", rec.Code)
+	}
+	if rec := post("wrong", `{"pods":["default/young-pod"]}`); rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 with the wrong token, got %d", rec.Code)
+	}
+	if rec := post("secret", `not json`); rec.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid body, got %d", rec.Code)
+	}
+	rec := httptest.NewRecorder()
+	r.ReapHandler("secret").ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReapPath, nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected 405 for GET, got %d", rec.Code)
+	}
+
+	rec = post("secret", `{"pods":["default/young-pod","default/preserved-pod","default/missing-pod","no-namespace"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp reapResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	want := []reapResult{
+		{Pod: "default/young-pod", Result: metrics.ReconcileDeleted},
+		{Pod: "default/preserved-pod", Result: metrics.ReconcileSkipped},
+		{Pod: "default/missing-pod", Result: metrics.ReconcileNoop},
+		{Pod: "no-namespace", Result: reapResultInvalid},
+	}
+	if len(resp.Results) != len(want) {
+		t.Fatalf("Expected %d results, got %+v", len(want), resp.Results)
+	}
+	for i, w := range want {
+		if resp.Results[i] != w {
+			t.Errorf("result %d = %+v, want %+v", i, resp.Results[i], w)
+		}
+	}
+
+	pod := &corev1.Pod{}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "young-pod", Namespace: "default"}, pod); err == nil {
+		t.Error("Expected the listed pod to be deleted despite its TTL")
+	}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Name: "preserved-pod", Namespace: "default"}, pod); err != nil {
+		t.Errorf("Expected the preserved pod to remain: %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	reaperv1alpha1 "github.com/kyosenergy-engineering/evicted-pod-reaper/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+//+kubebuilder:rbac:groups=pod-reaper.kyos.com,resources=reaperconfigs,verbs=get;list;watch
+
+// ReaperConfigReconciler applies a ReaperConfig to a running PodReconciler,
+// restoring its startup settings when the ReaperConfig is deleted
+type ReaperConfigReconciler struct {
+	client.Client
+
+	// Key names the ReaperConfig to apply, others are ignored
+	Key types.NamespacedName
+
+	// Target is the reconciler the settings are applied to
+	Target *PodReconciler
+
+	// OnReload, if set, is called with the live settings a reload replaced
+	// and the ones replacing them, nil standing for the environment settings
+	OnReload func(ctx context.Context, old, new *LiveConfig)
+}
+
+// Reconcile loads the ReaperConfig and applies it to the target
+func (r *ReaperConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+	previous := r.Target.liveConfig.Load()
+
+	cfg := &reaperv1alpha1.ReaperConfig{}
+	if err := r.Get(ctx, r.Key, cfg); err != nil {
+		if errors.IsNotFound(err) {
+			if previous != nil {
+				logger.Info("ReaperConfig removed, restoring settings from the environment", "reaperConfig", r.Key)
+			}
+			r.Target.SetLiveConfig(nil)
+			return ctrl.Result{}, r.reloaded(ctx, previous, nil)
+		}
+		return ctrl.Result{}, fmt.Errorf("getting ReaperConfig %s: %w", r.Key, err)
+	}
+
+	live := liveConfigFrom(cfg.Spec)
+	r.Target.SetLiveConfig(live)
+	logger.Info("applied ReaperConfig", "reaperConfig", r.Key, "generation", cfg.Generation,
+		"enabled", !live.Disabled, "ttlToDelete", r.Target.defaultTTL(),
+		"namespaces", live.Namespaces, "reasons", live.Reasons)
+	return ctrl.Result{}, r.reloaded(ctx, previous, live)
+}
+
+// reloaded reports a change of the live settings to OnReload and queues the
+// pods again, as ones skipped under the old settings may now be eligible
+func (r *ReaperConfigReconciler) reloaded(ctx context.Context, old, new *LiveConfig) error {
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+	if r.OnReload != nil {
+		r.OnReload(ctx, old, new)
+	}
+	if err := r.Target.requeueCandidates(ctx); err != nil {
+		return fmt.Errorf("requeuing pods after reloading ReaperConfig %s: %w", r.Key, err)
+	}
+	return nil
+}
+
+// liveConfigFrom converts a ReaperConfig spec to the settings it overrides
+func liveConfigFrom(spec reaperv1alpha1.ReaperConfigSpec) *LiveConfig {
+	live := &LiveConfig{
+		Disabled:   spec.Enabled != nil && !*spec.Enabled,
+		Namespaces: parseNames(spec.Namespaces),
+		Reasons:    parseNames(spec.Reasons),
+	}
+	if spec.TTLSeconds != nil && *spec.TTLSeconds >= 0 {
+		ttl := int(*spec.TTLSeconds)
+		live.TTLToDelete = &ttl
+	}
+	return live
+}
+
+// parseNames drops blank entries from a list of names
+func parseNames(names []string) []string {
+	var parsed []string
+	for _, name := range names {
+		if name != "" {
+			parsed = append(parsed, name)
+		}
+	}
+	return parsed
+}
+
+// SetupWithManager sets up the controller with the Manager, watching only
+// the configured ReaperConfig. It is reconciled once at startup even if it
+// doesn't exist, so the target knows its settings are loaded.
+func (r *ReaperConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	name := "reaperconfig"
+	if r.Target.ControllerName != "" {
+		name = r.Target.ControllerName + "-config"
+	}
+	initial := make(chan event.GenericEvent, 1)
+	initial <- event.GenericEvent{Object: &reaperv1alpha1.ReaperConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: r.Key.Namespace, Name: r.Key.Name},
+	}}
+	close(initial)
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&reaperv1alpha1.ReaperConfig{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return obj.GetNamespace() == r.Key.Namespace && obj.GetName() == r.Key.Name
+		}))).
+		WatchesRawSource(source.Channel(initial, &handler.EnqueueRequestForObject{})).
+		Named(name).
+		Complete(r)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	reaperv1alpha1 "github.com/kyosenergy-engineering/evicted-pod-reaper/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReaperConfigReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = reaperv1alpha1.AddToScheme(scheme)
+
+	key := types.NamespacedName{Namespace: "reaper", Name: "config"}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+	target := &PodReconciler{TTLToDelete: 300}
+	var reloads [][2]*LiveConfig
+	r := &ReaperConfigReconciler{
+		Client: fakeClient,
+		Key:    key,
+		Target: target,
+		OnReload: func(_ context.Context, old, new *LiveConfig) {
+			reloads = append(reloads, [2]*LiveConfig{old, new})
+		},
+	}
+
+	ctx := context.Background()
+	reconcileConfig := func() {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+	}
+
+	// Without a ReaperConfig the environment settings apply
+	reconcileConfig()
+	if target.liveConfig.Load() != nil {
+		t.Fatal("Expected no live config without a ReaperConfig")
+	}
+	if len(reloads) != 0 {
+		t.Fatalf("OnReload called %d times without a ReaperConfig, want 0", len(reloads))
+	}
+
+	enabled, ttl := false, int32(60)
+	cfg := &reaperv1alpha1.ReaperConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec: reaperv1alpha1.ReaperConfigSpec{
+			Enabled:    &enabled,
+			TTLSeconds: &ttl,
+			Namespaces: []string{"team-a", ""},
+			Reasons:    []string{"Evicted", "Shutdown"},
+		},
+	}
+	if err := fakeClient.Create(ctx, cfg); err != nil {
+		t.Fatalf("Failed to create ReaperConfig: %v", err)
+	}
+	reconcileConfig()
+	live := target.live()
+	if !live.Disabled {
+		t.Error("Expected reaping to be disabled")
+	}
+	if got := target.defaultTTL(); got != 60 {
+		t.Errorf("defaultTTL() = %d, want 60", got)
+	}
+	if !slices.Equal(live.Namespaces, []string{"team-a"}) {
+		t.Errorf("Namespaces = %v, want [team-a]", live.Namespaces)
+	}
+	if !slices.Equal(live.Reasons, []string{"Evicted", "Shutdown"}) {
+		t.Errorf("Reasons = %v, want [Evicted Shutdown]", live.Reasons)
+	}
+	if len(reloads) != 1 || reloads[0][0] != nil || reloads[0][1] != live {
+		t.Errorf("OnReload calls = %v, want one from the environment settings to %v", reloads, live)
+	}
+
+	// Updates are applied, unset fields fall back to the environment
+	cfg.Spec = reaperv1alpha1.ReaperConfigSpec{Reasons: []string{"Shutdown"}}
+	if err := fakeClient.Update(ctx, cfg); err != nil {
+		t.Fatalf("Failed to update ReaperConfig: %v", err)
+	}
+	reconcileConfig()
+	live = target.live()
+	if live.Disabled {
+		t.Error("Expected reaping to be enabled when enabled is unset")
+	}
+	if got := target.defaultTTL(); got != 300 {
+		t.Errorf("defaultTTL() = %d, want the environment TTL 300", got)
+	}
+	if len(live.Namespaces) != 0 {
+		t.Errorf("Namespaces = %v, want none", live.Namespaces)
+	}
+
+	// Deleting it restores the environment settings
+	if err := fakeClient.Delete(ctx, cfg); err != nil {
+		t.Fatalf("Failed to delete ReaperConfig: %v", err)
+	}
+	reconcileConfig()
+	if target.liveConfig.Load() != nil {
+		t.Error("Expected no live config after the ReaperConfig was deleted")
+	}
+	if len(reloads) != 3 || reloads[2][0] != live || reloads[2][1] != nil {
+		t.Errorf("OnReload calls = %v, want the last from %v to the environment settings", reloads, live)
+	}
+}
+
+func TestReaperConfigReconciler_RequeuesPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = reaperv1alpha1.AddToScheme(scheme)
+
+	failed := func(name, reason string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Status:     corev1.PodStatus{Phase: corev1.PodFailed, Reason: reason},
+		}
+	}
+	key := types.NamespacedName{Namespace: "reaper", Name: "config"}
+	cfg := &reaperv1alpha1.ReaperConfig{
+		ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name},
+		Spec:       reaperv1alpha1.ReaperConfigSpec{Reasons: []string{"Shutdown"}},
+	}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(cfg, failed("evicted", "Evicted"), failed("shutdown", "Shutdown")).
+		Build()
+
+	target := &PodReconciler{Client: fakeClient, TTLToDelete: 300, liveReloads: make(chan event.GenericEvent, 10)}
+	r := &ReaperConfigReconciler{Client: fakeClient, Key: key, Target: target}
+
+	ctx := context.Background()
+	reconcileConfig := func() []string {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		var queued []string
+		for len(target.liveReloads) > 0 {
+			queued = append(queued, (<-target.liveReloads).Object.GetName())
+		}
+		return queued
+	}
+
+	// Pods the reasons newly make eligible are queued, they had no event
+	if got := reconcileConfig(); !slices.Equal(got, []string{"shutdown"}) {
+		t.Errorf("queued %v, want [shutdown]", got)
+	}
+	// Nothing changed
+	if got := reconcileConfig(); len(got) != 0 {
+		t.Errorf("queued %v on an unchanged ReaperConfig, want none", got)
+	}
+}
@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// scheduledDeletionAnnotation tells users when the reaper plans to delete a
+// pod, set with AnnotateSchedule
+const scheduledDeletionAnnotation = "pod-reaper.kyos.com/scheduled-deletion"
+
+// scheduleTolerance is how far the planned deletion time may move before the
+// annotation is rewritten, so requeues don't churn the pod
+const scheduleTolerance = time.Minute
+
+// annotateSchedule sets the scheduled-deletion annotation on a pod, unless it
+// already holds a time within scheduleTolerance of at
+func (r *PodReconciler) annotateSchedule(ctx context.Context, pod *corev1.Pod, at time.Time) error {
+	return r.patchWithRetry(ctx, pod, func() bool {
+		if current, err := time.Parse(time.RFC3339, pod.Annotations[scheduledDeletionAnnotation]); err == nil {
+			if drift := current.Sub(at); drift > -scheduleTolerance && drift < scheduleTolerance {
+				return false
+			}
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string)
+		}
+		pod.Annotations[scheduledDeletionAnnotation] = at.UTC().Format(time.RFC3339)
+		return true
+	})
+}
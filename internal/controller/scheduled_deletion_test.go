@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_AnnotateSchedule(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	startTime := time.Now().Add(-time.Minute).Truncate(time.Second)
+	due := startTime.Add(300 * time.Second)
+
+	tests := []struct {
+		name         string
+		annotation   string
+		disabled     bool
+		expectPatch  bool
+		expectAround time.Time
+	}{
+		{name: "annotates the deletion time", expectPatch: true, expectAround: due},
+		{
+			name:         "leaves a close enough time alone",
+			annotation:   due.Add(20 * time.Second).UTC().Format(time.RFC3339),
+			expectAround: due.Add(20 * time.Second),
+		},
+		{
+			name:         "rewrites a time that moved",
+			annotation:   due.Add(time.Hour).UTC().Format(time.RFC3339),
+			expectPatch:  true,
+			expectAround: due,
+		},
+		{
+			name:         "rewrites an invalid time",
+			annotation:   "soon",
+			expectPatch:  true,
+			expectAround: due,
+		},
+		{name: "disabled", disabled: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: startTime},
+				},
+			}
+			if tt.annotation != "" {
+				pod.Annotations = map[string]string{scheduledDeletionAnnotation: tt.annotation}
+			}
+
+			var patches int
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						patches++
+						return c.Patch(ctx, obj, patch, opts...)
+					},
+				}).
+				Build()
+
+			r := &PodReconciler{
+				Client:           fakeClient,
+				Scheme:           scheme,
+				Metrics:          metrics.NewPodMetrics(),
+				TTLToDelete:      300,
+				AnnotateSchedule: !tt.disabled,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			// Later requeues must not rewrite the annotation
+			for range 3 {
+				result, err := r.Reconcile(context.Background(), req)
+				if err != nil {
+					t.Fatalf("Reconcile() error = %v", err)
+				}
+				if result.RequeueAfter == 0 {
+					t.Fatal("Expected the pod to be requeued until its TTL expires")
+				}
+			}
+
+			want := 0
+			if tt.expectPatch {
+				want = 1
+			}
+			if patches != want {
+				t.Errorf("Expected %d patches, got %d", want, patches)
+			}
+
+			current := &corev1.Pod{}
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, current); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			value, ok := current.Annotations[scheduledDeletionAnnotation]
+			if tt.disabled {
+				if ok {
+					t.Errorf("Expected no scheduled deletion annotation, got %q", value)
+				}
+				return
+			}
+			at, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				t.Fatalf("Expected an RFC3339 scheduled deletion, got %q", value)
+			}
+			if drift := at.Sub(tt.expectAround); drift < -2*time.Second || drift > 2*time.Second {
+				t.Errorf("Scheduled deletion = %v, want about %v", at, tt.expectAround)
+			}
+		})
+	}
+}
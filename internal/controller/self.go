@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// selfNameLabel is the well-known label identifying the reaper's own pods
+// when its pod can't be looked up
+const selfNameLabel = "app.kubernetes.io/name"
+
+// Identity describes the reaper's own pods, which are never reaped even if
+// the reaper is pointed at its own namespace
+type Identity struct {
+	// Namespace the reaper runs in
+	Namespace string
+
+	// OwnerUID is the controller owner of the reaper's pod, normally its
+	// ReplicaSet, shared by every replica
+	OwnerUID types.UID
+
+	// Labels all have to match for a pod to be considered the reaper's own
+	Labels map[string]string
+}
+
+// ResolveIdentity builds the reaper's identity from its own pod, as given by
+// the downward API. Without a pod name, or if the pod can't be read, it falls
+// back to matching the well-known name label.
+func ResolveIdentity(ctx context.Context, c client.Reader, namespace, name string) (*Identity, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+	self := &Identity{
+		Namespace: namespace,
+		Labels:    map[string]string{selfNameLabel: "evicted-pod-reaper"},
+	}
+	if name == "" {
+		return self, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, pod); err != nil {
+		return self, err
+	}
+	if owner := ownerRef(pod.OwnerReferences); owner != nil {
+		self.OwnerUID = owner.UID
+	}
+	if v, ok := pod.Labels[selfNameLabel]; ok {
+		self.Labels = map[string]string{selfNameLabel: v}
+		if instance, ok := pod.Labels["app.kubernetes.io/instance"]; ok {
+			self.Labels["app.kubernetes.io/instance"] = instance
+		}
+	}
+	return self, nil
+}
+
+// isSelf checks if a pod is one of the reaper's own, by owner or labels
+func (r *PodReconciler) isSelf(pod *corev1.Pod) bool {
+	if r.Self == nil || pod.Namespace != r.Self.Namespace {
+		return false
+	}
+	if r.Self.OwnerUID != "" {
+		for _, ref := range pod.OwnerReferences {
+			if ref.UID == r.Self.OwnerUID {
+				return true
+			}
+		}
+	}
+	if len(r.Self.Labels) == 0 {
+		return false
+	}
+	for k, v := range r.Self.Labels {
+		if pod.Labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestResolveIdentity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	reaper := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reaper-abc",
+			Namespace: "reaper-system",
+			Labels: map[string]string{
+				"app.kubernetes.io/name":     "reaper",
+				"app.kubernetes.io/instance": "prod",
+			},
+			OwnerReferences: []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "reaper-rs", "rs-uid")},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(reaper).Build()
+
+	self, err := ResolveIdentity(context.Background(), fakeClient, "reaper-system", "reaper-abc")
+	if err != nil {
+		t.Fatalf("ResolveIdentity() error = %v", err)
+	}
+	if self.OwnerUID != "rs-uid" {
+		t.Errorf("OwnerUID = %q, want rs-uid", self.OwnerUID)
+	}
+	if self.Labels["app.kubernetes.io/name"] != "reaper" || self.Labels["app.kubernetes.io/instance"] != "prod" {
+		t.Errorf("Labels = %v, want the pod's name and instance labels", self.Labels)
+	}
+
+	// Unknown pods fall back to the well-known label
+	self, err = ResolveIdentity(context.Background(), fakeClient, "reaper-system", "missing")
+	if err == nil {
+		t.Error("Expected an error looking up a missing pod")
+	}
+	if self == nil || self.Labels[selfNameLabel] != "evicted-pod-reaper" {
+		t.Errorf("Expected the well-known label fallback, got %+v", self)
+	}
+
+	if self, _ := ResolveIdentity(context.Background(), fakeClient, "", ""); self != nil {
+		t.Errorf("Expected no identity without a namespace, got %+v", self)
+	}
+}
+
+func TestPodReconciler_NeverReapsSelf(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	self := &Identity{
+		Namespace: "reaper-system",
+		OwnerUID:  "rs-uid",
+		Labels:    map[string]string{selfNameLabel: "evicted-pod-reaper"},
+	}
+
+	tests := []struct {
+		name          string
+		namespace     string
+		labels        map[string]string
+		owners        []metav1.OwnerReference
+		expectDeleted bool
+	}{
+		{
+			name:      "replica of the reaper is kept",
+			namespace: "reaper-system",
+			owners:    []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "reaper-rs", "rs-uid")},
+		},
+		{
+			name:      "pod with the reaper's label is kept",
+			namespace: "reaper-system",
+			labels:    map[string]string{selfNameLabel: "evicted-pod-reaper"},
+		},
+		{
+			name:          "other pod in the reaper's namespace is deleted",
+			namespace:     "reaper-system",
+			labels:        map[string]string{selfNameLabel: "something-else"},
+			owners:        []metav1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "other-rs", "other-uid")},
+			expectDeleted: true,
+		},
+		{
+			name:          "same label in another namespace is deleted",
+			namespace:     "default",
+			labels:        map[string]string{selfNameLabel: "evicted-pod-reaper"},
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "test-pod",
+					Namespace:       tt.namespace,
+					Labels:          tt.labels,
+					OwnerReferences: tt.owners,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           metrics.NewPodMetrics(),
+				TTLToDelete:       300,
+				AllowedNamespaces: []string{"default", "reaper-system"},
+				StandalonePolicy:  StandalonePolicyReap,
+				Self:              self,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: tt.namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("Expected deleted=%v, got %v", tt.expectDeleted, deleted)
+			}
+		})
+	}
+}
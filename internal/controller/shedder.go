@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	// shedMinSamples is the minimum number of API calls in the window
+	// before the error rate is considered meaningful
+	shedMinSamples = 10
+	shedBaseDelay  = 5 * time.Second
+	shedMaxDelay   = 5 * time.Minute
+)
+
+// LoadShedder tracks the outcome of recent API calls and sheds deletion work
+// while the rate of throttling errors exceeds a threshold. The requeue delay
+// doubles for every window shedding stays engaged, and every time it engages
+// again before a full window of calls went by without throttling errors.
+type LoadShedder struct {
+	threshold float64
+	window    time.Duration
+
+	mu       sync.Mutex
+	samples  []shedSample
+	shedding bool
+	since    time.Time
+	// level is how many times the delay has doubled across engagements,
+	// kept until calls have gone a full window without throttling errors
+	// since cleanSince
+	level      int
+	backingOff bool
+	cleanSince time.Time
+}
+
+type shedSample struct {
+	at     time.Time
+	failed bool
+}
+
+// NewLoadShedder creates a LoadShedder that engages when more than threshold
+// (0-1) of the API calls made within window failed with throttling errors
+func NewLoadShedder(threshold float64, window time.Duration) *LoadShedder {
+	return &LoadShedder{
+		threshold: threshold,
+		window:    window,
+	}
+}
+
+// Record records the outcome of an API call
+func (s *LoadShedder) Record(now time.Time, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	failed := isThrottlingError(err)
+	s.samples = append(s.samples, shedSample{at: now, failed: failed})
+	s.prune(now)
+	s.update(now)
+
+	switch {
+	case failed || s.shedding:
+		s.cleanSince = time.Time{}
+	case s.cleanSince.IsZero():
+		s.cleanSince = now
+	case now.Sub(s.cleanSince) >= s.window:
+		s.backingOff, s.level = false, 0
+	}
+}
+
+// Check reports whether new work should be shed and how long to delay it
+func (s *LoadShedder) Check(now time.Time) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prune(now)
+	s.update(now)
+
+	if !s.shedding {
+		return 0, false
+	}
+	delay := shedBaseDelay
+	for i := 0; i < s.level && delay < shedMaxDelay; i++ {
+		delay *= 2
+	}
+	for elapsed := now.Sub(s.since); elapsed >= s.window && delay < shedMaxDelay; elapsed -= s.window {
+		delay *= 2
+	}
+	return min(delay, shedMaxDelay), true
+}
+
+// Shedding reports whether shedding is currently engaged
+func (s *LoadShedder) Shedding() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shedding
+}
+
+// update engages or disengages shedding based on the samples in the window
+func (s *LoadShedder) update(now time.Time) {
+	engaged := len(s.samples) >= shedMinSamples && s.errorRate() > s.threshold
+	if engaged && !s.shedding {
+		s.since = now
+		if s.backingOff {
+			s.level++
+		}
+		s.backingOff = true
+	}
+	s.shedding = engaged
+}
+
+func (s *LoadShedder) prune(now time.Time) {
+	cutoff := now.Add(-s.window)
+	i := 0
+	for i < len(s.samples) && s.samples[i].at.Before(cutoff) {
+		i++
+	}
+	s.samples = s.samples[i:]
+}
+
+func (s *LoadShedder) errorRate() float64 {
+	failed := 0
+	for _, sample := range s.samples {
+		if sample.failed {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(s.samples))
+}
+
+// isThrottlingError reports whether an API error indicates the API server is
+// overloaded
+func isThrottlingError(err error) bool {
+	return errors.IsTooManyRequests(err) ||
+		errors.IsServerTimeout(err) ||
+		errors.IsTimeout(err) ||
+		errors.IsServiceUnavailable(err)
+}
+
+// recordAPIWrite feeds the outcome of a write to the API server to the
+// Shedder, if set
+func (r *PodReconciler) recordAPIWrite(err error) {
+	if r.Shedder == nil {
+		return
+	}
+	r.Shedder.Record(time.Now(), err)
+	r.Metrics.SetShedding(r.Shedder.Shedding())
+}
@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestLoadShedder_EngagesAndDisengages(t *testing.T) {
+	s := NewLoadShedder(0.5, time.Minute)
+	now := time.Now()
+	throttled := apierrors.NewTooManyRequests("slow down", 1)
+
+	// Too few samples to judge
+	for i := 0; i < shedMinSamples-1; i++ {
+		s.Record(now, throttled)
+	}
+	if _, shed := s.Check(now); shed {
+		t.Fatal("Check() shed before reaching the minimum sample count")
+	}
+
+	// High error rate engages shedding, the delay only growing with time
+	s.Record(now, throttled)
+	if !s.Shedding() {
+		t.Error("Shedding() = false after recording enough throttling errors")
+	}
+	for i := 0; i < 3; i++ {
+		delay, shed := s.Check(now)
+		if !shed || delay != shedBaseDelay {
+			t.Fatalf("Check() = (%v, %v), want (%v, true)", delay, shed, shedBaseDelay)
+		}
+	}
+
+	// No calls are made while shedding, so the failures leave the window
+	later := now.Add(time.Minute + time.Second)
+	if delay, shed := s.Check(later); shed {
+		t.Fatalf("Check() = (%v, true), want shedding to disengage", delay)
+	}
+
+	// Engaging again before a clean window keeps backing off
+	for i := 0; i < shedMinSamples; i++ {
+		s.Record(later, throttled)
+	}
+	if delay, shed := s.Check(later); !shed || delay != 2*shedBaseDelay {
+		t.Fatalf("Check() = (%v, %v), want (%v, true)", delay, shed, 2*shedBaseDelay)
+	}
+
+	// Once calls succeed for a full window, shedding disengages without
+	// waiting for a Check and the delay starts over
+	later = later.Add(2 * time.Minute)
+	for i := 0; i <= shedMinSamples; i++ {
+		s.Record(later.Add(time.Duration(i)*time.Minute/shedMinSamples), nil)
+	}
+	if s.Shedding() {
+		t.Error("Shedding() = true after disengaging")
+	}
+	later = later.Add(2 * time.Minute)
+	for i := 0; i < shedMinSamples; i++ {
+		s.Record(later, throttled)
+	}
+	if delay, shed := s.Check(later); !shed || delay != shedBaseDelay {
+		t.Fatalf("Check() = (%v, %v), want the delay to start over at %v", delay, shed, shedBaseDelay)
+	}
+}
+
+func TestLoadShedder_IgnoresNonThrottlingErrors(t *testing.T) {
+	s := NewLoadShedder(0.5, time.Minute)
+	now := time.Now()
+
+	for i := 0; i < 2*shedMinSamples; i++ {
+		s.Record(now, errors.New("forbidden"))
+	}
+	if _, shed := s.Check(now); shed {
+		t.Error("Check() shed on errors that are not throttling related")
+	}
+}
+
+func TestLoadShedder_DelayIsCapped(t *testing.T) {
+	s := NewLoadShedder(0.1, time.Minute)
+	now := time.Now()
+
+	var delay time.Duration
+	for i := 0; i < 20; i++ {
+		at := now.Add(time.Duration(i) * time.Minute)
+		for j := 0; j < shedMinSamples; j++ {
+			s.Record(at, apierrors.NewServiceUnavailable("overloaded"))
+		}
+		delay, _ = s.Check(at)
+	}
+	if delay != shedMaxDelay {
+		t.Errorf("Check() delay = %v, want cap %v", delay, shedMaxDelay)
+	}
+}
+
+func TestPodReconciler_Shedding(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	shedder := NewLoadShedder(0.5, time.Minute)
+	for i := 0; i < shedMinSamples; i++ {
+		shedder.Record(time.Now(), apierrors.NewTooManyRequests("slow down", 1))
+	}
+
+	r := &PodReconciler{
+		Client:      fakeClient,
+		Scheme:      scheme,
+		Metrics:     podMetrics,
+		TTLToDelete: 300,
+		Shedder:     shedder,
+	}
+
+	req := reconcile.Request{
+		NamespacedName: types.NamespacedName{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter != shedBaseDelay {
+		t.Errorf("Reconcile() RequeueAfter = %v, want %v", result.RequeueAfter, shedBaseDelay)
+	}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{}); err != nil {
+		t.Errorf("Expected pod to exist while shedding, but got error: %v", err)
+	}
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	var shedding float64
+	for _, mf := range mfs {
+		if mf.GetName() == "reaper_shedding" {
+			shedding = mf.GetMetric()[0].GetGauge().GetValue()
+		}
+	}
+	if shedding != 1 {
+		t.Errorf("reaper_shedding = %v, want 1", shedding)
+	}
+	if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferShedding); got != 1 {
+		t.Errorf("reaper_deferred_total{reason=%q} = %v, want 1", metrics.DeferShedding, got)
+	}
+}
+
+func TestPodReconciler_SheddingEscalates(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+
+	// The API server throttles every delete
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				return apierrors.NewTooManyRequests("slow down", 1)
+			},
+		}).
+		Build()
+
+	const window = 100 * time.Millisecond
+	r := &PodReconciler{
+		Client:      fakeClient,
+		Scheme:      scheme,
+		Metrics:     metrics.NewPodMetrics(),
+		TTLToDelete: 300,
+		Shedder:     NewLoadShedder(0.4, window),
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+	// shed reconciles until one is shed and returns its delay
+	shed := func() time.Duration {
+		t.Helper()
+		for i := 0; i < 2*shedMinSamples; i++ {
+			result, _ := r.Reconcile(context.Background(), req)
+			if result.RequeueAfter > 0 {
+				return result.RequeueAfter
+			}
+		}
+		t.Fatal("Expected reconciles to be shed")
+		return 0
+	}
+
+	// Nothing is written while shedding, so it disengages after a window,
+	// but the delay keeps growing each time it engages again
+	for _, want := range []time.Duration{shedBaseDelay, 2 * shedBaseDelay, 4 * shedBaseDelay} {
+		if got := shed(); got != want {
+			t.Fatalf("shed delay = %v, want %v", got, want)
+		}
+		time.Sleep(window + 10*time.Millisecond)
+	}
+}
+
+func TestPodReconciler_SheddingCountsPatches(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}}
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(pod).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				return apierrors.NewTooManyRequests("slow down", 1)
+			},
+		}).
+		Build()
+
+	r := &PodReconciler{
+		Client:  fakeClient,
+		Metrics: metrics.NewPodMetrics(),
+		Shedder: NewLoadShedder(0.5, time.Minute),
+	}
+	for i := 0; i < shedMinSamples; i++ {
+		if err := r.markReaped(context.Background(), pod.DeepCopy()); err == nil {
+			t.Fatal("markReaped() expected the throttling error")
+		}
+	}
+	if !r.Shedder.Shedding() {
+		t.Error("Shedding() = false after throttled patches")
+	}
+}
@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"hash/fnv"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// startupDelay spreads the reconciles arriving within StartupJitter of
+// startup, holding each pod until a stable offset into the window derived
+// from its UID and returning how long is left until then
+func (r *PodReconciler) startupDelay(uid types.UID, now time.Time) (time.Duration, bool) {
+	if r.StartupJitter <= 0 {
+		return 0, false
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(uid))
+	offset := time.Duration(h.Sum64() % uint64(r.StartupJitter))
+	wait := r.startedAt.Add(offset).Sub(now)
+	return wait, wait > 0
+}
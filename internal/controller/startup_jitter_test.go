@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_StartupJitter(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	const jitter = time.Hour
+	var objs []runtime.Object
+	for i := 0; i < 10; i++ {
+		objs = append(objs, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("test-pod-%d", i),
+				Namespace: "default",
+				UID:       types.UID(fmt.Sprintf("uid-%d", i)),
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+			},
+		})
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(objs...).Build()
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetrics()
+	podMetrics.Register(registry)
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           podMetrics,
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"default"},
+		StartupJitter:     jitter,
+	}
+
+	delays := map[time.Duration]bool{}
+	for i := 0; i < 10; i++ {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: fmt.Sprintf("test-pod-%d", i), Namespace: "default"}}
+		result, err := r.Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		if result.RequeueAfter > jitter {
+			t.Errorf("Expected a delay within the startup jitter, got %v", result.RequeueAfter)
+		}
+		delays[result.RequeueAfter.Round(time.Second)] = true
+	}
+	if len(delays) < 2 {
+		t.Errorf("Expected the reconciles to be spread, got delays %v", delays)
+	}
+	if got := gatherCounter(t, registry, "reaper_deferred_total", "reason", metrics.DeferStartupGrace); got != 10 {
+		t.Errorf("Expected 10 startup grace deferrals, got %v", got)
+	}
+
+	pods := &corev1.PodList{}
+	if err := fakeClient.List(context.Background(), pods); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(pods.Items) == 0 {
+		t.Error("Expected pods to be held back during the startup window")
+	}
+}
+
+func TestPodReconciler_StartupDelay(t *testing.T) {
+	r := &PodReconciler{StartupJitter: time.Minute, startedAt: time.Now()}
+
+	wait, ok := r.startupDelay("uid-1", r.startedAt)
+	if wait < 0 || wait >= time.Minute {
+		t.Fatalf("startupDelay() = %v, want within [0, 1m)", wait)
+	}
+	if again, _ := r.startupDelay("uid-1", r.startedAt); again != wait {
+		t.Errorf("Expected a stable delay per pod, got %v then %v", wait, again)
+	}
+	if ok {
+		if later, stillOk := r.startupDelay("uid-1", r.startedAt.Add(wait/2)); !stillOk || later != wait-wait/2 {
+			t.Errorf("startupDelay() = %v, %v, want %v, true", later, stillOk, wait-wait/2)
+		}
+	}
+	if _, ok := r.startupDelay("uid-1", r.startedAt.Add(time.Minute)); ok {
+		t.Error("Expected no delay once the startup window is over")
+	}
+
+	r.StartupJitter = 0
+	if _, ok := r.startupDelay("uid-1", r.startedAt); ok {
+		t.Error("Expected no delay with the jitter disabled")
+	}
+}
@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// teamCacheTTL is how long a namespace's team label is cached
+	teamCacheTTL = 5 * time.Minute
+
+	// maxTeams bounds the distinct team label values reported, later teams
+	// are reported as teamOther
+	maxTeams = 100
+
+	teamNone    = "none"
+	teamUnknown = "unknown"
+	teamOther   = "other"
+)
+
+// teamCache caches the team label of namespaces and tracks the team values
+// reported so far
+type teamCache struct {
+	mu       sync.Mutex
+	entries  map[string]teamEntry
+	reported map[string]bool
+}
+
+type teamEntry struct {
+	team    string
+	expires time.Time
+}
+
+// namespaceTeam returns the team owning a namespace, read from its
+// TeamLabelKey label, bounded to maxTeams distinct values
+func (r *PodReconciler) namespaceTeam(ctx context.Context, namespace string) string {
+	now := time.Now()
+	r.teams.mu.Lock()
+	entry, ok := r.teams.entries[namespace]
+	r.teams.mu.Unlock()
+	if ok && now.Before(entry.expires) {
+		return entry.team
+	}
+
+	team := teamNone
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		log.FromContext(ctx).Error(err, "unable to read namespace team label", "namespace", namespace)
+		team = teamUnknown
+	} else if v := ns.Labels[r.TeamLabelKey]; v != "" {
+		team = v
+	}
+
+	r.teams.mu.Lock()
+	defer r.teams.mu.Unlock()
+	if r.teams.reported == nil {
+		r.teams.entries = make(map[string]teamEntry)
+		r.teams.reported = make(map[string]bool)
+	}
+	if !r.teams.reported[team] {
+		if len(r.teams.reported) >= maxTeams {
+			team = teamOther
+		} else {
+			r.teams.reported[team] = true
+		}
+	}
+	r.teams.entries[namespace] = teamEntry{team: team, expires: now.Add(teamCacheTTL)}
+	return team
+}
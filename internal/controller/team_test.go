@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_namespaceTeam(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"owner-team": "billing"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "scratch"}},
+		).
+		Build()
+
+	r := &PodReconciler{Client: fakeClient, TeamLabelKey: "owner-team"}
+
+	tests := []struct {
+		namespace string
+		want      string
+	}{
+		{namespace: "payments", want: "billing"},
+		{namespace: "scratch", want: teamNone},
+		{namespace: "missing", want: teamUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.namespace, func(t *testing.T) {
+			if got := r.namespaceTeam(context.Background(), tt.namespace); got != tt.want {
+				t.Errorf("namespaceTeam(%q) = %q, want %q", tt.namespace, got, tt.want)
+			}
+		})
+	}
+
+	// Cached until the TTL expires, even if the label changes
+	ns := &corev1.Namespace{}
+	_ = fakeClient.Get(context.Background(), types.NamespacedName{Name: "payments"}, ns)
+	ns.Labels["owner-team"] = "finance"
+	_ = fakeClient.Update(context.Background(), ns)
+	if got := r.namespaceTeam(context.Background(), "payments"); got != "billing" {
+		t.Errorf("Expected the cached team billing, got %q", got)
+	}
+	r.teams.entries["payments"] = teamEntry{team: "billing", expires: time.Now().Add(-time.Second)}
+	if got := r.namespaceTeam(context.Background(), "payments"); got != "finance" {
+		t.Errorf("Expected the refreshed team finance, got %q", got)
+	}
+}
+
+func TestPodReconciler_namespaceTeamCardinality(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for i := 0; i <= maxTeams; i++ {
+		builder = builder.WithRuntimeObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   fmt.Sprintf("ns-%d", i),
+			Labels: map[string]string{"owner-team": fmt.Sprintf("team-%d", i)},
+		}})
+	}
+	r := &PodReconciler{Client: builder.Build(), TeamLabelKey: "owner-team"}
+
+	for i := 0; i < maxTeams; i++ {
+		if got, want := r.namespaceTeam(context.Background(), fmt.Sprintf("ns-%d", i)), fmt.Sprintf("team-%d", i); got != want {
+			t.Fatalf("namespaceTeam() = %q, want %q", got, want)
+		}
+	}
+	if got := r.namespaceTeam(context.Background(), fmt.Sprintf("ns-%d", maxTeams)); got != teamOther {
+		t.Errorf("Expected teams beyond the limit to be reported as %q, got %q", teamOther, got)
+	}
+	if got := r.namespaceTeam(context.Background(), "ns-0"); got != "team-0" {
+		t.Errorf("Expected a known team to still be reported, got %q", got)
+	}
+}
+
+func TestPodReconciler_TeamDeletionMetric(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "payments"},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodFailed,
+			Reason:    "Evicted",
+			StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+		},
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"owner-team": "billing"}}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod, ns).Build()
+
+	registry := prometheus.NewRegistry()
+	podMetrics := metrics.NewPodMetrics()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:            fakeClient,
+		Scheme:            scheme,
+		Metrics:           podMetrics,
+		TTLToDelete:       300,
+		AllowedNamespaces: []string{"payments"},
+		TeamLabelKey:      "owner-team",
+	}
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "test-pod", Namespace: "payments"}}
+	if _, err := r.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	if got := gatherCounter(t, registry, "evicted_pods_deleted_by_team_total", "team", "billing"); got != 1 {
+		t.Errorf("evicted_pods_deleted_by_team_total{team=billing} = %v, want 1", got)
+	}
+}
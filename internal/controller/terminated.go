@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// containersRunningRequeueAfter is how long to wait before checking again
+// whether a pod's containers have all terminated
+const containersRunningRequeueAfter = 30 * time.Second
+
+// runningContainers returns the names of a pod's containers that are still
+// running, sidecars started as init containers included
+func runningContainers(pod *corev1.Pod) []string {
+	var running []string
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses} {
+		for _, status := range statuses {
+			if status.State.Running != nil {
+				running = append(running, status.Name)
+			}
+		}
+	}
+	return running
+}
+
+// terminationReasons returns the reasons a pod's containers terminated
+// with, such as Error or ContainerCannotRun
+func terminationReasons(pod *corev1.Pod) []string {
+	var reasons []string
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated != nil && status.State.Terminated.Reason != "" {
+			reasons = append(reasons, status.State.Terminated.Reason)
+		}
+	}
+	return reasons
+}
+
+// matchesTerminationReason checks if one of the pod's containers terminated
+// with one of the ContainerTerminationReasons. An unset filter matches
+// every pod.
+func (r *PodReconciler) matchesTerminationReason(pod *corev1.Pod) bool {
+	if len(r.ContainerTerminationReasons) == 0 {
+		return true
+	}
+	for _, reason := range terminationReasons(pod) {
+		if slices.Contains(r.ContainerTerminationReasons, reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// terminatedCheck checks that none of a pod's containers are still running
+func terminatedCheck(pod *corev1.Pod) podCheck {
+	running := runningContainers(pod)
+	detail := "no container running"
+	if len(running) > 0 {
+		detail = "running: " + strings.Join(running, ", ")
+	}
+	return podCheck{
+		name:         "terminated",
+		pass:         len(running) == 0,
+		detail:       detail,
+		outcome:      fmt.Sprintf("requeue in %s", containersRunningRequeueAfter),
+		requeueAfter: containersRunningRequeueAfter,
+	}
+}
@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_RequireAllTerminated(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137}}
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+
+	tests := []struct {
+		name          string
+		require       bool
+		init          []corev1.ContainerStatus
+		containers    []corev1.ContainerStatus
+		expectDeleted bool
+	}{
+		{
+			name:    "running sidecar requeues",
+			require: true,
+			containers: []corev1.ContainerStatus{
+				{Name: "app", State: terminated},
+				{Name: "log-shipper", State: running},
+			},
+		},
+		{
+			name:    "running native sidecar requeues",
+			require: true,
+			init:    []corev1.ContainerStatus{{Name: "proxy", State: running}},
+			containers: []corev1.ContainerStatus{
+				{Name: "app", State: terminated},
+			},
+		},
+		{
+			name:    "all terminated is deleted",
+			require: true,
+			containers: []corev1.ContainerStatus{
+				{Name: "app", State: terminated},
+				{Name: "log-shipper", State: terminated},
+			},
+			expectDeleted: true,
+		},
+		{
+			name: "running sidecar is deleted when not required",
+			containers: []corev1.ContainerStatus{
+				{Name: "app", State: terminated},
+				{Name: "log-shipper", State: running},
+			},
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:                 corev1.PodFailed,
+					Reason:                "Evicted",
+					StartTime:             &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+					InitContainerStatuses: tt.init,
+					ContainerStatuses:     tt.containers,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:               fakeClient,
+				Scheme:               scheme,
+				Metrics:              metrics.NewPodMetrics(),
+				TTLToDelete:          300,
+				RequireAllTerminated: tt.require,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("pod deleted = %v, want %v", deleted, tt.expectDeleted)
+			}
+			if !tt.expectDeleted && result.RequeueAfter != containersRunningRequeueAfter {
+				t.Errorf("Expected requeue after %v, got %v", containersRunningRequeueAfter, result.RequeueAfter)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_ContainerTerminationReasons(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	terminatedWith := func(name, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: reason}},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		reasons       []string
+		containers    []corev1.ContainerStatus
+		expectDeleted bool
+	}{
+		{
+			name:          "matching reason is deleted",
+			reasons:       []string{"Error", "ContainerCannotRun"},
+			containers:    []corev1.ContainerStatus{terminatedWith("app", "ContainerCannotRun")},
+			expectDeleted: true,
+		},
+		{
+			name:    "one matching container is enough",
+			reasons: []string{"Error"},
+			containers: []corev1.ContainerStatus{
+				terminatedWith("app", "Completed"),
+				terminatedWith("sidecar", "Error"),
+			},
+			expectDeleted: true,
+		},
+		{
+			name:       "non-matching reason is skipped",
+			reasons:    []string{"Error"},
+			containers: []corev1.ContainerStatus{terminatedWith("app", "OOMKilled")},
+		},
+		{
+			name:    "no terminated container is skipped",
+			reasons: []string{"Error"},
+		},
+		{
+			name:          "no filter is deleted",
+			containers:    []corev1.ContainerStatus{terminatedWith("app", "OOMKilled")},
+			expectDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase:             corev1.PodFailed,
+					Reason:            "Evicted",
+					StartTime:         &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+					ContainerStatuses: tt.containers,
+				},
+			}
+
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(pod).
+				Build()
+
+			r := &PodReconciler{
+				Client:                      fakeClient,
+				Scheme:                      scheme,
+				Metrics:                     metrics.NewPodMetrics(),
+				TTLToDelete:                 300,
+				ContainerTerminationReasons: tt.reasons,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if deleted := err != nil; deleted != tt.expectDeleted {
+				t.Errorf("pod deleted = %v, want %v", deleted, tt.expectDeleted)
+			}
+		})
+	}
+}
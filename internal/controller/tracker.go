@@ -0,0 +1,124 @@
+package controller
+
+import (
+	"container/list"
+	"sync"
+)
+
+// podTracker is a concurrency-safe map of per-pod state keyed by UID or name.
+// When a limit is set, the least recently used entry is evicted once the
+// limit is exceeded; tracked state must be re-derivable on the next
+// reconcile. The zero value is ready to use and unbounded.
+type podTracker[K comparable, V any] struct {
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	order   list.List // most recently used first
+
+	limit   int
+	onEvict func()
+}
+
+type trackerEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// setLimit bounds the number of tracked pods, calling onEvict whenever an
+// entry is evicted to stay within it. A limit of 0 means unbounded.
+func (t *podTracker[K, V]) setLimit(limit int, onEvict func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.limit = limit
+	t.onEvict = onEvict
+	t.evictLocked()
+}
+
+// Get returns the state tracked for a pod
+func (t *podTracker[K, V]) Get(key K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	elem, ok := t.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	t.order.MoveToFront(elem)
+	return elem.Value.(*trackerEntry[K, V]).value, true
+}
+
+// Set tracks state for a pod
+func (t *podTracker[K, V]) Set(key K, v V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[K]*list.Element)
+	}
+	if elem, ok := t.entries[key]; ok {
+		elem.Value.(*trackerEntry[K, V]).value = v
+		t.order.MoveToFront(elem)
+		return
+	}
+	t.entries[key] = t.order.PushFront(&trackerEntry[K, V]{key: key, value: v})
+	t.evictLocked()
+}
+
+// Update atomically replaces the state tracked for a pod with the result of
+// fn, which is given the current state and whether there is any
+func (t *podTracker[K, V]) Update(key K, fn func(v V, ok bool) V) V {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.entries == nil {
+		t.entries = make(map[K]*list.Element)
+	}
+	if elem, ok := t.entries[key]; ok {
+		entry := elem.Value.(*trackerEntry[K, V])
+		entry.value = fn(entry.value, true)
+		t.order.MoveToFront(elem)
+		return entry.value
+	}
+	var zero V
+	v := fn(zero, false)
+	t.entries[key] = t.order.PushFront(&trackerEntry[K, V]{key: key, value: v})
+	t.evictLocked()
+	return v
+}
+
+// Delete stops tracking a pod
+func (t *podTracker[K, V]) Delete(key K) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if elem, ok := t.entries[key]; ok {
+		t.order.Remove(elem)
+		delete(t.entries, key)
+	}
+}
+
+// Len returns the number of tracked pods
+func (t *podTracker[K, V]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.entries)
+}
+
+// Keys returns the tracked pods, most recently used first
+func (t *podTracker[K, V]) Keys() []K {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	keys := make([]K, 0, len(t.entries))
+	for elem := t.order.Front(); elem != nil; elem = elem.Next() {
+		keys = append(keys, elem.Value.(*trackerEntry[K, V]).key)
+	}
+	return keys
+}
+
+// evictLocked drops least recently used entries beyond the limit
+func (t *podTracker[K, V]) evictLocked() {
+	for t.limit > 0 && len(t.entries) > t.limit {
+		oldest := t.order.Back()
+		t.order.Remove(oldest)
+		delete(t.entries, oldest.Value.(*trackerEntry[K, V]).key)
+		if t.onEvict != nil {
+			t.onEvict()
+		}
+	}
+}
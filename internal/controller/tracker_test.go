@@ -0,0 +1,203 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodTracker_LRU(t *testing.T) {
+	var tracker podTracker[string, int]
+	evictions := 0
+	tracker.setLimit(2, func() { evictions++ })
+
+	tracker.Set("a", 1)
+	tracker.Set("b", 2)
+
+	// Using a makes b the least recently used
+	if _, ok := tracker.Get("a"); !ok {
+		t.Fatal("Get(a) not found")
+	}
+	tracker.Set("c", 3)
+
+	if tracker.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", tracker.Len())
+	}
+	if evictions != 1 {
+		t.Errorf("evictions = %d, want 1", evictions)
+	}
+	if _, ok := tracker.Get("b"); ok {
+		t.Error("Expected least recently used entry b to be evicted")
+	}
+	for key, want := range map[string]int{"a": 1, "c": 3} {
+		if got, ok := tracker.Get(key); !ok || got != want {
+			t.Errorf("Get(%s) = %d, %v, want %d", key, got, ok, want)
+		}
+	}
+
+	// Updating an existing entry doesn't evict
+	tracker.Set("a", 10)
+	if evictions != 1 {
+		t.Errorf("evictions after update = %d, want 1", evictions)
+	}
+
+	// Deleted entries free up room
+	tracker.Delete("a")
+	tracker.Set("d", 4)
+	if evictions != 1 || tracker.Len() != 2 {
+		t.Errorf("after delete evictions = %d, Len() = %d, want 1 and 2", evictions, tracker.Len())
+	}
+}
+
+func TestPodTracker_Unbounded(t *testing.T) {
+	var tracker podTracker[int, bool]
+	for i := 0; i < 100; i++ {
+		tracker.Set(i, true)
+	}
+	if tracker.Len() != 100 {
+		t.Errorf("Len() = %d, want 100", tracker.Len())
+	}
+}
+
+func TestPodReconciler_MaxTrackedPods(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	var objects []runtime.Object
+	for _, name := range []string{"pod-a", "pod-b", "pod-c"} {
+		objects = append(objects, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				UID:       types.UID(name),
+			},
+			Status: corev1.PodStatus{
+				Phase:     corev1.PodFailed,
+				Reason:    "Evicted",
+				StartTime: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+			},
+		})
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRuntimeObjects(objects...).
+		Build()
+
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Client:         fakeClient,
+		Scheme:         scheme,
+		Metrics:        podMetrics,
+		TTLToDelete:    300,
+		MaxTrackedPods: 2,
+	}
+
+	for _, name := range []string{"pod-a", "pod-b", "pod-c"} {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}}
+		result, err := r.Reconcile(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Reconcile(%s) error = %v", name, err)
+		}
+		if result.RequeueAfter <= 0 {
+			t.Errorf("Reconcile(%s) expected a requeue within TTL", name)
+		}
+	}
+
+	if r.notBefore.Len() != 2 || r.scheduledRequeues.Len() != 2 {
+		t.Errorf("tracked pods = %d and %d, want 2 each", r.notBefore.Len(), r.scheduledRequeues.Len())
+	}
+	if got := gatherCounter(t, registry, "reaper_tracked_pods_evicted_total", "tracker", "not_before"); got != 1 {
+		t.Errorf("not_before evictions = %v, want 1", got)
+	}
+
+	// The evicted pod is fetched and evaluated again, still within its TTL
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "pod-a", Namespace: "default"}}
+	result, err := r.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Reconcile(pod-a) error = %v", err)
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 4*time.Minute+time.Second {
+		t.Errorf("Reconcile(pod-a) RequeueAfter = %v, want remaining TTL", result.RequeueAfter)
+	}
+}
+
+func TestPodTracker_ConcurrentUpdate(t *testing.T) {
+	var tracker podTracker[string, int]
+
+	const goroutines, updates = 50, 200
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < updates; j++ {
+				tracker.Update("pod", func(v int, _ bool) int { return v + 1 })
+				tracker.Get("pod")
+				tracker.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, _ := tracker.Get("pod"); got != goroutines*updates {
+		t.Errorf("Expected %d updates, got %d", goroutines*updates, got)
+	}
+}
+
+func TestPodReconciler_ConcurrentSharedState(t *testing.T) {
+	podMetrics := metrics.NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	podMetrics.Register(registry)
+
+	r := &PodReconciler{
+		Metrics:           podMetrics,
+		MaxTrackedPods:    10,
+		ReconcileDebounce: time.Hour,
+	}
+	r.initOnce.Do(r.init)
+
+	// Every goroutine fails to delete the same pod, and debounces pods of
+	// its own, overflowing the tracker limit
+	const goroutines, failures = 20, 100
+	key := types.NamespacedName{Name: "test-pod", Namespace: "default"}
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < failures; j++ {
+				r.recordDeleteFailure(key, "uid")
+				r.debounce(types.UID(fmt.Sprintf("pod-%d-%d", i, j%5)), time.Now())
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entry, ok := r.backoff.Get(key)
+	if !ok || entry.attempts != goroutines*failures {
+		t.Errorf("Expected %d failed deletions, got %+v", goroutines*failures, entry)
+	}
+	if got := r.lastReconciled.Len(); got != 10 {
+		t.Errorf("Expected the debounce tracker to be capped at 10, got %d", got)
+	}
+	// Pods evicted from the tracker may be tracked and evicted again
+	if got := gatherCounter(t, registry, "reaper_tracked_pods_evicted_total", "tracker", "last_reconciled"); got < goroutines*5-10 {
+		t.Errorf("Expected at least %d tracker evictions, got %v", goroutines*5-10, got)
+	}
+}
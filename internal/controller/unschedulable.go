@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// unschedulableCondition returns the PodScheduled=False condition with reason
+// Unschedulable of a Pending pod, or nil if the pod is not stuck unschedulable
+func unschedulableCondition(pod *corev1.Pod) *corev1.PodCondition {
+	if pod.Status.Phase != corev1.PodPending {
+		return nil
+	}
+	for i := range pod.Status.Conditions {
+		cond := &pod.Status.Conditions[i]
+		if cond.Type == corev1.PodScheduled &&
+			cond.Status == corev1.ConditionFalse &&
+			cond.Reason == corev1.PodReasonUnschedulable {
+			return cond
+		}
+	}
+	return nil
+}
+
+// isUnschedulablePodPredicate returns true if the object is a Pending pod that
+// cannot be scheduled
+func isUnschedulablePodPredicate(obj client.Object) bool {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		return false
+	}
+	return unschedulableCondition(pod) != nil
+}
+
+// unschedulableRequeueTime returns how long until an unschedulable pod has
+// been unschedulable for longer than UnschedulableTTL, or 0 if it already has.
+// A condition without a transition time can't be aged, so it waits a full TTL.
+func (r *PodReconciler) unschedulableRequeueTime(cond *corev1.PodCondition) time.Duration {
+	ttl := time.Duration(r.UnschedulableTTL) * time.Second
+	if cond.LastTransitionTime.IsZero() {
+		return ttl
+	}
+	stuckFor := time.Since(cond.LastTransitionTime.Time)
+	if stuckFor >= ttl {
+		return 0
+	}
+	return ttl - stuckFor
+}
+
+// reconcileUnschedulable reaps a Pending pod that has been unschedulable for
+// longer than UnschedulableTTL and whose owner is gone. It returns the
+// reconcile result to report.
+func (r *PodReconciler) reconcileUnschedulable(ctx context.Context, pod *corev1.Pod, cond *corev1.PodCondition) (ctrl.Result, string, error) {
+	logger := log.FromContext(ctx)
+	key := client.ObjectKeyFromObject(pod)
+
+	if c := firstFailed(r.unschedulableChecks(pod, cond)); c != nil {
+		res, result := r.handleFailedCheck(logger, pod, c)
+		return res, result, nil
+	}
+
+	// Pods of a live owner may be waiting for capacity, e.g. from an autoscaler
+	if !r.isOwnerGone(ctx, pod) {
+		logger.V(1).Info("unschedulable pod still has an owner, skipping", "pod", key)
+		return ctrl.Result{}, metrics.ReconcileNoop, nil
+	}
+
+	if res, result, paused, err := r.pauseForMaintenance(ctx, pod); paused {
+		return res, result, err
+	}
+
+	if r.dryRun(ctx, pod) {
+		return ctrl.Result{}, r.skip(pod, metrics.SkipDryRun), nil
+	}
+
+	if wait, dryRun := r.firstPassDryRun(ctx, pod, time.Now()); dryRun {
+		return ctrl.Result{RequeueAfter: wait}, metrics.ReconcileRequeued, nil
+	}
+
+	if r.Mode == ModeQuarantine {
+		return r.quarantine(ctx, pod)
+	}
+
+	logger.Info("deleting unschedulable pod", "pod", key, "message", cond.Message)
+	if err := r.deletePod(ctx, pod); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, metrics.ReconcileNoop, nil
+		}
+		logger.Error(err, "unable to delete pod", "pod", key)
+		return ctrl.Result{}, metrics.ReconcileError, fmt.Errorf("deleting unschedulable pod %s: %w", key, err)
+	}
+
+	if !hasNoMetrics(pod) {
+		r.Metrics.IncUnschedulableDeleted(pod.Namespace)
+	}
+	logger.Info("successfully deleted unschedulable pod", "pod", key)
+	return ctrl.Result{}, metrics.ReconcileDeleted, nil
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// unschedulablePod returns a Pending pod that became unschedulable stuckFor ago
+func unschedulablePod(stuckFor time.Duration, owners ...metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-pod",
+			Namespace:       "default",
+			OwnerReferences: owners,
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionFalse,
+					Reason:             corev1.PodReasonUnschedulable,
+					Message:            "0/3 nodes are available: 3 node(s) didn't match Pod's node affinity/selector.",
+					LastTransitionTime: metav1.Time{Time: time.Now().Add(-stuckFor)},
+				},
+			},
+		},
+	}
+}
+
+func TestPodReconciler_ReapUnschedulable(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: "rs-uid"},
+	}
+	noTransitionTime := unschedulablePod(0)
+	noTransitionTime.Status.Conditions[0].LastTransitionTime = metav1.Time{}
+
+	tests := []struct {
+		name              string
+		pod               *corev1.Pod
+		reapUnschedulable bool
+		expectDeleted     bool
+		expectRequeue     bool
+	}{
+		{
+			name:              "long-unschedulable pod is deleted",
+			pod:               unschedulablePod(2 * time.Hour),
+			reapUnschedulable: true,
+			expectDeleted:     true,
+			expectRequeue:     false,
+		},
+		{
+			name:              "recently pending pod is requeued",
+			pod:               unschedulablePod(time.Minute),
+			reapUnschedulable: true,
+			expectDeleted:     false,
+			expectRequeue:     true,
+		},
+		{
+			name:              "long-unschedulable pod is ignored when disabled",
+			pod:               unschedulablePod(2 * time.Hour),
+			reapUnschedulable: false,
+			expectDeleted:     false,
+			expectRequeue:     false,
+		},
+		{
+			name:              "long-unschedulable pod with an existing owner is kept",
+			pod:               unschedulablePod(2*time.Hour, controllerRef("apps/v1", "ReplicaSet", "web", "rs-uid")),
+			reapUnschedulable: true,
+		},
+		{
+			name:              "long-unschedulable pod whose owner was deleted is deleted",
+			pod:               unschedulablePod(2*time.Hour, controllerRef("apps/v1", "ReplicaSet", "gone", "gone-uid")),
+			reapUnschedulable: true,
+			expectDeleted:     true,
+		},
+		{
+			name:              "pod without a transition time is requeued",
+			pod:               noTransitionTime,
+			reapUnschedulable: true,
+			expectRequeue:     true,
+		},
+		{
+			name: "pending pod without unschedulable condition is ignored",
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-pod",
+					Namespace: "default",
+				},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodPending,
+				},
+			},
+			reapUnschedulable: true,
+			expectDeleted:     false,
+			expectRequeue:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithRuntimeObjects(tt.pod, replicaSet).
+				Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			r := &PodReconciler{
+				Client:            fakeClient,
+				Scheme:            scheme,
+				Metrics:           podMetrics,
+				TTLToDelete:       300,
+				ReapUnschedulable: tt.reapUnschedulable,
+				UnschedulableTTL:  3600,
+			}
+
+			req := reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      tt.pod.Name,
+					Namespace: tt.pod.Namespace,
+				},
+			}
+			result, err := r.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if (result.RequeueAfter > 0) != tt.expectRequeue {
+				t.Errorf("Reconcile() result = %v, expectRequeue %v", result, tt.expectRequeue)
+			}
+
+			err = fakeClient.Get(context.Background(), req.NamespacedName, &corev1.Pod{})
+			if tt.expectDeleted && err == nil {
+				t.Errorf("Expected pod to be deleted, but it still exists")
+			}
+			if !tt.expectDeleted && err != nil {
+				t.Errorf("Expected pod to exist, but got error: %v", err)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_CandidatePredicate(t *testing.T) {
+	pending := unschedulablePod(time.Minute)
+
+	disabled := &PodReconciler{}
+	if disabled.isCandidatePodPredicate(pending) {
+		t.Error("isCandidatePodPredicate() for unschedulable pod = true with reaping disabled, want false")
+	}
+
+	enabled := &PodReconciler{ReapUnschedulable: true}
+	if !enabled.isCandidatePodPredicate(pending) {
+		t.Error("isCandidatePodPredicate() for unschedulable pod = false with reaping enabled, want true")
+	}
+}
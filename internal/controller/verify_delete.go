@@ -0,0 +1,45 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// verifyDeleteAttempts is how many times a deleted pod is looked up before
+	// its deletion is reported as unconfirmed
+	verifyDeleteAttempts = 5
+	// verifyDeleteInterval is the wait between lookups of a deleted pod
+	verifyDeleteInterval = 200 * time.Millisecond
+)
+
+// confirmDeleted polls the API server until a deleted pod is gone, or its
+// name belongs to a new pod, and reports whether that happened within
+// verifyDeleteAttempts. Pods held by a finalizer are never confirmed.
+func (r *PodReconciler) confirmDeleted(ctx context.Context, pod *corev1.Pod) (bool, error) {
+	key := client.ObjectKeyFromObject(pod)
+	for attempt := 0; attempt < verifyDeleteAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(verifyDeleteInterval):
+			case <-ctx.Done():
+				return false, ctx.Err()
+			}
+		}
+		current := &corev1.Pod{}
+		if err := r.apiReader().Get(ctx, key, current); err != nil {
+			if errors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, err
+		}
+		if current.UID != pod.UID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestPodReconciler_VerifyDelete(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name              string
+		finalizers        []string
+		disabled          bool
+		expectDeleted     float64
+		expectUnconfirmed float64
+	}{
+		{name: "pod is gone", expectDeleted: 1},
+		{name: "pod held by a finalizer", finalizers: []string{"example.com/hold"}, expectUnconfirmed: 1},
+		{name: "disabled", finalizers: []string{"example.com/hold"}, disabled: true, expectDeleted: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "test-pod",
+					Namespace:  "default",
+					UID:        "uid-1",
+					Finalizers: tt.finalizers,
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodFailed,
+					Reason:    "Evicted",
+					StartTime: &metav1.Time{Time: time.Now().Add(-10 * time.Minute)},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(pod).Build()
+
+			podMetrics := metrics.NewPodMetrics()
+			registry := prometheus.NewRegistry()
+			podMetrics.Register(registry)
+
+			r := &PodReconciler{
+				Client:       fakeClient,
+				Scheme:       scheme,
+				Metrics:      podMetrics,
+				TTLToDelete:  300,
+				VerifyDelete: !tt.disabled,
+			}
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: pod.Name, Namespace: pod.Namespace}}
+			if _, err := r.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if got := gatherCounter(t, registry, "evicted_pods_deleted_total", "namespace", "default"); got != tt.expectDeleted {
+				t.Errorf("evicted_pods_deleted_total = %v, want %v", got, tt.expectDeleted)
+			}
+			if got := gatherCounter(t, registry, "evicted_pods_delete_unconfirmed_total", "namespace", "default"); got != tt.expectUnconfirmed {
+				t.Errorf("evicted_pods_delete_unconfirmed_total = %v, want %v", got, tt.expectUnconfirmed)
+			}
+		})
+	}
+}
+
+func TestPodReconciler_ConfirmDeletedReplaced(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	replacement := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: "uid-2"},
+	}
+	r := &PodReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithRuntimeObjects(replacement).Build(),
+	}
+
+	deleted := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default", UID: "uid-1"},
+	}
+	confirmed, err := r.confirmDeleted(context.Background(), deleted)
+	if err != nil {
+		t.Fatalf("confirmDeleted() error = %v", err)
+	}
+	if !confirmed {
+		t.Error("Expected a pod replaced under the same name to count as deleted")
+	}
+}
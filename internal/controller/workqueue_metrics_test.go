@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/kyosenergy-engineering/evicted-pod-reaper/internal/metrics"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+)
+
+// TestPodReconciler_WorkqueueMetrics checks that the controller's work queue
+// reports its depth and adds on the registry served on /metrics
+func TestPodReconciler_WorkqueueMetrics(t *testing.T) {
+	ctrl.SetLogger(logr.Discard())
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// The queue is created when the controller starts, so no API server is
+	// needed to see it registered
+	skipNameValidation := true
+	mgr, err := ctrl.NewManager(&rest.Config{Host: "http://127.0.0.1:1"}, ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+		Controller:             config.Controller{SkipNameValidation: &skipNameValidation},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create manager: %v", err)
+	}
+	r := &PodReconciler{
+		Client:         mgr.GetClient(),
+		Scheme:         scheme,
+		Metrics:        metrics.NewPodMetrics(),
+		ControllerName: "workqueue-metrics-test",
+	}
+	if err := r.SetupWithManager(mgr); err != nil {
+		t.Fatalf("Failed to set up reconciler: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = mgr.Start(ctx)
+
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	found := map[string]bool{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "name" && label.GetValue() == r.ControllerName {
+					found[family.GetName()] = true
+				}
+			}
+		}
+	}
+	for _, name := range []string{"workqueue_depth", "workqueue_adds_total"} {
+		if !found[name] {
+			t.Errorf("Expected %s for the controller's queue", name)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import "sync"
+
+// OtherNamespace is the namespace label value of series for namespaces past
+// MetricsConfig.MaxNamespaces or outside MetricsConfig.NamespaceAllowlist
+const OtherNamespace = "other"
+
+// namespaceLimiter caps the distinct namespace label values, folding
+// namespaces outside the allowlist, if any, into OtherNamespace, then
+// keeping the first namespaces seen and folding later ones
+type namespaceLimiter struct {
+	max     int
+	allowed map[string]struct{}
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+func newNamespaceLimiter(max int, allowlist []string) *namespaceLimiter {
+	l := &namespaceLimiter{max: max, seen: make(map[string]struct{})}
+	if len(allowlist) > 0 {
+		l.allowed = make(map[string]struct{}, len(allowlist))
+		for _, namespace := range allowlist {
+			l.allowed[namespace] = struct{}{}
+		}
+	}
+	return l
+}
+
+// label returns the label value to record a namespace under
+func (l *namespaceLimiter) label(namespace string) string {
+	if l.allowed != nil {
+		if _, ok := l.allowed[namespace]; !ok {
+			return OtherNamespace
+		}
+	}
+	if l.max <= 0 {
+		return namespace
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.seen[namespace]; ok {
+		return namespace
+	}
+	if len(l.seen) >= l.max {
+		return OtherNamespace
+	}
+	l.seen[namespace] = struct{}{}
+	return namespace
+}
@@ -1,29 +1,336 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// Reconcile results reported by the reconciles counter
+const (
+	ReconcileDeleted     = "deleted"
+	ReconcileQuarantined = "quarantined"
+	ReconcileSkipped     = "skipped"
+	ReconcileRequeued    = "requeued"
+	ReconcileNoop        = "noop"
+	ReconcileError       = "error"
+)
+
+// Skip reasons reported by the skipped counter
+const (
+	SkipPreserved         = "preserved"
+	SkipSelf              = "self"
+	SkipSafeMode          = "safe_mode"
+	SkipPriority          = "priority"
+	SkipTerminationReason = "termination_reason"
+	SkipEmptySpec         = "empty_spec"
+	SkipStandalone        = "standalone"
+	SkipJobTTL            = "job_ttl"
+	SkipDryRun            = "dry_run"
+)
+
+// Deferral reasons reported by the deferred counter, for candidates held
+// back by a time or policy gate rather than their TTL
+const (
+	DeferMaintenance    = "maintenance"
+	DeferStartupGrace   = "startup_grace"
+	DeferFirstPass      = "first_pass"
+	DeferShedding       = "shedding"
+	DeferLogsShipped    = "logs_shipped"
+	DeferOwnerObserved  = "owner_observed"
+	DeferCacheSync      = "cache_sync"
+	DeferReaperConfig   = "reaper_config"
+	DeferReaperDisabled = "reaper_disabled"
+	DeferPostReboot     = "post_reboot"
+	DeferLatencyBackoff = "latency_backoff"
+)
+
+// Mirror receives a copy of counter increments, for exporting them to
+// systems other than Prometheus
+type Mirror interface {
+	Incr(name string, tags map[string]string)
+}
+
 // PodMetrics holds the prometheus metrics for pod operations
 type PodMetrics struct {
 	deletedTotal *prometheus.CounterVec
 	skippedTotal *prometheus.CounterVec
+
+	reconcilesTotal    *prometheus.CounterVec
+	jobTTLPatchedTotal *prometheus.CounterVec
+
+	configuredNamespaceMissing *prometheus.GaugeVec
+	shedding                   prometheus.Gauge
+
+	unschedulableDeletedTotal *prometheus.CounterVec
+	maintenanceActive         prometheus.Gauge
+	requeueDrift              prometheus.Histogram
+	lastDeletion              *prometheus.GaugeVec
+	suspiciousStartTimeTotal  *prometheus.CounterVec
+	trackerEvictionsTotal     *prometheus.CounterVec
+	teamDeletedTotal          *prometheus.CounterVec
+	backoffEntries            prometheus.Gauge
+	namespaceTTL              *prometheus.GaugeVec
+	updateConflictsTotal      prometheus.Counter
+	heartbeatTotal            prometheus.Counter
+	preservedOverdue          *prometheus.GaugeVec
+	evictionBlockedTotal      *prometheus.CounterVec
+	deletedByOwnerKindTotal   *prometheus.CounterVec
+	podInfo                   *prometheus.GaugeVec
+	nodeNotReadyTotal         *prometheus.CounterVec
+	deleteAPIDuration         prometheus.Histogram
+	deferredTotal             *prometheus.CounterVec
+	quarantinedTotal          *prometheus.CounterVec
+	latencyBackoff            prometheus.Gauge
+	deleteUnconfirmedTotal    *prometheus.CounterVec
+
+	namespaces *namespaceLimiter
+	mirror     Mirror
+}
+
+// MetricsConfig customizes the metadata of the reaper's metrics
+type MetricsConfig struct {
+	// ConstLabels are attached to every series, such as service="reaper"
+	ConstLabels prometheus.Labels
+
+	// Help overrides the help text of metrics, by metric name
+	Help map[string]string
+
+	// MaxNamespaces caps the distinct namespace label values of per-pod
+	// metrics, recording namespaces past the cap as OtherNamespace. Zero
+	// means no cap. Gauges of configured namespaces are not capped.
+	MaxNamespaces int
+
+	// NamespaceAllowlist, if set, lists the namespaces per-pod metrics keep
+	// their own label for, the others are recorded as OtherNamespace
+	NamespaceAllowlist []string
+}
+
+// help returns the help text of a metric, unless overridden
+func (c MetricsConfig) help(name, defaultHelp string) string {
+	if help, ok := c.Help[name]; ok {
+		return help
+	}
+	return defaultHelp
 }
 
 // NewPodMetrics creates a new PodMetrics instance
 func NewPodMetrics() *PodMetrics {
+	return NewPodMetricsWithConfig(MetricsConfig{})
+}
+
+// NewPodMetricsWithConfig creates a new PodMetrics instance with custom
+// constant labels, help text and namespace limits
+func NewPodMetricsWithConfig(cfg MetricsConfig) *PodMetrics {
 	return &PodMetrics{
+		namespaces: newNamespaceLimiter(cfg.MaxNamespaces, cfg.NamespaceAllowlist),
 		deletedTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
-				Name: "evicted_pods_deleted_total",
-				Help: "Total number of evicted pods deleted",
+				Name:        "evicted_pods_deleted_total",
+				Help:        cfg.help("evicted_pods_deleted_total", "Total number of evicted pods deleted"),
+				ConstLabels: cfg.ConstLabels,
 			},
-			[]string{"namespace"},
+			[]string{"namespace", "qos"},
 		),
 		skippedTotal: prometheus.NewCounterVec(
 			prometheus.CounterOpts{
-				Name: "evicted_pods_skipped_total",
-				Help: "Total number of evicted pods skipped due to preserve annotation",
+				Name:        "evicted_pods_skipped_total",
+				Help:        cfg.help("evicted_pods_skipped_total", "Total number of evicted pods skipped, by reason"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace", "reason"},
+		),
+		reconcilesTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "reaper_reconciles_total",
+				Help:        cfg.help("reaper_reconciles_total", "Total number of reconciles by result"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"result"},
+		),
+		jobTTLPatchedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "evicted_pods_job_ttl_patched_total",
+				Help:        cfg.help("evicted_pods_job_ttl_patched_total", "Total number of Jobs patched with ttlSecondsAfterFinished instead of deleting their evicted pods"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		configuredNamespaceMissing: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "reaper_configured_namespace_missing",
+				Help:        cfg.help("reaper_configured_namespace_missing", "Whether a configured namespace to watch does not exist (1) or exists (0)"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		shedding: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name:        "reaper_shedding",
+				Help:        cfg.help("reaper_shedding", "Whether deletion work is being shed due to a high API error rate (1) or not (0)"),
+				ConstLabels: cfg.ConstLabels,
+			},
+		),
+		unschedulableDeletedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "unschedulable_pods_deleted_total",
+				Help:        cfg.help("unschedulable_pods_deleted_total", "Total number of pods deleted after being unschedulable past their TTL"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		maintenanceActive: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name:        "reaper_maintenance_active",
+				Help:        cfg.help("reaper_maintenance_active", "Whether deletions are paused by an active maintenance window (1) or not (0)"),
+				ConstLabels: cfg.ConstLabels,
+			},
+		),
+		requeueDrift: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:        "evicted_pod_requeue_drift_seconds",
+				Help:        cfg.help("evicted_pod_requeue_drift_seconds", "Delay between when a pod was scheduled to be reconciled again and when it was"),
+				ConstLabels: cfg.ConstLabels,
+				Buckets:     []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+			},
+		),
+		lastDeletion: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "reaper_last_deletion_info",
+				Help:        cfg.help("reaper_last_deletion_info", "Unix timestamp of the most recent deletion per namespace and reason"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace", "reason"},
+		),
+		suspiciousStartTimeTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "evicted_pods_suspicious_starttime_total",
+				Help:        cfg.help("evicted_pods_suspicious_starttime_total", "Total number of evicted pods seen with a StartTime too old to be real"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		trackerEvictionsTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "reaper_tracked_pods_evicted_total",
+				Help:        cfg.help("reaper_tracked_pods_evicted_total", "Total number of pods evicted from an in-memory tracking map to stay within its limit"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"tracker"},
+		),
+		teamDeletedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "evicted_pods_deleted_by_team_total",
+				Help:        cfg.help("evicted_pods_deleted_by_team_total", "Total number of evicted pods deleted per owning team of their namespace"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"team"},
+		),
+		backoffEntries: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name:        "reaper_backoff_entries",
+				Help:        cfg.help("reaper_backoff_entries", "Number of pods tracked with failed deletion attempts"),
+				ConstLabels: cfg.ConstLabels,
+			},
+		),
+		namespaceTTL: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "evicted_pod_reaper_namespace_ttl_seconds",
+				Help:        cfg.help("evicted_pod_reaper_namespace_ttl_seconds", "TTL configured for namespaces overriding the default TTL"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		updateConflictsTotal: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:        "reaper_update_conflicts_total",
+				Help:        cfg.help("reaper_update_conflicts_total", "Total number of writes that conflicted with a concurrent update"),
+				ConstLabels: cfg.ConstLabels,
+			},
+		),
+		heartbeatTotal: prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name:        "evicted_pod_reaper_heartbeat_total",
+				Help:        cfg.help("evicted_pod_reaper_heartbeat_total", "Total number of heartbeats, increasing steadily while the reaper runs"),
+				ConstLabels: cfg.ConstLabels,
+			},
+		),
+		preservedOverdue: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "reaper_preserved_overdue_pods",
+				Help:        cfg.help("reaper_preserved_overdue_pods", "Number of preserved evicted pods older than the global TTL, which would otherwise have been deleted"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		evictionBlockedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "reaper_eviction_blocked_total",
+				Help:        cfg.help("reaper_eviction_blocked_total", "Total number of evictions blocked by a PodDisruptionBudget and requeued"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		deletedByOwnerKindTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "reaper_deleted_by_owner_kind_total",
+				Help:        cfg.help("reaper_deleted_by_owner_kind_total", "Total number of evicted pods deleted per kind of their top-level owner"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"kind"},
+		),
+		podInfo: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Name:        "evicted_pod_info",
+				Help:        cfg.help("evicted_pod_info", "Information about evicted pods tracked by the reaper, always 1"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace", "pod", "node", "reason"},
+		),
+		nodeNotReadyTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "reaper_node_not_ready_requeues_total",
+				Help:        cfg.help("reaper_node_not_ready_requeues_total", "Total number of deletions requeued because the pod's node was not Ready"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"node"},
+		),
+		deleteAPIDuration: prometheus.NewHistogram(
+			prometheus.HistogramOpts{
+				Name:        "evicted_pod_delete_api_duration_seconds",
+				Help:        cfg.help("evicted_pod_delete_api_duration_seconds", "Duration of pod delete calls to the API server, excluding the rest of the reconcile"),
+				ConstLabels: cfg.ConstLabels,
+				Buckets:     prometheus.DefBuckets,
+			},
+		),
+		deferredTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "reaper_deferred_total",
+				Help:        cfg.help("reaper_deferred_total", "Total number of reconciles requeued by a time or policy gate rather than the TTL"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"reason"},
+		),
+		quarantinedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "evicted_pods_quarantined_total",
+				Help:        cfg.help("evicted_pods_quarantined_total", "Total number of pods labelled for manual review instead of being deleted"),
+				ConstLabels: cfg.ConstLabels,
+			},
+			[]string{"namespace"},
+		),
+		latencyBackoff: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Name:        "reaper_latency_backoff",
+				Help:        cfg.help("reaper_latency_backoff", "Whether deletions are backing off due to high delete latency (1) or not (0)"),
+				ConstLabels: cfg.ConstLabels,
+			},
+		),
+		deleteUnconfirmedTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name:        "evicted_pods_delete_unconfirmed_total",
+				Help:        cfg.help("evicted_pods_delete_unconfirmed_total", "Total number of deleted pods still present after polling for them to be gone"),
+				ConstLabels: cfg.ConstLabels,
 			},
 			[]string{"namespace"},
 		),
@@ -34,14 +341,214 @@ func NewPodMetrics() *PodMetrics {
 func (m *PodMetrics) Register(registry prometheus.Registerer) {
 	registry.MustRegister(m.deletedTotal)
 	registry.MustRegister(m.skippedTotal)
+	registry.MustRegister(m.reconcilesTotal)
+	registry.MustRegister(m.jobTTLPatchedTotal)
+	registry.MustRegister(m.configuredNamespaceMissing)
+	registry.MustRegister(m.shedding)
+	registry.MustRegister(m.unschedulableDeletedTotal)
+	registry.MustRegister(m.maintenanceActive)
+	registry.MustRegister(m.requeueDrift)
+	registry.MustRegister(m.lastDeletion)
+	registry.MustRegister(m.suspiciousStartTimeTotal)
+	registry.MustRegister(m.trackerEvictionsTotal)
+	registry.MustRegister(m.teamDeletedTotal)
+	registry.MustRegister(m.backoffEntries)
+	registry.MustRegister(m.namespaceTTL)
+	registry.MustRegister(m.updateConflictsTotal)
+	registry.MustRegister(m.heartbeatTotal)
+	registry.MustRegister(m.preservedOverdue)
+	registry.MustRegister(m.evictionBlockedTotal)
+	registry.MustRegister(m.deletedByOwnerKindTotal)
+	registry.MustRegister(m.podInfo)
+	registry.MustRegister(m.nodeNotReadyTotal)
+	registry.MustRegister(m.deleteAPIDuration)
+	registry.MustRegister(m.deferredTotal)
+	registry.MustRegister(m.quarantinedTotal)
+	registry.MustRegister(m.latencyBackoff)
+	registry.MustRegister(m.deleteUnconfirmedTotal)
+}
+
+// SetMirror mirrors the deleted and skipped counters to mirror, in addition
+// to Prometheus
+func (m *PodMetrics) SetMirror(mirror Mirror) {
+	m.mirror = mirror
+}
+
+// IncDeleted increments the deleted counter for a namespace and pod QoS class
+func (m *PodMetrics) IncDeleted(namespace, qos string) {
+	namespace = m.namespaces.label(namespace)
+	m.deletedTotal.WithLabelValues(namespace, qos).Inc()
+	if m.mirror != nil {
+		m.mirror.Incr("evicted_pods_deleted_total", map[string]string{"namespace": namespace, "qos": qos})
+	}
+}
+
+// IncSkipped increments the skipped counter for a namespace and skip reason
+func (m *PodMetrics) IncSkipped(namespace, reason string) {
+	namespace = m.namespaces.label(namespace)
+	m.skippedTotal.WithLabelValues(namespace, reason).Inc()
+	if m.mirror != nil {
+		m.mirror.Incr("evicted_pods_skipped_total", map[string]string{"namespace": namespace, "reason": reason})
+	}
+}
+
+// IncReconcile increments the reconciles counter for a result
+func (m *PodMetrics) IncReconcile(result string) {
+	m.reconcilesTotal.WithLabelValues(result).Inc()
+}
+
+// IncJobTTLPatched increments the Job TTL patched counter for a namespace
+func (m *PodMetrics) IncJobTTLPatched(namespace string) {
+	m.jobTTLPatchedTotal.WithLabelValues(m.namespaces.label(namespace)).Inc()
+}
+
+// SetNamespaceMissing records whether a configured namespace is missing
+func (m *PodMetrics) SetNamespaceMissing(namespace string, missing bool) {
+	value := 0.0
+	if missing {
+		value = 1
+	}
+	m.configuredNamespaceMissing.WithLabelValues(namespace).Set(value)
+}
+
+// SetShedding records whether deletion work is being shed
+func (m *PodMetrics) SetShedding(shedding bool) {
+	value := 0.0
+	if shedding {
+		value = 1
+	}
+	m.shedding.Set(value)
+}
+
+// IncUnschedulableDeleted increments the unschedulable deleted counter for a namespace
+func (m *PodMetrics) IncUnschedulableDeleted(namespace string) {
+	m.unschedulableDeletedTotal.WithLabelValues(m.namespaces.label(namespace)).Inc()
+}
+
+// SetMaintenanceActive records whether a maintenance window is active
+func (m *PodMetrics) SetMaintenanceActive(active bool) {
+	value := 0.0
+	if active {
+		value = 1
+	}
+	m.maintenanceActive.Set(value)
+}
+
+// ObserveRequeueDrift records how late a scheduled requeue fired
+func (m *PodMetrics) ObserveRequeueDrift(drift time.Duration) {
+	m.requeueDrift.Observe(drift.Seconds())
+}
+
+// SetLastDeletion records the time of the most recent deletion for a namespace and reason
+func (m *PodMetrics) SetLastDeletion(namespace, reason string, at time.Time) {
+	m.lastDeletion.WithLabelValues(m.namespaces.label(namespace), reason).Set(float64(at.Unix()))
+}
+
+// IncSuspiciousStartTime increments the suspicious StartTime counter for a namespace
+func (m *PodMetrics) IncSuspiciousStartTime(namespace string) {
+	m.suspiciousStartTimeTotal.WithLabelValues(m.namespaces.label(namespace)).Inc()
+}
+
+// IncTrackerEviction increments the tracking map eviction counter for a tracker
+func (m *PodMetrics) IncTrackerEviction(tracker string) {
+	m.trackerEvictionsTotal.WithLabelValues(tracker).Inc()
+}
+
+// IncTeamDeleted increments the deleted pods counter for a team
+func (m *PodMetrics) IncTeamDeleted(team string) {
+	m.teamDeletedTotal.WithLabelValues(team).Inc()
+}
+
+// SetBackoffEntries sets the number of pods tracked with failed deletions
+func (m *PodMetrics) SetBackoffEntries(n int) {
+	m.backoffEntries.Set(float64(n))
+}
+
+// SetNamespaceTTLs replaces the per-namespace TTL gauges with the given
+// overrides, in seconds
+func (m *PodMetrics) SetNamespaceTTLs(ttls map[string]int) {
+	m.namespaceTTL.Reset()
+	for namespace, ttl := range ttls {
+		m.namespaceTTL.WithLabelValues(namespace).Set(float64(ttl))
+	}
+}
+
+// IncUpdateConflict increments the update conflicts counter
+func (m *PodMetrics) IncUpdateConflict() {
+	m.updateConflictsTotal.Inc()
+}
+
+// IncHeartbeat increments the heartbeat counter
+func (m *PodMetrics) IncHeartbeat() {
+	m.heartbeatTotal.Inc()
+}
+
+// SetPreservedOverdue replaces the preserved overdue pods gauges with the
+// given counts per namespace
+func (m *PodMetrics) SetPreservedOverdue(counts map[string]int) {
+	m.preservedOverdue.Reset()
+	for namespace, n := range counts {
+		// Namespaces folded into the same label add up
+		m.preservedOverdue.WithLabelValues(m.namespaces.label(namespace)).Add(float64(n))
+	}
+}
+
+// IncEvictionBlocked increments the blocked evictions counter for a namespace
+func (m *PodMetrics) IncEvictionBlocked(namespace string) {
+	m.evictionBlockedTotal.WithLabelValues(m.namespaces.label(namespace)).Inc()
+}
+
+// IncDeletedByOwnerKind increments the deleted counter for a top-level owner
+// kind
+func (m *PodMetrics) IncDeletedByOwnerKind(kind string) {
+	m.deletedByOwnerKindTotal.WithLabelValues(kind).Inc()
+}
+
+// ObserveDeleteAPIDuration records how long a pod delete call took
+func (m *PodMetrics) ObserveDeleteAPIDuration(d time.Duration) {
+	m.deleteAPIDuration.Observe(d.Seconds())
+}
+
+// IncNodeNotReady increments the node not ready requeues counter for a node
+func (m *PodMetrics) IncNodeNotReady(node string) {
+	m.nodeNotReadyTotal.WithLabelValues(node).Inc()
+}
+
+// IncDeferred increments the deferred counter for a gate
+func (m *PodMetrics) IncDeferred(reason string) {
+	m.deferredTotal.WithLabelValues(reason).Inc()
+}
+
+// IncQuarantined increments the quarantined counter for a namespace
+func (m *PodMetrics) IncQuarantined(namespace string) {
+	m.quarantinedTotal.WithLabelValues(m.namespaces.label(namespace)).Inc()
+}
+
+// SetLatencyBackoff records whether deletions are backing off due to high
+// delete latency
+func (m *PodMetrics) SetLatencyBackoff(backoff bool) {
+	value := 0.0
+	if backoff {
+		value = 1
+	}
+	m.latencyBackoff.Set(value)
+}
+
+// IncDeleteUnconfirmed increments the delete unconfirmed counter for a
+// namespace
+func (m *PodMetrics) IncDeleteUnconfirmed(namespace string) {
+	m.deleteUnconfirmedTotal.WithLabelValues(m.namespaces.label(namespace)).Inc()
 }
 
-// IncDeleted increments the deleted counter for a namespace
-func (m *PodMetrics) IncDeleted(namespace string) {
-	m.deletedTotal.WithLabelValues(namespace).Inc()
+// SetPodInfo records an evicted pod as tracked, replacing any series it had
+// with a different node or reason. Pods keep their own namespace, as the
+// series identifies them.
+func (m *PodMetrics) SetPodInfo(namespace, pod, node, reason string) {
+	m.podInfo.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "pod": pod})
+	m.podInfo.WithLabelValues(namespace, pod, node, reason).Set(1)
 }
 
-// IncSkipped increments the skipped counter for a namespace
-func (m *PodMetrics) IncSkipped(namespace string) {
-	m.skippedTotal.WithLabelValues(namespace).Inc()
+// DeletePodInfo drops the series of a pod that is no longer tracked
+func (m *PodMetrics) DeletePodInfo(namespace, pod string) {
+	m.podInfo.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "pod": pod})
 }
@@ -1,7 +1,9 @@
 package metrics
 
 import (
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -31,8 +33,8 @@ func TestPodMetrics_Register(t *testing.T) {
 	metrics.Register(registry)
 
 	// Initialize the metrics with a value to ensure they appear in the registry
-	metrics.IncDeleted("test")
-	metrics.IncSkipped("test")
+	metrics.IncDeleted("test", "BestEffort")
+	metrics.IncSkipped("test", SkipPreserved)
 
 	// Verify metrics are registered
 	mfs, err := registry.Gather()
@@ -82,10 +84,10 @@ func TestPodMetrics_IncDeleted(t *testing.T) {
 			metrics.deletedTotal.Reset()
 
 			// Increment the counter
-			metrics.IncDeleted(tt.namespace)
+			metrics.IncDeleted(tt.namespace, "BestEffort")
 
 			// Verify the counter value
-			count := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues(tt.namespace))
+			count := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues(tt.namespace, "BestEffort"))
 			if count != tt.want {
 				t.Errorf("IncDeleted() counter = %v, want %v", count, tt.want)
 			}
@@ -93,6 +95,94 @@ func TestPodMetrics_IncDeleted(t *testing.T) {
 	}
 }
 
+func TestNewPodMetricsWithConfig(t *testing.T) {
+	metrics := NewPodMetricsWithConfig(MetricsConfig{
+		ConstLabels: prometheus.Labels{"service": "reaper"},
+		Help:        map[string]string{"evicted_pods_deleted_total": "Pods reaped"},
+	})
+	registry := prometheus.NewRegistry()
+	metrics.Register(registry)
+
+	metrics.IncDeleted("default", "BestEffort")
+	metrics.IncSkipped("default", SkipPreserved)
+	metrics.IncHeartbeat()
+	metrics.ObserveRequeueDrift(time.Second)
+
+	mfs, err := registry.Gather()
+	if err != nil {
+		t.Fatalf("Failed to gather metrics: %v", err)
+	}
+	if len(mfs) == 0 {
+		t.Fatal("Expected metrics to be gathered")
+	}
+	for _, mf := range mfs {
+		for _, m := range mf.GetMetric() {
+			var service string
+			for _, label := range m.GetLabel() {
+				if label.GetName() == "service" {
+					service = label.GetValue()
+				}
+			}
+			if service != "reaper" {
+				t.Errorf("Expected %s series to have service=reaper, got %q", mf.GetName(), service)
+			}
+		}
+
+		switch mf.GetName() {
+		case "evicted_pods_deleted_total":
+			if mf.GetHelp() != "Pods reaped" {
+				t.Errorf("Expected overridden help text, got %q", mf.GetHelp())
+			}
+		case "evicted_pods_skipped_total":
+			if mf.GetHelp() != "Total number of evicted pods skipped, by reason" {
+				t.Errorf("Expected default help text, got %q", mf.GetHelp())
+			}
+		}
+	}
+}
+
+// recordingMirror captures mirrored increments
+type recordingMirror struct {
+	names []string
+	tags  []map[string]string
+}
+
+func (m *recordingMirror) Incr(name string, tags map[string]string) {
+	m.names = append(m.names, name)
+	m.tags = append(m.tags, tags)
+}
+
+func TestPodMetrics_Mirror(t *testing.T) {
+	metrics := NewPodMetricsWithConfig(MetricsConfig{MaxNamespaces: 1})
+	mirror := &recordingMirror{}
+	metrics.SetMirror(mirror)
+
+	metrics.IncDeleted("default", "BestEffort")
+	metrics.IncSkipped("monitoring", SkipPreserved)
+
+	wantNames := []string{"evicted_pods_deleted_total", "evicted_pods_skipped_total"}
+	wantTags := []map[string]string{
+		{"namespace": "default", "qos": "BestEffort"},
+		{"namespace": OtherNamespace, "reason": SkipPreserved},
+	}
+	if len(mirror.names) != len(wantNames) {
+		t.Fatalf("Expected %d mirrored increments, got %v", len(wantNames), mirror.names)
+	}
+	for i := range wantNames {
+		if mirror.names[i] != wantNames[i] {
+			t.Errorf("Mirrored name %d = %q, want %q", i, mirror.names[i], wantNames[i])
+		}
+		for k, v := range wantTags[i] {
+			if mirror.tags[i][k] != v {
+				t.Errorf("Mirrored tag %s = %q, want %q", k, mirror.tags[i][k], v)
+			}
+		}
+	}
+	if got := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues("default", "BestEffort")); got != 1 {
+		t.Errorf("Expected Prometheus to still count the deletion, got %v", got)
+	}
+}
+
 func TestPodMetrics_IncSkipped(t *testing.T) {
 	metrics := NewPodMetrics()
 	registry := prometheus.NewRegistry()
@@ -101,16 +191,19 @@ func TestPodMetrics_IncSkipped(t *testing.T) {
 	tests := []struct {
 		name      string
 		namespace string
+		reason    string
 		want      float64
 	}{
 		{
 			name:      "increment default namespace",
 			namespace: "default",
+			reason:    SkipPreserved,
 			want:      1,
 		},
 		{
 			name:      "increment monitoring namespace",
 			namespace: "monitoring",
+			reason:    SkipStandalone,
 			want:      1,
 		},
 	}
@@ -121,10 +214,10 @@ func TestPodMetrics_IncSkipped(t *testing.T) {
 			metrics.skippedTotal.Reset()
 
 			// Increment the counter
-			metrics.IncSkipped(tt.namespace)
+			metrics.IncSkipped(tt.namespace, tt.reason)
 
 			// Verify the counter value
-			count := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues(tt.namespace))
+			count := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues(tt.namespace, tt.reason))
 			if count != tt.want {
 				t.Errorf("IncSkipped() counter = %v, want %v", count, tt.want)
 			}
@@ -142,31 +235,38 @@ func TestPodMetrics_MultipleIncrements(t *testing.T) {
 	metrics.skippedTotal.Reset()
 
 	// Increment deleted counter multiple times for same namespace
-	metrics.IncDeleted("default")
-	metrics.IncDeleted("default")
-	metrics.IncDeleted("default")
+	metrics.IncDeleted("default", "BestEffort")
+	metrics.IncDeleted("default", "BestEffort")
+	metrics.IncDeleted("default", "BestEffort")
 
 	// Increment skipped counter multiple times for different namespaces
-	metrics.IncSkipped("default")
-	metrics.IncSkipped("kube-system")
-	metrics.IncSkipped("kube-system")
+	metrics.IncSkipped("default", SkipPreserved)
+	metrics.IncSkipped("kube-system", SkipPreserved)
+	metrics.IncSkipped("kube-system", SkipPreserved)
+	metrics.IncSkipped("kube-system", SkipSafeMode)
 
 	// Verify deleted counter
-	deletedCount := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues("default"))
+	deletedCount := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues("default", "BestEffort"))
 	if deletedCount != 3 {
 		t.Errorf("IncDeleted() multiple calls: got %v, want 3", deletedCount)
 	}
 
 	// Verify skipped counters
-	skippedDefault := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues("default"))
+	skippedDefault := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues("default", SkipPreserved))
 	if skippedDefault != 1 {
 		t.Errorf("IncSkipped() default namespace: got %v, want 1", skippedDefault)
 	}
 
-	skippedKubeSystem := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues("kube-system"))
+	skippedKubeSystem := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues("kube-system", SkipPreserved))
 	if skippedKubeSystem != 2 {
 		t.Errorf("IncSkipped() kube-system namespace: got %v, want 2", skippedKubeSystem)
 	}
+
+	// Reasons are counted separately
+	skippedSafeMode := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues("kube-system", SkipSafeMode))
+	if skippedSafeMode != 1 {
+		t.Errorf("IncSkipped() kube-system safe mode: got %v, want 1", skippedSafeMode)
+	}
 }
 
 func TestPodMetrics_MetricLabels(t *testing.T) {
@@ -175,8 +275,8 @@ func TestPodMetrics_MetricLabels(t *testing.T) {
 	metrics.Register(registry)
 
 	// Increment counters with specific namespaces
-	metrics.IncDeleted("test-namespace")
-	metrics.IncSkipped("another-namespace")
+	metrics.IncDeleted("test-namespace", "Burstable")
+	metrics.IncSkipped("another-namespace", SkipPriority)
 
 	// Gather metrics
 	mfs, err := registry.Gather()
@@ -189,8 +289,8 @@ func TestPodMetrics_MetricLabels(t *testing.T) {
 		if mf.GetName() == "evicted_pods_deleted_total" {
 			for _, m := range mf.GetMetric() {
 				labels := m.GetLabel()
-				if len(labels) != 1 {
-					t.Errorf("Expected 1 label, got %d", len(labels))
+				if len(labels) != 2 {
+					t.Fatalf("Expected 2 labels, got %d", len(labels))
 				}
 				if labels[0].GetName() != "namespace" {
 					t.Errorf("Expected label name 'namespace', got '%s'", labels[0].GetName())
@@ -198,14 +298,17 @@ func TestPodMetrics_MetricLabels(t *testing.T) {
 				if labels[0].GetValue() != "test-namespace" {
 					t.Errorf("Expected label value 'test-namespace', got '%s'", labels[0].GetValue())
 				}
+				if labels[1].GetName() != "qos" || labels[1].GetValue() != "Burstable" {
+					t.Errorf("Expected label qos=Burstable, got %s=%s", labels[1].GetName(), labels[1].GetValue())
+				}
 			}
 		}
 
 		if mf.GetName() == "evicted_pods_skipped_total" {
 			for _, m := range mf.GetMetric() {
 				labels := m.GetLabel()
-				if len(labels) != 1 {
-					t.Errorf("Expected 1 label, got %d", len(labels))
+				if len(labels) != 2 {
+					t.Fatalf("Expected 2 labels, got %d", len(labels))
 				}
 				if labels[0].GetName() != "namespace" {
 					t.Errorf("Expected label name 'namespace', got '%s'", labels[0].GetName())
@@ -213,7 +316,433 @@ func TestPodMetrics_MetricLabels(t *testing.T) {
 				if labels[0].GetValue() != "another-namespace" {
 					t.Errorf("Expected label value 'another-namespace', got '%s'", labels[0].GetValue())
 				}
+				if labels[1].GetName() != "reason" || labels[1].GetValue() != SkipPriority {
+					t.Errorf("Expected label reason=%s, got %s=%s", SkipPriority, labels[1].GetName(), labels[1].GetValue())
+				}
+			}
+		}
+	}
+}
+
+func TestPodMetrics_IncReconcile(t *testing.T) {
+	metrics := NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	metrics.Register(registry)
+
+	metrics.IncReconcile(ReconcileDeleted)
+	metrics.IncReconcile(ReconcileNoop)
+	metrics.IncReconcile(ReconcileNoop)
+
+	if got := testutil.ToFloat64(metrics.reconcilesTotal.WithLabelValues(ReconcileDeleted)); got != 1 {
+		t.Errorf("IncReconcile(%q) counter = %v, want 1", ReconcileDeleted, got)
+	}
+	if got := testutil.ToFloat64(metrics.reconcilesTotal.WithLabelValues(ReconcileNoop)); got != 2 {
+		t.Errorf("IncReconcile(%q) counter = %v, want 2", ReconcileNoop, got)
+	}
+	if got := testutil.ToFloat64(metrics.reconcilesTotal.WithLabelValues(ReconcileError)); got != 0 {
+		t.Errorf("IncReconcile(%q) counter = %v, want 0", ReconcileError, got)
+	}
+}
+
+func TestPodMetrics_IncJobTTLPatched(t *testing.T) {
+	metrics := NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	metrics.Register(registry)
+
+	metrics.IncJobTTLPatched("batch")
+
+	if got := testutil.ToFloat64(metrics.jobTTLPatchedTotal.WithLabelValues("batch")); got != 1 {
+		t.Errorf("IncJobTTLPatched() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_SetNamespaceMissing(t *testing.T) {
+	metrics := NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	metrics.Register(registry)
+
+	metrics.SetNamespaceMissing("typo", true)
+	metrics.SetNamespaceMissing("default", false)
+
+	if got := testutil.ToFloat64(metrics.configuredNamespaceMissing.WithLabelValues("typo")); got != 1 {
+		t.Errorf("SetNamespaceMissing(true) gauge = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(metrics.configuredNamespaceMissing.WithLabelValues("default")); got != 0 {
+		t.Errorf("SetNamespaceMissing(false) gauge = %v, want 0", got)
+	}
+}
+
+func TestPodMetrics_SetShedding(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.SetShedding(true)
+	if got := testutil.ToFloat64(metrics.shedding); got != 1 {
+		t.Errorf("SetShedding(true) gauge = %v, want 1", got)
+	}
+
+	metrics.SetShedding(false)
+	if got := testutil.ToFloat64(metrics.shedding); got != 0 {
+		t.Errorf("SetShedding(false) gauge = %v, want 0", got)
+	}
+}
+
+func TestPodMetrics_IncUnschedulableDeleted(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncUnschedulableDeleted("default")
+	metrics.IncUnschedulableDeleted("default")
+
+	if got := testutil.ToFloat64(metrics.unschedulableDeletedTotal.WithLabelValues("default")); got != 2 {
+		t.Errorf("unschedulable deleted counter = %v, want 2", got)
+	}
+}
+
+func TestPodMetrics_SetMaintenanceActive(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.SetMaintenanceActive(true)
+	if got := testutil.ToFloat64(metrics.maintenanceActive); got != 1 {
+		t.Errorf("SetMaintenanceActive(true) gauge = %v, want 1", got)
+	}
+
+	metrics.SetMaintenanceActive(false)
+	if got := testutil.ToFloat64(metrics.maintenanceActive); got != 0 {
+		t.Errorf("SetMaintenanceActive(false) gauge = %v, want 0", got)
+	}
+}
+
+func TestPodMetrics_SetLastDeletion(t *testing.T) {
+	metrics := NewPodMetrics()
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	metrics.SetLastDeletion("default", "Evicted", at)
+
+	if got := testutil.ToFloat64(metrics.lastDeletion.WithLabelValues("default", "Evicted")); got != float64(at.Unix()) {
+		t.Errorf("SetLastDeletion() gauge = %v, want %v", got, at.Unix())
+	}
+}
+
+func TestPodMetrics_IncSuspiciousStartTime(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncSuspiciousStartTime("default")
+
+	if got := testutil.ToFloat64(metrics.suspiciousStartTimeTotal.WithLabelValues("default")); got != 1 {
+		t.Errorf("IncSuspiciousStartTime() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncTrackerEviction(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncTrackerEviction("observations")
+
+	if got := testutil.ToFloat64(metrics.trackerEvictionsTotal.WithLabelValues("observations")); got != 1 {
+		t.Errorf("IncTrackerEviction() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncTeamDeleted(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncTeamDeleted("payments")
+	metrics.IncTeamDeleted("payments")
+
+	if got := testutil.ToFloat64(metrics.teamDeletedTotal.WithLabelValues("payments")); got != 2 {
+		t.Errorf("IncTeamDeleted() counter = %v, want 2", got)
+	}
+}
+
+func TestPodMetrics_IncDeletedByOwnerKind(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncDeletedByOwnerKind("Deployment")
+	metrics.IncDeletedByOwnerKind("None")
+	metrics.IncDeletedByOwnerKind("Deployment")
+
+	if got := testutil.ToFloat64(metrics.deletedByOwnerKindTotal.WithLabelValues("Deployment")); got != 2 {
+		t.Errorf("IncDeletedByOwnerKind() counter = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(metrics.deletedByOwnerKindTotal.WithLabelValues("None")); got != 1 {
+		t.Errorf("IncDeletedByOwnerKind() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_SetBackoffEntries(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.SetBackoffEntries(3)
+
+	if got := testutil.ToFloat64(metrics.backoffEntries); got != 3 {
+		t.Errorf("SetBackoffEntries() gauge = %v, want 3", got)
+	}
+}
+
+func TestPodMetrics_SetNamespaceTTLs(t *testing.T) {
+	metrics := NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	metrics.Register(registry)
+
+	metrics.SetNamespaceTTLs(map[string]int{"batch": 60, "prod": 86400})
+
+	if got := testutil.ToFloat64(metrics.namespaceTTL.WithLabelValues("batch")); got != 60 {
+		t.Errorf("namespace TTL gauge for batch = %v, want 60", got)
+	}
+	if got := testutil.ToFloat64(metrics.namespaceTTL.WithLabelValues("prod")); got != 86400 {
+		t.Errorf("namespace TTL gauge for prod = %v, want 86400", got)
+	}
+
+	// Replacing the overrides drops namespaces no longer listed
+	metrics.SetNamespaceTTLs(map[string]int{"batch": 120})
+	if got := testutil.CollectAndCount(metrics.namespaceTTL); got != 1 {
+		t.Errorf("Expected 1 namespace TTL series, got %d", got)
+	}
+	if got := testutil.ToFloat64(metrics.namespaceTTL.WithLabelValues("batch")); got != 120 {
+		t.Errorf("namespace TTL gauge for batch = %v, want 120", got)
+	}
+}
+
+func TestPodMetrics_SetPreservedOverdue(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.SetPreservedOverdue(map[string]int{"team-a": 3, "team-b": 1})
+	if got := testutil.ToFloat64(metrics.preservedOverdue.WithLabelValues("team-a")); got != 3 {
+		t.Errorf("preserved overdue gauge for team-a = %v, want 3", got)
+	}
+
+	// Namespaces without overdue pods are dropped on the next sweep
+	metrics.SetPreservedOverdue(map[string]int{"team-a": 2})
+	if got := testutil.CollectAndCount(metrics.preservedOverdue); got != 1 {
+		t.Errorf("Expected 1 preserved overdue series, got %d", got)
+	}
+}
+
+func TestPodMetrics_PodInfo(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.SetPodInfo("default", "web-1", "node-a", "Evicted")
+	metrics.SetPodInfo("default", "web-2", "node-b", "Evicted")
+	if got := testutil.ToFloat64(metrics.podInfo.WithLabelValues("default", "web-1", "node-a", "Evicted")); got != 1 {
+		t.Errorf("pod info gauge for web-1 = %v, want 1", got)
+	}
+
+	// Setting a pod again replaces its series instead of adding one
+	metrics.SetPodInfo("default", "web-1", "node-c", "Evicted")
+	if got := testutil.CollectAndCount(metrics.podInfo); got != 2 {
+		t.Errorf("Expected 2 pod info series, got %d", got)
+	}
+
+	metrics.DeletePodInfo("default", "web-1")
+	metrics.DeletePodInfo("default", "missing")
+	if got := testutil.CollectAndCount(metrics.podInfo); got != 1 {
+		t.Errorf("Expected 1 pod info series after deleting web-1, got %d", got)
+	}
+	if got := testutil.ToFloat64(metrics.podInfo.WithLabelValues("default", "web-2", "node-b", "Evicted")); got != 1 {
+		t.Errorf("pod info gauge for web-2 = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncEvictionBlocked(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncEvictionBlocked("default")
+
+	if got := testutil.ToFloat64(metrics.evictionBlockedTotal.WithLabelValues("default")); got != 1 {
+		t.Errorf("IncEvictionBlocked() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncNodeNotReady(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncNodeNotReady("node-a")
+
+	if got := testutil.ToFloat64(metrics.nodeNotReadyTotal.WithLabelValues("node-a")); got != 1 {
+		t.Errorf("IncNodeNotReady() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncDeferred(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncDeferred(DeferMaintenance)
+	metrics.IncDeferred(DeferMaintenance)
+	metrics.IncDeferred(DeferStartupGrace)
+
+	if got := testutil.ToFloat64(metrics.deferredTotal.WithLabelValues(DeferMaintenance)); got != 2 {
+		t.Errorf("IncDeferred() maintenance counter = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(metrics.deferredTotal.WithLabelValues(DeferStartupGrace)); got != 1 {
+		t.Errorf("IncDeferred() startup grace counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncQuarantined(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncQuarantined("default")
+
+	if got := testutil.ToFloat64(metrics.quarantinedTotal.WithLabelValues("default")); got != 1 {
+		t.Errorf("IncQuarantined() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_SetLatencyBackoff(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.SetLatencyBackoff(true)
+	if got := testutil.ToFloat64(metrics.latencyBackoff); got != 1 {
+		t.Errorf("SetLatencyBackoff(true) gauge = %v, want 1", got)
+	}
+
+	metrics.SetLatencyBackoff(false)
+	if got := testutil.ToFloat64(metrics.latencyBackoff); got != 0 {
+		t.Errorf("SetLatencyBackoff(false) gauge = %v, want 0", got)
+	}
+}
+
+func TestPodMetrics_IncDeleteUnconfirmed(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncDeleteUnconfirmed("default")
+
+	if got := testutil.ToFloat64(metrics.deleteUnconfirmedTotal.WithLabelValues("default")); got != 1 {
+		t.Errorf("IncDeleteUnconfirmed() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncUpdateConflict(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncUpdateConflict()
+
+	if got := testutil.ToFloat64(metrics.updateConflictsTotal); got != 1 {
+		t.Errorf("IncUpdateConflict() counter = %v, want 1", got)
+	}
+}
+
+func TestPodMetrics_IncHeartbeat(t *testing.T) {
+	metrics := NewPodMetrics()
+
+	metrics.IncHeartbeat()
+	metrics.IncHeartbeat()
+
+	if got := testutil.ToFloat64(metrics.heartbeatTotal); got != 2 {
+		t.Errorf("IncHeartbeat() counter = %v, want 2", got)
+	}
+}
+
+func TestPodMetrics_Concurrent(t *testing.T) {
+	metrics := NewPodMetrics()
+	registry := prometheus.NewRegistry()
+	metrics.Register(registry)
+
+	const goroutines, increments = 50, 200
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < increments; j++ {
+				metrics.IncDeleted("default", "BestEffort")
+				metrics.IncSkipped("default", SkipPreserved)
+				metrics.IncReconcile(ReconcileDeleted)
+				metrics.IncTeamDeleted("payments")
+				metrics.IncUpdateConflict()
+				metrics.IncHeartbeat()
+				metrics.SetBackoffEntries(j)
+				metrics.SetNamespaceTTLs(map[string]int{"batch": j})
 			}
+		}()
+	}
+	wg.Wait()
+
+	want := float64(goroutines * increments)
+	counters := map[string]float64{
+		"deleted":          testutil.ToFloat64(metrics.deletedTotal.WithLabelValues("default", "BestEffort")),
+		"skipped":          testutil.ToFloat64(metrics.skippedTotal.WithLabelValues("default", SkipPreserved)),
+		"reconciles":       testutil.ToFloat64(metrics.reconcilesTotal.WithLabelValues(ReconcileDeleted)),
+		"team deleted":     testutil.ToFloat64(metrics.teamDeletedTotal.WithLabelValues("payments")),
+		"update conflicts": testutil.ToFloat64(metrics.updateConflictsTotal),
+		"heartbeats":       testutil.ToFloat64(metrics.heartbeatTotal),
+	}
+	for name, got := range counters {
+		if got != want {
+			t.Errorf("%s counter = %v, want %v", name, got, want)
 		}
 	}
+	if _, err := registry.Gather(); err != nil {
+		t.Errorf("Failed to gather metrics: %v", err)
+	}
+}
+
+func TestPodMetrics_MaxNamespaces(t *testing.T) {
+	metrics := NewPodMetricsWithConfig(MetricsConfig{MaxNamespaces: 2})
+
+	for _, namespace := range []string{"a", "b", "c", "a", "d", "c"} {
+		metrics.IncDeleted(namespace, "BestEffort")
+	}
+
+	want := map[string]float64{"a": 2, "b": 1, OtherNamespace: 3}
+	for namespace, count := range want {
+		if got := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues(namespace, "BestEffort")); got != count {
+			t.Errorf("Expected %v deletions for %q, got %v", count, namespace, got)
+		}
+	}
+	if n := testutil.CollectAndCount(metrics.deletedTotal); n != 3 {
+		t.Errorf("Expected 3 series, got %d", n)
+	}
+
+	// Namespaces are capped across metrics, not per metric
+	metrics.IncSkipped("c", SkipPreserved)
+	if got := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues(OtherNamespace, SkipPreserved)); got != 1 {
+		t.Errorf("Expected the skip to fold into %q, got %v", OtherNamespace, got)
+	}
+
+	// Folded gauges add up
+	metrics.SetPreservedOverdue(map[string]int{"a": 1, "c": 2, "d": 3})
+	if got := testutil.ToFloat64(metrics.preservedOverdue.WithLabelValues(OtherNamespace)); got != 5 {
+		t.Errorf("Expected 5 preserved overdue pods in %q, got %v", OtherNamespace, got)
+	}
+}
+
+func TestPodMetrics_NoMaxNamespaces(t *testing.T) {
+	metrics := NewPodMetrics()
+	for _, namespace := range []string{"a", "b", "c"} {
+		metrics.IncDeleted(namespace, "BestEffort")
+	}
+	if n := testutil.CollectAndCount(metrics.deletedTotal); n != 3 {
+		t.Errorf("Expected 3 series without a cap, got %d", n)
+	}
+}
+
+func TestPodMetrics_NamespaceAllowlist(t *testing.T) {
+	metrics := NewPodMetricsWithConfig(MetricsConfig{NamespaceAllowlist: []string{"prod", "staging"}})
+
+	for _, namespace := range []string{"prod", "team-a", "staging", "team-b", "prod"} {
+		metrics.IncDeleted(namespace, "BestEffort")
+		metrics.IncSkipped(namespace, SkipPreserved)
+	}
+
+	want := map[string]float64{"prod": 2, "staging": 1, OtherNamespace: 2}
+	for namespace, count := range want {
+		if got := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues(namespace, "BestEffort")); got != count {
+			t.Errorf("Expected %v deletions for %q, got %v", count, namespace, got)
+		}
+		if got := testutil.ToFloat64(metrics.skippedTotal.WithLabelValues(namespace, SkipPreserved)); got != count {
+			t.Errorf("Expected %v skips for %q, got %v", count, namespace, got)
+		}
+	}
+	if n := testutil.CollectAndCount(metrics.deletedTotal); n != 3 {
+		t.Errorf("Expected 3 series, got %d", n)
+	}
+
+	// The cap applies on top of the allowlist
+	metrics = NewPodMetricsWithConfig(MetricsConfig{MaxNamespaces: 1, NamespaceAllowlist: []string{"prod", "staging"}})
+	for _, namespace := range []string{"prod", "staging", "team-a"} {
+		metrics.IncDeleted(namespace, "BestEffort")
+	}
+	if got := testutil.ToFloat64(metrics.deletedTotal.WithLabelValues(OtherNamespace, "BestEffort")); got != 2 {
+		t.Errorf("Expected 2 deletions for %q, got %v", OtherNamespace, got)
+	}
 }
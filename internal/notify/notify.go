@@ -0,0 +1,150 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultBatchWindow is how long deletions sharing an owner are
+	// coalesced before a notification is sent
+	DefaultBatchWindow = 30 * time.Second
+
+	sendTimeout = 10 * time.Second
+)
+
+// Event describes a reaped pod
+type Event struct {
+	Namespace string
+	Name      string
+	Reason    string
+	OwnerKind string
+	OwnerName string
+
+	// Annotations are pod annotations echoed into the notification
+	Annotations map[string]string
+}
+
+// Sender delivers a notification message
+type Sender interface {
+	Send(ctx context.Context, message string) error
+}
+
+// Notifier sends notifications for reaped pods. Pods sharing an owner that
+// are reaped within the batch window are coalesced into one notification.
+type Notifier struct {
+	sender Sender
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*batch
+}
+
+type batch struct {
+	events []Event
+	timer  *time.Timer
+}
+
+// NewNotifier creates a Notifier delivering through sender, batching
+// same-owner events within window
+func NewNotifier(sender Sender, window time.Duration) *Notifier {
+	return &Notifier{
+		sender:  sender,
+		window:  window,
+		pending: make(map[string]*batch),
+	}
+}
+
+// PodReaped records a reaped pod, to be notified once its batch window ends
+func (n *Notifier) PodReaped(e Event) {
+	key := batchKey(e)
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	b, ok := n.pending[key]
+	if !ok {
+		b = &batch{}
+		b.timer = time.AfterFunc(n.window, func() { n.flush(key) })
+		n.pending[key] = b
+	}
+	b.events = append(b.events, e)
+}
+
+// Flush immediately sends all pending notifications
+func (n *Notifier) Flush() {
+	n.mu.Lock()
+	keys := make([]string, 0, len(n.pending))
+	for key, b := range n.pending {
+		b.timer.Stop()
+		keys = append(keys, key)
+	}
+	n.mu.Unlock()
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		n.flush(key)
+	}
+}
+
+func (n *Notifier) flush(key string) {
+	n.mu.Lock()
+	b, ok := n.pending[key]
+	delete(n.pending, key)
+	n.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	if err := n.sender.Send(ctx, message(b.events)); err != nil {
+		log.FromContext(ctx).WithName("notify").Error(err, "unable to send notification", "batch", key)
+	}
+}
+
+// batchKey groups events by owner. Ownerless pods are never batched.
+func batchKey(e Event) string {
+	if e.OwnerKind == "" {
+		return "Pod/" + e.Namespace + "/" + e.Name
+	}
+	return e.OwnerKind + "/" + e.Namespace + "/" + e.OwnerName
+}
+
+func message(events []Event) string {
+	first := events[0]
+	if len(events) == 1 {
+		msg := fmt.Sprintf("Reaped evicted pod %s/%s", first.Namespace, first.Name)
+		if first.OwnerKind != "" {
+			msg += fmt.Sprintf(" (owner %s %s)", first.OwnerKind, first.OwnerName)
+		}
+		return msg + formatAnnotations(first.Annotations)
+	}
+
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Name + formatAnnotations(e.Annotations)
+	}
+	return fmt.Sprintf("%d pods reaped for %s %s/%s: %s",
+		len(events), first.OwnerKind, first.Namespace, first.OwnerName, strings.Join(names, ", "))
+}
+
+// formatAnnotations renders annotations as " [key=value, ...]" sorted by
+// key, or nothing if there are none
+func formatAnnotations(annotations map[string]string) string {
+	if len(annotations) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return " [" + strings.Join(pairs, ", ") + "]"
+}
@@ -0,0 +1,132 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingSender captures sent messages
+type recordingSender struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (s *recordingSender) Send(ctx context.Context, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, message)
+	return nil
+}
+
+func (s *recordingSender) sent() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	messages := append([]string(nil), s.messages...)
+	sort.Strings(messages)
+	return messages
+}
+
+func TestNotifier_BatchesSameOwner(t *testing.T) {
+	sender := &recordingSender{}
+	n := NewNotifier(sender, 50*time.Millisecond)
+
+	for _, name := range []string{"web-1", "web-2", "web-3"} {
+		n.PodReaped(Event{Namespace: "default", Name: name, OwnerKind: "ReplicaSet", OwnerName: "web"})
+	}
+
+	if got := sender.sent(); len(got) != 0 {
+		t.Fatalf("Expected no notification before the batch window ends, got %v", got)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	got := sender.sent()
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 batched notification, got %d: %v", len(got), got)
+	}
+	want := "3 pods reaped for ReplicaSet default/web: web-1, web-2, web-3"
+	if got[0] != want {
+		t.Errorf("notification = %q, want %q", got[0], want)
+	}
+}
+
+func TestNotifier_SeparatesOwners(t *testing.T) {
+	sender := &recordingSender{}
+	n := NewNotifier(sender, time.Hour)
+
+	n.PodReaped(Event{Namespace: "default", Name: "web-1", OwnerKind: "ReplicaSet", OwnerName: "web"})
+	n.PodReaped(Event{Namespace: "default", Name: "api-1", OwnerKind: "ReplicaSet", OwnerName: "api"})
+	n.PodReaped(Event{Namespace: "default", Name: "standalone-1"})
+	n.PodReaped(Event{Namespace: "default", Name: "standalone-2"})
+	n.Flush()
+
+	got := sender.sent()
+	want := []string{
+		"Reaped evicted pod default/api-1 (owner ReplicaSet api)",
+		"Reaped evicted pod default/standalone-1",
+		"Reaped evicted pod default/standalone-2",
+		"Reaped evicted pod default/web-1 (owner ReplicaSet web)",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d notifications, got %d: %v", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("notification[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestWebhookSender_Send(t *testing.T) {
+	var received map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&received)
+		if strings.Contains(received["text"], "fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	sender := &WebhookSender{URL: server.URL}
+
+	if err := sender.Send(context.Background(), "hello"); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if received["text"] != "hello" {
+		t.Errorf("webhook received text = %q, want %q", received["text"], "hello")
+	}
+
+	if err := sender.Send(context.Background(), "fail"); err == nil {
+		t.Error("Send() expected an error for a non-2xx response")
+	}
+}
+
+func TestNotifier_Annotations(t *testing.T) {
+	sender := &recordingSender{}
+	n := NewNotifier(sender, time.Hour)
+
+	n.PodReaped(Event{
+		Namespace:   "default",
+		Name:        "web-1",
+		OwnerKind:   "ReplicaSet",
+		OwnerName:   "web",
+		Annotations: map[string]string{"team": "payments", "app.kubernetes.io/name": "web"},
+	})
+	n.Flush()
+
+	got := sender.sent()
+	want := "Reaped evicted pod default/web-1 (owner ReplicaSet web) [app.kubernetes.io/name=web, team=payments]"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("notifications = %v, want [%q]", got, want)
+	}
+}
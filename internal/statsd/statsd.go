@@ -0,0 +1,96 @@
+package statsd
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// queueSize bounds how many increments wait to be written before new ones
+// are dropped, so a slow or unreachable daemon never blocks a reconcile
+const queueSize = 1024
+
+// Client sends counter increments to a StatsD daemon over UDP, with
+// DogStatsD-style tags. Sends are fire and forget: write errors, such as an
+// unreachable daemon, are ignored.
+type Client struct {
+	conn  net.Conn
+	queue chan string
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// New creates a Client sending to address, such as "localhost:8125". It
+// only fails if the address can't be resolved.
+func New(address string) (*Client, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		conn:  conn,
+		queue: make(chan string, queueSize),
+		done:  make(chan struct{}),
+	}
+	go c.run()
+	return c, nil
+}
+
+// Incr queues an increment of the counter name by one, dropping it if the
+// queue is full
+func (c *Client) Incr(name string, tags map[string]string) {
+	select {
+	case c.queue <- formatIncr(name, tags):
+	default:
+	}
+}
+
+// Close writes the queued increments and closes the connection. Incr must
+// not be called afterwards.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() { close(c.queue) })
+	<-c.done
+	return c.conn.Close()
+}
+
+func (c *Client) run() {
+	defer close(c.done)
+	for line := range c.queue {
+		_, _ = c.conn.Write([]byte(line))
+	}
+}
+
+// formatIncr formats an increment by one as a StatsD counter line, with tags
+// sorted by name, e.g. "evicted_pods_deleted_total:1|c|#namespace:default"
+func formatIncr(name string, tags map[string]string) string {
+	var b strings.Builder
+	b.WriteString(sanitize(name))
+	b.WriteString(":1|c")
+	if len(tags) == 0 {
+		return b.String()
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	b.WriteString("|#")
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(sanitize(k))
+		b.WriteByte(':')
+		b.WriteString(sanitize(tags[k]))
+	}
+	return b.String()
+}
+
+// reserved replaces the characters with a meaning in the StatsD line format
+var reserved = strings.NewReplacer(":", "_", "|", "_", ",", "_", "#", "_", "\n", "_")
+
+func sanitize(s string) string {
+	return reserved.Replace(s)
+}
@@ -0,0 +1,99 @@
+package statsd
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestFormatIncr(t *testing.T) {
+	tests := []struct {
+		name   string
+		metric string
+		tags   map[string]string
+		want   string
+	}{
+		{
+			name:   "no tags",
+			metric: "evicted_pods_deleted_total",
+			want:   "evicted_pods_deleted_total:1|c",
+		},
+		{
+			name:   "tags are sorted",
+			metric: "evicted_pods_skipped_total",
+			tags:   map[string]string{"reason": "preserved", "namespace": "default"},
+			want:   "evicted_pods_skipped_total:1|c|#namespace:default,reason:preserved",
+		},
+		{
+			name:   "reserved characters are replaced",
+			metric: "odd:name",
+			tags:   map[string]string{"team": "a|b,c#d"},
+			want:   "odd_name:1|c|#team:a_b_c_d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatIncr(tt.metric, tt.tags); got != tt.want {
+				t.Errorf("formatIncr() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_Incr(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer func() { _ = conn.Close() }()
+
+	c, err := New(conn.LocalAddr().String())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	c.Incr("evicted_pods_deleted_total", map[string]string{"namespace": "default", "qos": "BestEffort"})
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 512)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read: %v", err)
+	}
+	if got, want := string(buf[:n]), "evicted_pods_deleted_total:1|c|#namespace:default,qos:BestEffort"; got != want {
+		t.Errorf("Received %q, want %q", got, want)
+	}
+}
+
+func TestClient_Unreachable(t *testing.T) {
+	// Nothing listens on port 1, writes fail with connection refused
+	c, err := New("127.0.0.1:1")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10*queueSize; i++ {
+			c.Incr("evicted_pods_deleted_total", nil)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected Incr not to block on an unreachable daemon")
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestNew_InvalidAddress(t *testing.T) {
+	if _, err := New("no-port"); err == nil {
+		t.Error("Expected an error for an address without a port")
+	}
+}